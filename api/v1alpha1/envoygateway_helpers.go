@@ -6,8 +6,10 @@
 package v1alpha1
 
 import (
+	"io/fs"
 	"net"
 	"strconv"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -77,6 +79,15 @@ func (e *EnvoyGateway) GetEnvoyGatewayAdmin() *EnvoyGatewayAdmin {
 	return e.Admin
 }
 
+// GetHistoryLimit returns the number of revisions of the xDS IR and xDS resources of each
+// Gateway retained by the admin server.
+func (a *EnvoyGatewayAdmin) GetHistoryLimit() int {
+	if a == nil || a.HistoryLimit == nil {
+		return DefaultAdminHistoryLimit
+	}
+	return int(*a.HistoryLimit)
+}
+
 // GetEnvoyGatewayAdminAddress returns the EnvoyGateway Admin Address.
 func (e *EnvoyGateway) GetEnvoyGatewayAdminAddress() string {
 	address := e.GetEnvoyGatewayAdmin().Address
@@ -138,11 +149,43 @@ func (e *EnvoyGateway) GetEnvoyGatewayTelemetry() *EnvoyGatewayTelemetry {
 	return e.Telemetry
 }
 
+// GetEnvoyGatewayTracing returns the EnvoyGatewayTracing of EnvoyGateway, or nil if tracing is disabled.
+func (e *EnvoyGateway) GetEnvoyGatewayTracing() *EnvoyGatewayTracing {
+	if e == nil || e.Telemetry == nil {
+		return nil
+	}
+	return e.Telemetry.Tracing
+}
+
+// GetSamplingRate returns the percentage of the provider updates whose translation is traced.
+func (t *EnvoyGatewayTracing) GetSamplingRate() float64 {
+	if t == nil || t.SamplingRate == nil {
+		return DefaultTracingSamplingRate
+	}
+	return float64(*t.SamplingRate)
+}
+
 // DisablePrometheus returns if disable prometheus.
 func (e *EnvoyGateway) DisablePrometheus() bool {
 	return e.GetEnvoyGatewayTelemetry().Metrics.Prometheus.Disable
 }
 
+// GetExtensionManagers returns the extension managers registered to EnvoyGateway, in the order
+// their hooks are invoked.
+func (e *EnvoyGateway) GetExtensionManagers() []*ExtensionManager {
+	if e == nil {
+		return nil
+	}
+	if e.ExtensionManager != nil {
+		return []*ExtensionManager{e.ExtensionManager}
+	}
+	ret := make([]*ExtensionManager, 0, len(e.ExtensionManagers))
+	for i := range e.ExtensionManagers {
+		ret = append(ret, &e.ExtensionManagers[i])
+	}
+	return ret
+}
+
 // DefaultEnvoyGatewayTelemetry returns a new EnvoyGatewayTelemetry with default configuration parameters.
 func DefaultEnvoyGatewayTelemetry() *EnvoyGatewayTelemetry {
 	return &EnvoyGatewayTelemetry{
@@ -256,6 +299,134 @@ func (r *EnvoyGatewayProvider) IsRunningOnHost() bool {
 		r.Custom.Infrastructure.Type == InfrastructureProviderTypeHost
 }
 
+// GetHostInfrastructureProvider returns the EnvoyGatewayHostInfrastructureProvider of Provider,
+// or nil if Envoy Gateway is not running on the host.
+func (r *EnvoyGatewayProvider) GetHostInfrastructureProvider() *EnvoyGatewayHostInfrastructureProvider {
+	if !r.IsRunningOnHost() {
+		return nil
+	}
+	return r.Custom.Infrastructure.Host
+}
+
+// GetCertificatesDir returns the directory of the certificates used on the host,
+// or the default directory if unspecified.
+func (h *EnvoyGatewayHostInfrastructureProvider) GetCertificatesDir() string {
+	if h == nil || h.CertificatesDir == nil {
+		return DefaultHostCertificatesDir
+	}
+	return *h.CertificatesDir
+}
+
+// GetRateLimitBinary returns the binary of the rate limit service run on the host,
+// or the default binary if unspecified.
+func (h *EnvoyGatewayHostInfrastructureProvider) GetRateLimitBinary() string {
+	if h == nil || h.RateLimit == nil || h.RateLimit.Binary == nil {
+		return DefaultHostRateLimitBinary
+	}
+	return *h.RateLimit.Binary
+}
+
+// GetRateLimitPort returns the gRPC port of the rate limit service run on the host,
+// or the default port if unspecified.
+func (h *EnvoyGatewayHostInfrastructureProvider) GetRateLimitPort() int32 {
+	if h == nil || h.RateLimit == nil || h.RateLimit.Port == nil {
+		return DefaultHostRateLimitPort
+	}
+	return *h.RateLimit.Port
+}
+
+// GetProxyLogsMaxSizeMegabytes returns the max size in megabytes of a proxy log file
+// written on the host, or the default size if unspecified.
+func (h *EnvoyGatewayHostInfrastructureProvider) GetProxyLogsMaxSizeMegabytes() uint32 {
+	if h == nil || h.ProxyLogs == nil || h.ProxyLogs.MaxSizeMegabytes == nil {
+		return DefaultHostProxyLogsMaxSizeMegabytes
+	}
+	return *h.ProxyLogs.MaxSizeMegabytes
+}
+
+// GetProxyLogsMaxBackups returns the max number of rotated proxy log files retained
+// on the host, or the default number if unspecified.
+func (h *EnvoyGatewayHostInfrastructureProvider) GetProxyLogsMaxBackups() uint32 {
+	if h == nil || h.ProxyLogs == nil || h.ProxyLogs.MaxBackups == nil {
+		return DefaultHostProxyLogsMaxBackups
+	}
+	return *h.ProxyLogs.MaxBackups
+}
+
+// RollbackOnNACKEnabled returns whether the xDS server serves the last snapshot acknowledged
+// by all the proxies of a Gateway when a newer snapshot is rejected.
+func (x *EnvoyGatewayXDSServer) RollbackOnNACKEnabled() bool {
+	return x != nil && x.RollbackOnNACK != nil && *x.RollbackOnNACK
+}
+
+// GetRollout returns the staged rollout settings of the xDS server, or nil if the new
+// snapshots are pushed to all the proxies at once.
+func (x *EnvoyGatewayXDSServer) GetRollout() *XDSRollout {
+	if x == nil {
+		return nil
+	}
+	return x.Rollout
+}
+
+// GetAddress returns the address the xDS server listens on, or the default address if unspecified.
+func (x *EnvoyGatewayXDSServer) GetAddress() string {
+	if x == nil || x.Address == nil {
+		return DefaultXDSServerAddress
+	}
+	return *x.Address
+}
+
+// GetPort returns the port the xDS server listens on, or the default port if unspecified.
+func (x *EnvoyGatewayXDSServer) GetPort() int32 {
+	if x == nil || x.Port == nil {
+		return DefaultXDSServerPort
+	}
+	return *x.Port
+}
+
+// GetUnixSocket returns the Unix domain socket the xDS server listens on, or nil if it
+// listens on TCP.
+func (x *EnvoyGatewayXDSServer) GetUnixSocket() *XDSUnixSocket {
+	if x == nil {
+		return nil
+	}
+	return x.UnixSocket
+}
+
+// GetTLS returns the paths of the certificates of the xDS server, or nil if unspecified.
+func (x *EnvoyGatewayXDSServer) GetTLS() *XDSServerTLS {
+	if x == nil {
+		return nil
+	}
+	return x.TLS
+}
+
+// GetMode returns the file permissions of the socket, or the default permissions if
+// unspecified or invalid.
+func (u *XDSUnixSocket) GetMode() fs.FileMode {
+	if u == nil || u.Mode == nil {
+		return DefaultXDSUnixSocketMode
+	}
+	mode, err := strconv.ParseUint(*u.Mode, 8, 32)
+	if err != nil {
+		return DefaultXDSUnixSocketMode
+	}
+	return fs.FileMode(mode)
+}
+
+// GetBakeDuration returns the bake duration of the staged rollout, or the default duration
+// if unspecified or invalid.
+func (r *XDSRollout) GetBakeDuration() time.Duration {
+	if r == nil || r.BakeDuration == nil {
+		return DefaultXDSRolloutBakeDuration
+	}
+	d, err := time.ParseDuration(string(*r.BakeDuration))
+	if err != nil {
+		return DefaultXDSRolloutBakeDuration
+	}
+	return d
+}
+
 // DefaultEnvoyGatewayLoggingLevel returns a new EnvoyGatewayLogging with default configuration parameters.
 // When v1alpha1.LogComponentGatewayDefault specified, all other logging components are ignored.
 func (logging *EnvoyGatewayLogging) DefaultEnvoyGatewayLoggingLevel(level LogLevel) LogLevel {
@@ -6,6 +6,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -24,6 +26,28 @@ const (
 	GatewayMetricsPort = 19001
 	// GatewayMetricsHost is the host of envoy gateway metrics server.
 	GatewayMetricsHost = "0.0.0.0"
+	// DefaultHostCertificatesDir is the default directory of the certificates used by the Host infrastructure provider.
+	DefaultHostCertificatesDir = "/tmp/envoy-gateway/certs"
+	// DefaultHostRateLimitBinary is the default binary of the rate limit service run by the Host infrastructure provider.
+	DefaultHostRateLimitBinary = "ratelimit"
+	// DefaultHostRateLimitPort is the default gRPC port of the rate limit service run by the Host infrastructure provider.
+	DefaultHostRateLimitPort = 8081
+	// DefaultHostProxyLogsMaxSizeMegabytes is the default max size of a proxy log file written by the Host infrastructure provider.
+	DefaultHostProxyLogsMaxSizeMegabytes = 100
+	// DefaultHostProxyLogsMaxBackups is the default number of rotated proxy log files retained by the Host infrastructure provider.
+	DefaultHostProxyLogsMaxBackups = 5
+	// DefaultXDSRolloutBakeDuration is the default duration the canary proxies run a new xDS snapshot before it's pushed to the other proxies.
+	DefaultXDSRolloutBakeDuration = 30 * time.Second
+	// DefaultAdminHistoryLimit is the default number of revisions of the xDS IR and xDS resources of each Gateway retained by the admin server.
+	DefaultAdminHistoryLimit = 10
+	// DefaultTracingSamplingRate is the default percentage of the provider updates whose translation is traced.
+	DefaultTracingSamplingRate = 100
+	// DefaultXDSServerAddress is the default address the xDS server listens on.
+	DefaultXDSServerAddress = "0.0.0.0"
+	// DefaultXDSServerPort is the default port the xDS server listens on.
+	DefaultXDSServerPort = 18000
+	// DefaultXDSUnixSocketMode is the default file permissions of the Unix domain socket of the xDS server.
+	DefaultXDSUnixSocketMode = 0o600
 )
 
 // +kubebuilder:object:root=true
@@ -80,15 +104,138 @@ type EnvoyGatewaySpec struct {
 	RateLimit *RateLimit `json:"rateLimit,omitempty"`
 
 	// ExtensionManager defines an extension manager to register for the Envoy Gateway Control Plane.
+	// It's mutually exclusive with ExtensionManagers.
 	//
 	// +optional
 	ExtensionManager *ExtensionManager `json:"extensionManager,omitempty"`
 
+	// ExtensionManagers defines a list of extension managers to register for the Envoy Gateway Control Plane.
+	// The hooks of the extensions are invoked in the order of the list, where each hook receives the output
+	// of the same hook of the previous extension. It's mutually exclusive with ExtensionManager.
+	//
+	// +optional
+	ExtensionManagers []ExtensionManager `json:"extensionManagers,omitempty"`
+
 	// ExtensionAPIs defines the settings related to specific Gateway API Extensions
 	// implemented by Envoy Gateway
 	//
 	// +optional
 	ExtensionAPIs *ExtensionAPISettings `json:"extensionApis,omitempty"`
+
+	// XDSServer defines the settings of the xDS server which serves the configuration
+	// of the Envoy proxies.
+	//
+	// +optional
+	XDSServer *EnvoyGatewayXDSServer `json:"xdsServer,omitempty"`
+}
+
+// EnvoyGatewayXDSServer defines the settings of the xDS server.
+type EnvoyGatewayXDSServer struct {
+	// RollbackOnNACK enables serving the last xDS snapshot acknowledged by all the proxies
+	// of a Gateway when one of them rejects a newer snapshot. The proxies connecting
+	// afterwards receive the last acknowledged snapshot too, until a new snapshot is generated.
+	// Disabled by default.
+	//
+	// +optional
+	RollbackOnNACK *bool `json:"rollbackOnNACK,omitempty"`
+
+	// Rollout defines the staged rollout of the new xDS snapshots to the proxies of a Gateway.
+	// If unspecified, the new snapshots are pushed to all the proxies at once.
+	//
+	// +optional
+	Rollout *XDSRollout `json:"rollout,omitempty"`
+
+	// Address is the address the xDS server listens on. Defaults to 0.0.0.0.
+	// It's ignored if the xDS server listens on a Unix domain socket.
+	//
+	// +optional
+	Address *string `json:"address,omitempty"`
+
+	// Port is the port the xDS server listens on, and the proxies connect to. Defaults to 18000.
+	// It's ignored if the xDS server listens on a Unix domain socket. The Kubernetes provider
+	// only supports the default port, which is exposed by the Service of Envoy Gateway.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// UnixSocket configures the xDS server to listen on a Unix domain socket rather than on TCP.
+	// The proxies are authorized by the file permissions of the socket rather than by mutual TLS,
+	// so it's only supported by the Host infrastructure provider, whose proxies run on the same
+	// host as Envoy Gateway.
+	//
+	// +optional
+	UnixSocket *XDSUnixSocket `json:"unixSocket,omitempty"`
+
+	// TLS defines the paths of the certificates of the xDS server. The certificates of the
+	// infrastructure provider are used if unspecified.
+	//
+	// +optional
+	TLS *XDSServerTLS `json:"tls,omitempty"`
+}
+
+// XDSUnixSocket defines the Unix domain socket the xDS server listens on.
+type XDSUnixSocket struct {
+	// Path is the path of the socket. A file left at the path by a previous run is removed.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// Mode is the file permissions of the socket, in octal, which restrict the users whose
+	// proxies can connect to the xDS server. Defaults to 0600, which only allows the user
+	// running Envoy Gateway.
+	//
+	// +kubebuilder:validation:Pattern=`^0?[0-7]{3}$`
+	// +optional
+	Mode *string `json:"mode,omitempty"`
+}
+
+// XDSServerTLS defines the paths of the certificates of the xDS server.
+type XDSServerTLS struct {
+	// CertPath is the path of the TLS certificate of the xDS server.
+	//
+	// +optional
+	CertPath *string `json:"certPath,omitempty"`
+
+	// KeyPath is the path of the TLS key of the xDS server.
+	//
+	// +optional
+	KeyPath *string `json:"keyPath,omitempty"`
+
+	// CAPath is the path of the CA certificate trusted to sign the certificates of the proxies.
+	//
+	// +optional
+	CAPath *string `json:"caPath,omitempty"`
+}
+
+// XDSRollout defines the staged rollout of the new xDS snapshots. A new snapshot is pushed to
+// the canary proxies first, and to the other proxies once the canaries ran it for the bake
+// duration without rejecting it and without exceeding the maximum error rate. Otherwise the
+// rollout is held until a newer snapshot is generated.
+type XDSRollout struct {
+	// CanaryPercentage is the percentage of the proxies of a Gateway which receive a new
+	// snapshot first. At least one proxy is a canary.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	CanaryPercentage uint32 `json:"canaryPercentage"`
+
+	// BakeDuration is how long the canary proxies run a new snapshot before it's pushed
+	// to the other proxies. Defaults to 30s.
+	//
+	// +optional
+	BakeDuration *gwapiv1.Duration `json:"bakeDuration,omitempty"`
+
+	// MaxErrorRatePercent is the maximum percentage of the downstream requests answered with
+	// a 5xx status by the canary proxies during the bake. It's computed from the Prometheus
+	// stats of the proxies, which must be enabled. The rollout is held if the stats of a canary
+	// can't be read, e.g. when the proxies connect to the xDS server over a Unix domain socket.
+	// If unspecified, the error rate is not checked.
+	//
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxErrorRatePercent *uint32 `json:"maxErrorRatePercent,omitempty"`
 }
 
 // LeaderElection defines the desired leader election settings.
@@ -107,10 +254,28 @@ type LeaderElection struct {
 }
 
 // EnvoyGatewayTelemetry defines telemetry configurations for envoy gateway control plane.
-// Control plane will focus on metrics observability telemetry and tracing telemetry later.
 type EnvoyGatewayTelemetry struct {
 	// Metrics defines metrics configuration for envoy gateway.
 	Metrics *EnvoyGatewayMetrics `json:"metrics,omitempty"`
+	// Tracing defines the tracing of the translation pipeline of envoy gateway.
+	// Tracing is disabled if unspecified.
+	//
+	// +optional
+	Tracing *EnvoyGatewayTracing `json:"tracing,omitempty"`
+}
+
+// EnvoyGatewayTracing defines control plane tracing configurations.
+// The traces span the provider update, the Gateway API translation, the extension hooks,
+// the xDS translation and the push of the xDS snapshots, and are exported to the
+// OpenTelemetry sinks of the metrics.
+type EnvoyGatewayTracing struct {
+	// SamplingRate is the percentage of the provider updates whose translation is traced.
+	// If unspecified, defaults to 100.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SamplingRate *uint32 `json:"samplingRate,omitempty"`
 }
 
 // EnvoyGatewayLogging defines logging for Envoy Gateway.
@@ -328,6 +493,13 @@ type EnvoyGatewayFileResourceProvider struct {
 	// Paths are the paths to a directory or file containing the resource configuration.
 	// Recursive subdirectories are not currently supported.
 	Paths []string `json:"paths"`
+	// StatusPath is the path to a directory where the statuses of the loaded
+	// resources are written to, one file per resource.
+	// It must not be under any of the watched paths.
+	// If unset, the statuses are only served by the health probe server.
+	//
+	// +optional
+	StatusPath *string `json:"statusPath,omitempty"`
 }
 
 // InfrastructureProviderType defines the types of custom infrastructure providers supported by Envoy Gateway.
@@ -355,7 +527,61 @@ type EnvoyGatewayInfrastructureProvider struct {
 
 // EnvoyGatewayHostInfrastructureProvider defines configuration for the Host Infrastructure provider.
 type EnvoyGatewayHostInfrastructureProvider struct {
-	// TODO: Add config as use cases are better understood.
+	// CertificatesDir is the directory holding the certificates generated by
+	// `envoy-gateway certgen --local`, with a sub-directory for each component.
+	// If unspecified, "/tmp/envoy-gateway/certs" is used.
+	//
+	// +optional
+	CertificatesDir *string `json:"certificatesDir,omitempty"`
+
+	// RateLimit defines the configuration of the rate limit service process,
+	// which is run on the host when global rate limiting is enabled.
+	//
+	// +optional
+	RateLimit *HostRateLimitService `json:"rateLimit,omitempty"`
+
+	// ProxyLogs defines the rotation of the log files of the proxies, the output
+	// of each proxy is written to its own file under the home directory.
+	//
+	// +optional
+	ProxyLogs *HostProxyLogs `json:"proxyLogs,omitempty"`
+}
+
+// HostProxyLogs defines the rotation of the proxy log files written by the
+// Host Infrastructure provider.
+type HostProxyLogs struct {
+	// MaxSizeMegabytes is the max size in megabytes of a log file before it's rotated.
+	// If unspecified, 100 is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxSizeMegabytes *uint32 `json:"maxSizeMegabytes,omitempty"`
+
+	// MaxBackups is the max number of rotated log files to retain, the oldest
+	// ones are removed. If unspecified, 5 is used.
+	//
+	// +optional
+	MaxBackups *uint32 `json:"maxBackups,omitempty"`
+}
+
+// HostRateLimitService defines the configuration of the rate limit service
+// run by the Host Infrastructure provider.
+type HostRateLimitService struct {
+	// Binary is the path of the rate limit service binary. It's looked up
+	// in the PATH if it's not an absolute path.
+	// If unspecified, "ratelimit" is used.
+	//
+	// +optional
+	Binary *string `json:"binary,omitempty"`
+
+	// Port is the port of the rate limit gRPC server, which listens on the
+	// loopback interface only.
+	// If unspecified, 8081 is used.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
 }
 
 // RateLimit defines the configuration associated with the Rate Limit Service
@@ -477,6 +703,12 @@ type RateLimitRedisSettings struct {
 // ExtensionManager defines the configuration for registering an extension manager to
 // the Envoy Gateway control plane.
 type ExtensionManager struct {
+	// Name identifies the extension in the logs and errors of Envoy Gateway.
+	// It must be unique across the registered extension managers.
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Resources defines the set of K8s resources the extension will handle as route
 	// filter resources
 	//
@@ -565,10 +797,29 @@ type ExtensionTLS struct {
 	// CertificateRef is a reference to a Kubernetes Secret with a CA certificate in a key named "tls.crt".
 	//
 	// The CA certificate is used by Envoy Gateway the verify the server certificate presented by the extension server.
-	// At this time, Envoy Gateway does not support Client Certificate authentication of Envoy Gateway towards the extension server (mTLS).
+	// It's only supported by the Kubernetes provider, and it's mutually exclusive with CACertFile.
 	//
-	// +kubebuilder:validation:Required
-	CertificateRef gwapiv1.SecretObjectReference `json:"certificateRef"`
+	// +optional
+	CertificateRef gwapiv1.SecretObjectReference `json:"certificateRef,omitempty"`
+
+	// CACertFile is the path of a file with the CA certificate in PEM format, which is used
+	// by Envoy Gateway to verify the server certificate presented by the extension server.
+	// It's mutually exclusive with CertificateRef.
+	//
+	// +optional
+	CACertFile *string `json:"caCertFile,omitempty"`
+
+	// ClientCertFile is the path of a file with the client certificate in PEM format, which is
+	// presented by Envoy Gateway to the extension server (mTLS). It must be set along with ClientKeyFile.
+	//
+	// +optional
+	ClientCertFile *string `json:"clientCertFile,omitempty"`
+
+	// ClientKeyFile is the path of a file with the private key of the client certificate in PEM format.
+	// It must be set along with ClientCertFile.
+	//
+	// +optional
+	ClientKeyFile *string `json:"clientKeyFile,omitempty"`
 }
 
 // EnvoyGatewayAdmin defines the Envoy Gateway Admin configuration.
@@ -585,6 +836,13 @@ type EnvoyGatewayAdmin struct {
 	//
 	// +optional
 	EnablePprof bool `json:"enablePprof,omitempty"`
+	// HistoryLimit defines the number of revisions of the xDS IR and xDS resources of each
+	// Gateway retained by the Envoy Gateway Admin Server. Setting it to 0 disables the history.
+	// Defaults to 10.
+	//
+	// +optional
+	// +kubebuilder:validation:Maximum=100
+	HistoryLimit *uint32 `json:"historyLimit,omitempty"`
 }
 
 // EnvoyGatewayAdminAddress defines the Envoy Gateway Admin Address configuration.
@@ -350,7 +350,7 @@ const (
 // XDSTranslatorHook defines the types of hooks that an Envoy Gateway extension may support
 // for the xds-translator
 //
-// +kubebuilder:validation:Enum=VirtualHost;Route;HTTPListener;Translation
+// +kubebuilder:validation:Enum=VirtualHost;Route;HTTPListener;Translation;IR;Cluster
 type XDSTranslatorHook string
 
 const (
@@ -358,6 +358,11 @@ const (
 	XDSRoute        XDSTranslatorHook = "Route"
 	XDSHTTPListener XDSTranslatorHook = "HTTPListener"
 	XDSTranslation  XDSTranslatorHook = "Translation"
+	// XDSCluster is a post hook invoked for each cluster generated for the backends of a route.
+	XDSCluster XDSTranslatorHook = "Cluster"
+	// XDSIR is a pre hook invoked with the IR of a Gateway before it is translated into xDS resources.
+	// It is only supported in the pre hooks.
+	XDSIR XDSTranslatorHook = "IR"
 )
 
 // StringMatch defines how to match any strings.
@@ -7,7 +7,13 @@ package validation
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 )
@@ -51,7 +57,7 @@ func ValidateEnvoyGateway(eg *egv1a1.EnvoyGateway) error {
 		return err
 	}
 
-	if err := validateEnvoyGatewayExtensionManager(eg.ExtensionManager); err != nil {
+	if err := validateEnvoyGatewayExtensionManagers(eg); err != nil {
 		return err
 	}
 
@@ -59,6 +65,14 @@ func ValidateEnvoyGateway(eg *egv1a1.EnvoyGateway) error {
 		return err
 	}
 
+	if err := validateEnvoyGatewayXDSServer(eg); err != nil {
+		return err
+	}
+
+	if err := validateEnvoyGatewayAdmin(eg.Admin); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -109,12 +123,29 @@ func validateEnvoyGatewayCustomResourceProvider(resource egv1a1.EnvoyGatewayReso
 		if len(resource.File.Paths) == 0 {
 			return fmt.Errorf("no paths were assigned for file resource provider to watch")
 		}
+
+		if resource.File.StatusPath != nil {
+			for _, path := range resource.File.Paths {
+				if isPathUnder(*resource.File.StatusPath, path) {
+					return fmt.Errorf("statusPath %s should not be under the watched path %s", *resource.File.StatusPath, path)
+				}
+			}
+		}
 	default:
 		return fmt.Errorf("unsupported resource provider: %s", resource.Type)
 	}
 	return nil
 }
 
+// isPathUnder returns true if the path is the same as, or under the given directory.
+func isPathUnder(path, dir string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
 func validateEnvoyGatewayCustomInfrastructureProvider(infra *egv1a1.EnvoyGatewayInfrastructureProvider) error {
 	if infra == nil {
 		return nil
@@ -173,7 +204,28 @@ func validateEnvoyGatewayRateLimit(rateLimit *egv1a1.RateLimit) error {
 	return nil
 }
 
-func validateEnvoyGatewayExtensionManager(extensionManager *egv1a1.ExtensionManager) error {
+func validateEnvoyGatewayExtensionManagers(eg *egv1a1.EnvoyGateway) error {
+	if eg.ExtensionManager != nil && len(eg.ExtensionManagers) > 0 {
+		return fmt.Errorf("only one of extensionManager and extensionManagers can be configured")
+	}
+
+	names := map[string]bool{}
+	for _, extensionManager := range eg.GetExtensionManagers() {
+		if extensionManager.Name != "" {
+			if names[extensionManager.Name] {
+				return fmt.Errorf("duplicated extension manager name %s", extensionManager.Name)
+			}
+			names[extensionManager.Name] = true
+		}
+
+		if err := validateEnvoyGatewayExtensionManager(extensionManager, eg.Provider); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateEnvoyGatewayExtensionManager(extensionManager *egv1a1.ExtensionManager, provider *egv1a1.EnvoyGatewayProvider) error {
 	if extensionManager == nil {
 		return nil
 	}
@@ -186,6 +238,10 @@ func validateEnvoyGatewayExtensionManager(extensionManager *egv1a1.ExtensionMana
 		return fmt.Errorf("registered extension has no hooks specified")
 	}
 
+	if slices.Contains(extensionManager.Hooks.XDSTranslator.Post, egv1a1.XDSIR) {
+		return fmt.Errorf("the %s hook is only supported as a pre hook", egv1a1.XDSIR)
+	}
+
 	if extensionManager.Service == nil {
 		return fmt.Errorf("extension service config is empty")
 	}
@@ -200,15 +256,29 @@ func validateEnvoyGatewayExtensionManager(extensionManager *egv1a1.ExtensionMana
 		return fmt.Errorf("only one backend target can be configured for the extension manager")
 	}
 
-	if extensionManager.Service.TLS != nil {
-		certificateRefKind := extensionManager.Service.TLS.CertificateRef.Kind
+	if tls := extensionManager.Service.TLS; tls != nil {
+		if tls.CACertFile != nil {
+			if tls.CertificateRef.Name != "" || tls.CertificateRef.Kind != nil {
+				return fmt.Errorf("only one of certificateRef and caCertFile can be configured in extension service server TLS settings")
+			}
+		} else {
+			if provider.Type != egv1a1.ProviderTypeKubernetes {
+				return fmt.Errorf("caCertFile must be configured in extension service server TLS settings when not running on Kubernetes")
+			}
+
+			certificateRefKind := tls.CertificateRef.Kind
 
-		if certificateRefKind == nil {
-			return fmt.Errorf("certificateRef empty in extension service server TLS settings")
+			if certificateRefKind == nil {
+				return fmt.Errorf("certificateRef empty in extension service server TLS settings")
+			}
+
+			if *certificateRefKind != "Secret" {
+				return fmt.Errorf("unsupported extension server TLS certificateRef %v", certificateRefKind)
+			}
 		}
 
-		if *certificateRefKind != "Secret" {
-			return fmt.Errorf("unsupported extension server TLS certificateRef %v", certificateRefKind)
+		if (tls.ClientCertFile == nil) != (tls.ClientKeyFile == nil) {
+			return fmt.Errorf("clientCertFile and clientKeyFile must be configured together in extension service server TLS settings")
 		}
 	}
 	return nil
@@ -228,5 +298,67 @@ func validateEnvoyGatewayTelemetry(telemetry *egv1a1.EnvoyGatewayTelemetry) erro
 			}
 		}
 	}
+
+	if telemetry.Tracing != nil {
+		if telemetry.Tracing.SamplingRate != nil && *telemetry.Tracing.SamplingRate > 100 {
+			return fmt.Errorf("tracing samplingRate must be between 0 and 100")
+		}
+		if telemetry.Metrics == nil || len(telemetry.Metrics.Sinks) == 0 {
+			return fmt.Errorf("tracing requires an OpenTelemetry metric sink to export the traces to")
+		}
+	}
+	return nil
+}
+
+func validateEnvoyGatewayXDSServer(eg *egv1a1.EnvoyGateway) error {
+	xdsServer := eg.XDSServer
+	if xdsServer != nil && xdsServer.Address != nil && net.ParseIP(*xdsServer.Address) == nil {
+		return fmt.Errorf("invalid xds server address %q", *xdsServer.Address)
+	}
+	if port := xdsServer.GetPort(); port < 1 || port > 65535 {
+		return fmt.Errorf("xds server port must be between 1 and 65535")
+	}
+	// The port is exposed by the Service of Envoy Gateway, which is deployed by the Helm chart.
+	if port := xdsServer.GetPort(); port != egv1a1.DefaultXDSServerPort && eg.Provider.IsRunningOnKubernetes() {
+		return fmt.Errorf("xds server port %d is not supported by the kubernetes provider, which only supports %d",
+			port, egv1a1.DefaultXDSServerPort)
+	}
+	if socket := xdsServer.GetUnixSocket(); socket != nil {
+		if !eg.Provider.IsRunningOnHost() {
+			return fmt.Errorf("xds server unixSocket is only supported by the host infrastructure provider")
+		}
+		if socket.Path == "" {
+			return fmt.Errorf("xds server unixSocket path must be specified")
+		}
+		if socket.Mode != nil {
+			if mode, err := strconv.ParseUint(*socket.Mode, 8, 32); err != nil || mode > 0o777 {
+				return fmt.Errorf("invalid xds server unixSocket mode %q", *socket.Mode)
+			}
+		}
+	}
+
+	rollout := xdsServer.GetRollout()
+	if rollout == nil {
+		return nil
+	}
+
+	if rollout.CanaryPercentage < 1 || rollout.CanaryPercentage > 100 {
+		return fmt.Errorf("xds rollout canaryPercentage must be between 1 and 100")
+	}
+	if rollout.BakeDuration != nil {
+		if d, err := time.ParseDuration(string(*rollout.BakeDuration)); err != nil || d <= 0 {
+			return fmt.Errorf("invalid xds rollout bakeDuration %q", *rollout.BakeDuration)
+		}
+	}
+	if rollout.MaxErrorRatePercent != nil && *rollout.MaxErrorRatePercent > 100 {
+		return fmt.Errorf("xds rollout maxErrorRatePercent must be between 0 and 100")
+	}
+	return nil
+}
+
+func validateEnvoyGatewayAdmin(admin *egv1a1.EnvoyGatewayAdmin) error {
+	if admin != nil && admin.HistoryLimit != nil && *admin.HistoryLimit > 100 {
+		return fmt.Errorf("admin historyLimit must be between 0 and 100")
+	}
 	return nil
 }
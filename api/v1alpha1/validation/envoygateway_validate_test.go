@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
@@ -239,6 +240,81 @@ func TestValidateEnvoyGateway(t *testing.T) {
 			},
 			expect: false,
 		},
+		{
+			name: "custom provider with file provider and status path in the watched paths",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: egv1a1.DefaultGateway(),
+					Provider: &egv1a1.EnvoyGatewayProvider{
+						Type: egv1a1.ProviderTypeCustom,
+						Custom: &egv1a1.EnvoyGatewayCustomProvider{
+							Resource: egv1a1.EnvoyGatewayResourceProvider{
+								Type: egv1a1.ResourceProviderTypeFile,
+								File: &egv1a1.EnvoyGatewayFileResourceProvider{
+									Paths:      []string{"/etc/envoy-gateway/resources"},
+									StatusPath: ptr.To("/etc/envoy-gateway/resources/"),
+								},
+							},
+							Infrastructure: &egv1a1.EnvoyGatewayInfrastructureProvider{
+								Type: egv1a1.InfrastructureProviderTypeHost,
+								Host: &egv1a1.EnvoyGatewayHostInfrastructureProvider{},
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "custom provider with file provider and status path under a watched path",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: egv1a1.DefaultGateway(),
+					Provider: &egv1a1.EnvoyGatewayProvider{
+						Type: egv1a1.ProviderTypeCustom,
+						Custom: &egv1a1.EnvoyGatewayCustomProvider{
+							Resource: egv1a1.EnvoyGatewayResourceProvider{
+								Type: egv1a1.ResourceProviderTypeFile,
+								File: &egv1a1.EnvoyGatewayFileResourceProvider{
+									Paths:      []string{"/etc/envoy-gateway/resources"},
+									StatusPath: ptr.To("/etc/envoy-gateway/resources/status"),
+								},
+							},
+							Infrastructure: &egv1a1.EnvoyGatewayInfrastructureProvider{
+								Type: egv1a1.InfrastructureProviderTypeHost,
+								Host: &egv1a1.EnvoyGatewayHostInfrastructureProvider{},
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "custom provider with file provider and status path next to a watched path",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: egv1a1.DefaultGateway(),
+					Provider: &egv1a1.EnvoyGatewayProvider{
+						Type: egv1a1.ProviderTypeCustom,
+						Custom: &egv1a1.EnvoyGatewayCustomProvider{
+							Resource: egv1a1.EnvoyGatewayResourceProvider{
+								Type: egv1a1.ResourceProviderTypeFile,
+								File: &egv1a1.EnvoyGatewayFileResourceProvider{
+									Paths:      []string{"/etc/envoy-gateway/resources"},
+									StatusPath: ptr.To("/etc/envoy-gateway/resources-status"),
+								},
+							},
+							Infrastructure: &egv1a1.EnvoyGatewayInfrastructureProvider{
+								Type: egv1a1.InfrastructureProviderTypeHost,
+								Host: &egv1a1.EnvoyGatewayHostInfrastructureProvider{},
+							},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
 		{
 			name: "empty ratelimit",
 			eg: &egv1a1.EnvoyGateway{
@@ -430,19 +506,365 @@ func TestValidateEnvoyGateway(t *testing.T) {
 									egv1a1.XDSHTTPListener,
 									egv1a1.XDSTranslation,
 									egv1a1.XDSRoute,
-									egv1a1.XDSVirtualHost,
+									egv1a1.XDSVirtualHost,
+								},
+							},
+						},
+						Service: &egv1a1.ExtensionService{
+							Host: "foo.extension",
+							Port: 8080,
+							TLS: &egv1a1.ExtensionTLS{
+								CertificateRef: gwapiv1.SecretObjectReference{
+									Kind: &TLSUnrecognizedKind,
+									Name: gwapiv1.ObjectName("certificate"),
+								},
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "extension TLS settings from files with custom provider",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: egv1a1.DefaultGateway(),
+					Provider: &egv1a1.EnvoyGatewayProvider{
+						Type: egv1a1.ProviderTypeCustom,
+						Custom: &egv1a1.EnvoyGatewayCustomProvider{
+							Resource: egv1a1.EnvoyGatewayResourceProvider{
+								Type: egv1a1.ResourceProviderTypeFile,
+								File: &egv1a1.EnvoyGatewayFileResourceProvider{
+									Paths: []string{"foo"},
+								},
+							},
+							Infrastructure: &egv1a1.EnvoyGatewayInfrastructureProvider{
+								Type: egv1a1.InfrastructureProviderTypeHost,
+								Host: &egv1a1.EnvoyGatewayHostInfrastructureProvider{},
+							},
+						},
+					},
+					ExtensionManager: &egv1a1.ExtensionManager{
+						Hooks: &egv1a1.ExtensionHooks{
+							XDSTranslator: &egv1a1.XDSTranslatorHooks{
+								Post: []egv1a1.XDSTranslatorHook{
+									egv1a1.XDSRoute,
+								},
+							},
+						},
+						Service: &egv1a1.ExtensionService{
+							BackendEndpoint: egv1a1.BackendEndpoint{
+								IP: &egv1a1.IPEndpoint{
+									Address: "127.0.0.1",
+									Port:    5005,
+								},
+							},
+							TLS: &egv1a1.ExtensionTLS{
+								CACertFile:     ptr.To("/etc/extension/ca.crt"),
+								ClientCertFile: ptr.To("/etc/extension/tls.crt"),
+								ClientKeyFile:  ptr.To("/etc/extension/tls.key"),
+							},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "extension with IR pre hook",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ExtensionManager: &egv1a1.ExtensionManager{
+						Hooks: &egv1a1.ExtensionHooks{
+							XDSTranslator: &egv1a1.XDSTranslatorHooks{
+								Pre: []egv1a1.XDSTranslatorHook{
+									egv1a1.XDSIR,
+								},
+							},
+						},
+						Service: &egv1a1.ExtensionService{
+							BackendEndpoint: egv1a1.BackendEndpoint{
+								FQDN: &egv1a1.FQDNEndpoint{
+									Hostname: "extension.example.com",
+									Port:     8080,
+								},
+							},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "extension with IR post hook",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ExtensionManager: &egv1a1.ExtensionManager{
+						Hooks: &egv1a1.ExtensionHooks{
+							XDSTranslator: &egv1a1.XDSTranslatorHooks{
+								Post: []egv1a1.XDSTranslatorHook{
+									egv1a1.XDSIR,
+								},
+							},
+						},
+						Service: &egv1a1.ExtensionService{
+							BackendEndpoint: egv1a1.BackendEndpoint{
+								FQDN: &egv1a1.FQDNEndpoint{
+									Hostname: "extension.example.com",
+									Port:     8080,
+								},
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "extension TLS settings with both certificateRef and caCertFile",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ExtensionManager: &egv1a1.ExtensionManager{
+						Hooks: &egv1a1.ExtensionHooks{
+							XDSTranslator: &egv1a1.XDSTranslatorHooks{
+								Post: []egv1a1.XDSTranslatorHook{
+									egv1a1.XDSRoute,
+								},
+							},
+						},
+						Service: &egv1a1.ExtensionService{
+							BackendEndpoint: egv1a1.BackendEndpoint{
+								IP: &egv1a1.IPEndpoint{
+									Address: "127.0.0.1",
+									Port:    5005,
+								},
+							},
+							TLS: &egv1a1.ExtensionTLS{
+								CertificateRef: gwapiv1.SecretObjectReference{
+									Kind: &TLSSecretKind,
+									Name: gwapiv1.ObjectName("certificate"),
+								},
+								CACertFile: ptr.To("/etc/extension/ca.crt"),
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "extension TLS certificateRef with custom provider",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: egv1a1.DefaultGateway(),
+					Provider: &egv1a1.EnvoyGatewayProvider{
+						Type: egv1a1.ProviderTypeCustom,
+						Custom: &egv1a1.EnvoyGatewayCustomProvider{
+							Resource: egv1a1.EnvoyGatewayResourceProvider{
+								Type: egv1a1.ResourceProviderTypeFile,
+								File: &egv1a1.EnvoyGatewayFileResourceProvider{
+									Paths: []string{"foo"},
+								},
+							},
+							Infrastructure: &egv1a1.EnvoyGatewayInfrastructureProvider{
+								Type: egv1a1.InfrastructureProviderTypeHost,
+								Host: &egv1a1.EnvoyGatewayHostInfrastructureProvider{},
+							},
+						},
+					},
+					ExtensionManager: &egv1a1.ExtensionManager{
+						Hooks: &egv1a1.ExtensionHooks{
+							XDSTranslator: &egv1a1.XDSTranslatorHooks{
+								Post: []egv1a1.XDSTranslatorHook{
+									egv1a1.XDSRoute,
+								},
+							},
+						},
+						Service: &egv1a1.ExtensionService{
+							BackendEndpoint: egv1a1.BackendEndpoint{
+								IP: &egv1a1.IPEndpoint{
+									Address: "127.0.0.1",
+									Port:    5005,
+								},
+							},
+							TLS: &egv1a1.ExtensionTLS{
+								CertificateRef: gwapiv1.SecretObjectReference{
+									Kind: &TLSSecretKind,
+									Name: gwapiv1.ObjectName("certificate"),
+								},
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "extension TLS client certificate without key",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ExtensionManager: &egv1a1.ExtensionManager{
+						Hooks: &egv1a1.ExtensionHooks{
+							XDSTranslator: &egv1a1.XDSTranslatorHooks{
+								Post: []egv1a1.XDSTranslatorHook{
+									egv1a1.XDSRoute,
+								},
+							},
+						},
+						Service: &egv1a1.ExtensionService{
+							BackendEndpoint: egv1a1.BackendEndpoint{
+								IP: &egv1a1.IPEndpoint{
+									Address: "127.0.0.1",
+									Port:    5005,
+								},
+							},
+							TLS: &egv1a1.ExtensionTLS{
+								CACertFile:     ptr.To("/etc/extension/ca.crt"),
+								ClientCertFile: ptr.To("/etc/extension/tls.crt"),
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "multiple extension managers",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ExtensionManagers: []egv1a1.ExtensionManager{
+						{
+							Name: "foo",
+							Hooks: &egv1a1.ExtensionHooks{
+								XDSTranslator: &egv1a1.XDSTranslatorHooks{
+									Post: []egv1a1.XDSTranslatorHook{
+										egv1a1.XDSRoute,
+									},
+								},
+							},
+							Service: &egv1a1.ExtensionService{
+								Host: "foo.extension",
+								Port: 443,
+							},
+						},
+						{
+							Name: "bar",
+							Hooks: &egv1a1.ExtensionHooks{
+								XDSTranslator: &egv1a1.XDSTranslatorHooks{
+									Post: []egv1a1.XDSTranslatorHook{
+										egv1a1.XDSRoute,
+									},
+								},
+							},
+							Service: &egv1a1.ExtensionService{
+								Host: "bar.extension",
+								Port: 443,
+							},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "duplicated extension manager names",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ExtensionManagers: []egv1a1.ExtensionManager{
+						{
+							Name: "foo",
+							Hooks: &egv1a1.ExtensionHooks{
+								XDSTranslator: &egv1a1.XDSTranslatorHooks{
+									Post: []egv1a1.XDSTranslatorHook{
+										egv1a1.XDSRoute,
+									},
+								},
+							},
+							Service: &egv1a1.ExtensionService{
+								Host: "foo.extension",
+								Port: 443,
+							},
+						},
+						{
+							Name: "foo",
+							Hooks: &egv1a1.ExtensionHooks{
+								XDSTranslator: &egv1a1.XDSTranslatorHooks{
+									Post: []egv1a1.XDSTranslatorHook{
+										egv1a1.XDSRoute,
+									},
+								},
+							},
+							Service: &egv1a1.ExtensionService{
+								Host: "foo.extension",
+								Port: 443,
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "both extension manager and extension managers",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ExtensionManager: &egv1a1.ExtensionManager{
+						Hooks: &egv1a1.ExtensionHooks{
+							XDSTranslator: &egv1a1.XDSTranslatorHooks{
+								Post: []egv1a1.XDSTranslatorHook{
+									egv1a1.XDSRoute,
 								},
 							},
 						},
 						Service: &egv1a1.ExtensionService{
 							Host: "foo.extension",
-							Port: 8080,
-							TLS: &egv1a1.ExtensionTLS{
-								CertificateRef: gwapiv1.SecretObjectReference{
-									Kind: &TLSUnrecognizedKind,
-									Name: gwapiv1.ObjectName("certificate"),
+							Port: 443,
+						},
+					},
+					ExtensionManagers: []egv1a1.ExtensionManager{
+						{
+							Name: "bar",
+							Hooks: &egv1a1.ExtensionHooks{
+								XDSTranslator: &egv1a1.XDSTranslatorHooks{
+									Post: []egv1a1.XDSTranslatorHook{
+										egv1a1.XDSRoute,
+									},
 								},
 							},
+							Service: &egv1a1.ExtensionService{
+								Host: "bar.extension",
+								Port: 443,
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "invalid extension manager in extension managers",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ExtensionManagers: []egv1a1.ExtensionManager{
+						{
+							Name: "foo",
 						},
 					},
 				},
@@ -621,6 +1043,73 @@ func TestValidateEnvoyGateway(t *testing.T) {
 			},
 			expect: false,
 		},
+		{
+			name: "valid gateway tracing",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Telemetry: &egv1a1.EnvoyGatewayTelemetry{
+						Metrics: &egv1a1.EnvoyGatewayMetrics{
+							Sinks: []egv1a1.EnvoyGatewayMetricSink{
+								{
+									Type: egv1a1.MetricSinkTypeOpenTelemetry,
+									OpenTelemetry: &egv1a1.EnvoyGatewayOpenTelemetrySink{
+										Host:     "x.x.x.x",
+										Port:     4317,
+										Protocol: "grpc",
+									},
+								},
+							},
+						},
+						Tracing: &egv1a1.EnvoyGatewayTracing{
+							SamplingRate: ptr.To[uint32](10),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "invalid gateway tracing sampling rate",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Telemetry: &egv1a1.EnvoyGatewayTelemetry{
+						Metrics: &egv1a1.EnvoyGatewayMetrics{
+							Sinks: []egv1a1.EnvoyGatewayMetricSink{
+								{
+									Type: egv1a1.MetricSinkTypeOpenTelemetry,
+									OpenTelemetry: &egv1a1.EnvoyGatewayOpenTelemetrySink{
+										Host:     "x.x.x.x",
+										Port:     4317,
+										Protocol: "grpc",
+									},
+								},
+							},
+						},
+						Tracing: &egv1a1.EnvoyGatewayTracing{
+							SamplingRate: ptr.To[uint32](101),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "invalid gateway tracing without metric sink",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Telemetry: &egv1a1.EnvoyGatewayTelemetry{
+						Tracing: &egv1a1.EnvoyGatewayTracing{},
+					},
+				},
+			},
+			expect: false,
+		},
 		{
 			name: "invalid gateway watch mode",
 			eg: &egv1a1.EnvoyGateway{
@@ -821,6 +1310,239 @@ func TestValidateEnvoyGateway(t *testing.T) {
 			},
 			expect: false,
 		},
+		{
+			name: "valid xds rollout",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XDSServer: &egv1a1.EnvoyGatewayXDSServer{
+						Rollout: &egv1a1.XDSRollout{
+							CanaryPercentage:    10,
+							BakeDuration:        ptr.To(gwapiv1.Duration("1m")),
+							MaxErrorRatePercent: ptr.To[uint32](5),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "xds rollout without canaries",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XDSServer: &egv1a1.EnvoyGatewayXDSServer{
+						Rollout: &egv1a1.XDSRollout{},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "xds rollout with invalid bake duration",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XDSServer: &egv1a1.EnvoyGatewayXDSServer{
+						Rollout: &egv1a1.XDSRollout{
+							CanaryPercentage: 10,
+							BakeDuration:     ptr.To(gwapiv1.Duration("soon")),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid xds server address and port",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: egv1a1.DefaultGateway(),
+					Provider: &egv1a1.EnvoyGatewayProvider{
+						Type: egv1a1.ProviderTypeCustom,
+						Custom: &egv1a1.EnvoyGatewayCustomProvider{
+							Resource: egv1a1.EnvoyGatewayResourceProvider{
+								Type: egv1a1.ResourceProviderTypeFile,
+								File: &egv1a1.EnvoyGatewayFileResourceProvider{
+									Paths: []string{"foo"},
+								},
+							},
+							Infrastructure: &egv1a1.EnvoyGatewayInfrastructureProvider{
+								Type: egv1a1.InfrastructureProviderTypeHost,
+								Host: &egv1a1.EnvoyGatewayHostInfrastructureProvider{},
+							},
+						},
+					},
+					XDSServer: &egv1a1.EnvoyGatewayXDSServer{
+						Address: ptr.To("127.0.0.1"),
+						Port:    ptr.To[int32](18080),
+						TLS: &egv1a1.XDSServerTLS{
+							CertPath: ptr.To("/etc/xds/tls.crt"),
+							KeyPath:  ptr.To("/etc/xds/tls.key"),
+							CAPath:   ptr.To("/etc/xds/ca.crt"),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "valid xds server address with kubernetes provider",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XDSServer: &egv1a1.EnvoyGatewayXDSServer{
+						Address: ptr.To("127.0.0.1"),
+						Port:    ptr.To[int32](egv1a1.DefaultXDSServerPort),
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "xds server port with kubernetes provider",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XDSServer: &egv1a1.EnvoyGatewayXDSServer{
+						Port: ptr.To[int32](18080),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "invalid xds server address",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XDSServer: &egv1a1.EnvoyGatewayXDSServer{
+						Address: ptr.To("localhost"),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "invalid xds server port",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XDSServer: &egv1a1.EnvoyGatewayXDSServer{
+						Port: ptr.To[int32](0),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "xds server unix socket with host infra provider",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: egv1a1.DefaultGateway(),
+					Provider: &egv1a1.EnvoyGatewayProvider{
+						Type: egv1a1.ProviderTypeCustom,
+						Custom: &egv1a1.EnvoyGatewayCustomProvider{
+							Resource: egv1a1.EnvoyGatewayResourceProvider{
+								Type: egv1a1.ResourceProviderTypeFile,
+								File: &egv1a1.EnvoyGatewayFileResourceProvider{
+									Paths: []string{"foo"},
+								},
+							},
+							Infrastructure: &egv1a1.EnvoyGatewayInfrastructureProvider{
+								Type: egv1a1.InfrastructureProviderTypeHost,
+								Host: &egv1a1.EnvoyGatewayHostInfrastructureProvider{},
+							},
+						},
+					},
+					XDSServer: &egv1a1.EnvoyGatewayXDSServer{
+						UnixSocket: &egv1a1.XDSUnixSocket{
+							Path: "/run/envoy-gateway/xds.sock",
+							Mode: ptr.To("0660"),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "xds server unix socket with invalid mode",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: egv1a1.DefaultGateway(),
+					Provider: &egv1a1.EnvoyGatewayProvider{
+						Type: egv1a1.ProviderTypeCustom,
+						Custom: &egv1a1.EnvoyGatewayCustomProvider{
+							Resource: egv1a1.EnvoyGatewayResourceProvider{
+								Type: egv1a1.ResourceProviderTypeFile,
+								File: &egv1a1.EnvoyGatewayFileResourceProvider{
+									Paths: []string{"foo"},
+								},
+							},
+							Infrastructure: &egv1a1.EnvoyGatewayInfrastructureProvider{
+								Type: egv1a1.InfrastructureProviderTypeHost,
+								Host: &egv1a1.EnvoyGatewayHostInfrastructureProvider{},
+							},
+						},
+					},
+					XDSServer: &egv1a1.EnvoyGatewayXDSServer{
+						UnixSocket: &egv1a1.XDSUnixSocket{
+							Path: "/run/envoy-gateway/xds.sock",
+							Mode: ptr.To("0999"),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "xds server unix socket with kubernetes provider",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XDSServer: &egv1a1.EnvoyGatewayXDSServer{
+						UnixSocket: &egv1a1.XDSUnixSocket{
+							Path: "/run/envoy-gateway/xds.sock",
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid admin history limit",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Admin: &egv1a1.EnvoyGatewayAdmin{
+						HistoryLimit: ptr.To[uint32](0),
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "admin history limit too large",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Admin: &egv1a1.EnvoyGatewayAdmin{
+						HistoryLimit: ptr.To[uint32](1000),
+					},
+				},
+			},
+			expect: false,
+		},
 	}
 
 	for _, tc := range testCases {
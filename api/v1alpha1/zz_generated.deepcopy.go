@@ -1556,6 +1556,11 @@ func (in *EnvoyGatewayAdmin) DeepCopyInto(out *EnvoyGatewayAdmin) {
 		*out = new(EnvoyGatewayAdminAddress)
 		**out = **in
 	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(uint32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayAdmin.
@@ -1612,6 +1617,11 @@ func (in *EnvoyGatewayFileResourceProvider) DeepCopyInto(out *EnvoyGatewayFileRe
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.StatusPath != nil {
+		in, out := &in.StatusPath, &out.StatusPath
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayFileResourceProvider.
@@ -1627,6 +1637,21 @@ func (in *EnvoyGatewayFileResourceProvider) DeepCopy() *EnvoyGatewayFileResource
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyGatewayHostInfrastructureProvider) DeepCopyInto(out *EnvoyGatewayHostInfrastructureProvider) {
 	*out = *in
+	if in.CertificatesDir != nil {
+		in, out := &in.CertificatesDir, &out.CertificatesDir
+		*out = new(string)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(HostRateLimitService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyLogs != nil {
+		in, out := &in.ProxyLogs, &out.ProxyLogs
+		*out = new(HostProxyLogs)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayHostInfrastructureProvider.
@@ -1645,7 +1670,7 @@ func (in *EnvoyGatewayInfrastructureProvider) DeepCopyInto(out *EnvoyGatewayInfr
 	if in.Host != nil {
 		in, out := &in.Host, &out.Host
 		*out = new(EnvoyGatewayHostInfrastructureProvider)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1896,11 +1921,23 @@ func (in *EnvoyGatewaySpec) DeepCopyInto(out *EnvoyGatewaySpec) {
 		*out = new(ExtensionManager)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExtensionManagers != nil {
+		in, out := &in.ExtensionManagers, &out.ExtensionManagers
+		*out = make([]ExtensionManager, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ExtensionAPIs != nil {
 		in, out := &in.ExtensionAPIs, &out.ExtensionAPIs
 		*out = new(ExtensionAPISettings)
 		**out = **in
 	}
+	if in.XDSServer != nil {
+		in, out := &in.XDSServer, &out.XDSServer
+		*out = new(EnvoyGatewayXDSServer)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewaySpec.
@@ -1921,6 +1958,11 @@ func (in *EnvoyGatewayTelemetry) DeepCopyInto(out *EnvoyGatewayTelemetry) {
 		*out = new(EnvoyGatewayMetrics)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(EnvoyGatewayTracing)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayTelemetry.
@@ -1933,6 +1975,71 @@ func (in *EnvoyGatewayTelemetry) DeepCopy() *EnvoyGatewayTelemetry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayTracing) DeepCopyInto(out *EnvoyGatewayTracing) {
+	*out = *in
+	if in.SamplingRate != nil {
+		in, out := &in.SamplingRate, &out.SamplingRate
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayTracing.
+func (in *EnvoyGatewayTracing) DeepCopy() *EnvoyGatewayTracing {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayTracing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayXDSServer) DeepCopyInto(out *EnvoyGatewayXDSServer) {
+	*out = *in
+	if in.RollbackOnNACK != nil {
+		in, out := &in.RollbackOnNACK, &out.RollbackOnNACK
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(XDSRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UnixSocket != nil {
+		in, out := &in.UnixSocket, &out.UnixSocket
+		*out = new(XDSUnixSocket)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(XDSServerTLS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayXDSServer.
+func (in *EnvoyGatewayXDSServer) DeepCopy() *EnvoyGatewayXDSServer {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayXDSServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyJSONPatchConfig) DeepCopyInto(out *EnvoyJSONPatchConfig) {
 	*out = *in
@@ -2479,6 +2586,21 @@ func (in *ExtensionService) DeepCopy() *ExtensionService {
 func (in *ExtensionTLS) DeepCopyInto(out *ExtensionTLS) {
 	*out = *in
 	in.CertificateRef.DeepCopyInto(&out.CertificateRef)
+	if in.CACertFile != nil {
+		in, out := &in.CACertFile, &out.CACertFile
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClientCertFile != nil {
+		in, out := &in.ClientCertFile, &out.ClientCertFile
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClientKeyFile != nil {
+		in, out := &in.ClientKeyFile, &out.ClientKeyFile
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionTLS.
@@ -3349,6 +3471,56 @@ func (in *HealthCheckSettings) DeepCopy() *HealthCheckSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostProxyLogs) DeepCopyInto(out *HostProxyLogs) {
+	*out = *in
+	if in.MaxSizeMegabytes != nil {
+		in, out := &in.MaxSizeMegabytes, &out.MaxSizeMegabytes
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxBackups != nil {
+		in, out := &in.MaxBackups, &out.MaxBackups
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostProxyLogs.
+func (in *HostProxyLogs) DeepCopy() *HostProxyLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(HostProxyLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRateLimitService) DeepCopyInto(out *HostRateLimitService) {
+	*out = *in
+	if in.Binary != nil {
+		in, out := &in.Binary, &out.Binary
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRateLimitService.
+func (in *HostRateLimitService) DeepCopy() *HostRateLimitService {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRateLimitService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPEndpoint) DeepCopyInto(out *IPEndpoint) {
 	*out = *in
@@ -6076,6 +6248,61 @@ func (in *WasmEnv) DeepCopy() *WasmEnv {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XDSRollout) DeepCopyInto(out *XDSRollout) {
+	*out = *in
+	if in.BakeDuration != nil {
+		in, out := &in.BakeDuration, &out.BakeDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxErrorRatePercent != nil {
+		in, out := &in.MaxErrorRatePercent, &out.MaxErrorRatePercent
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XDSRollout.
+func (in *XDSRollout) DeepCopy() *XDSRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(XDSRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XDSServerTLS) DeepCopyInto(out *XDSServerTLS) {
+	*out = *in
+	if in.CertPath != nil {
+		in, out := &in.CertPath, &out.CertPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyPath != nil {
+		in, out := &in.KeyPath, &out.KeyPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.CAPath != nil {
+		in, out := &in.CAPath, &out.CAPath
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XDSServerTLS.
+func (in *XDSServerTLS) DeepCopy() *XDSServerTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(XDSServerTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *XDSTranslatorHooks) DeepCopyInto(out *XDSTranslatorHooks) {
 	*out = *in
@@ -6101,6 +6328,26 @@ func (in *XDSTranslatorHooks) DeepCopy() *XDSTranslatorHooks {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XDSUnixSocket) DeepCopyInto(out *XDSUnixSocket) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XDSUnixSocket.
+func (in *XDSUnixSocket) DeepCopy() *XDSUnixSocket {
+	if in == nil {
+		return nil
+	}
+	out := new(XDSUnixSocket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *XForwardedClientCert) DeepCopyInto(out *XForwardedClientCert) {
 	*out = *in
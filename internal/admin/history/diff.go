@@ -0,0 +1,158 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ChangeOp is the operation of a change between two revisions.
+type ChangeOp string
+
+const (
+	ChangeOpAdd     ChangeOp = "add"
+	ChangeOpRemove  ChangeOp = "remove"
+	ChangeOpReplace ChangeOp = "replace"
+)
+
+// Change is a change of a value between two revisions.
+type Change struct {
+	// Op is the operation of the change.
+	Op ChangeOp `json:"op"`
+	// Path is the JSON pointer of the changed value. The elements of the lists of named
+	// objects, such as listeners and clusters, are identified by their name rather than
+	// their index.
+	Path string `json:"path"`
+	// From is the value before the change, unset if the value was added.
+	From any `json:"from,omitempty"`
+	// To is the value after the change, unset if the value was removed.
+	To any `json:"to,omitempty"`
+}
+
+// Diff returns the changes between two JSON documents, sorted by path.
+func Diff(from, to json.RawMessage) ([]Change, error) {
+	fromValue, err := decode(from)
+	if err != nil {
+		return nil, err
+	}
+	toValue, err := decode(to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []Change{}
+	diffValues("", fromValue, toValue, &changes)
+	return changes, nil
+}
+
+func decode(data json.RawMessage) (any, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// Keep the numbers as they are encoded, so large integers are compared exactly.
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func diffValues(path string, from, to any, changes *[]Change) {
+	switch fromValue := from.(type) {
+	case map[string]any:
+		if toValue, ok := to.(map[string]any); ok {
+			diffObjects(path, fromValue, toValue, changes)
+			return
+		}
+	case []any:
+		if toValue, ok := to.([]any); ok {
+			diffLists(path, fromValue, toValue, changes)
+			return
+		}
+	}
+
+	switch {
+	case reflect.DeepEqual(from, to):
+	case from == nil:
+		*changes = append(*changes, Change{Op: ChangeOpAdd, Path: path, To: to})
+	case to == nil:
+		*changes = append(*changes, Change{Op: ChangeOpRemove, Path: path, From: from})
+	default:
+		*changes = append(*changes, Change{Op: ChangeOpReplace, Path: path, From: from, To: to})
+	}
+}
+
+func diffObjects(path string, from, to map[string]any, changes *[]Change) {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		diffValues(path+"/"+escapePathSegment(key), from[key], to[key], changes)
+	}
+}
+
+func diffLists(path string, from, to []any, changes *[]Change) {
+	// The lists of named objects are compared by name, so that inserting an element does not
+	// report all the following elements as changed.
+	fromByName, fromNamed := byName(from)
+	toByName, toNamed := byName(to)
+	if fromNamed && toNamed {
+		diffObjects(path, fromByName, toByName, changes)
+		return
+	}
+
+	for i := 0; i < len(from) || i < len(to); i++ {
+		var fromValue, toValue any
+		if i < len(from) {
+			fromValue = from[i]
+		}
+		if i < len(to) {
+			toValue = to[i]
+		}
+		diffValues(path+"/"+strconv.Itoa(i), fromValue, toValue, changes)
+	}
+}
+
+// byName returns the elements of a list indexed by name, and whether all of the elements are
+// objects with a unique name.
+func byName(list []any) (map[string]any, bool) {
+	named := make(map[string]any, len(list))
+	for _, value := range list {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+		if _, ok := named[name]; ok {
+			return nil, false
+		}
+		named[name] = object
+	}
+	return named, true
+}
+
+// escapePathSegment escapes a segment of a JSON pointer, see RFC 6901.
+func escapePathSegment(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,86 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package history
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name string
+		from string
+		to   string
+		want []Change
+	}{
+		{
+			name: "no change",
+			from: `{"a":1,"b":[1,2]}`,
+			to:   `{"b":[1,2],"a":1}`,
+			want: []Change{},
+		},
+		{
+			name: "object fields",
+			from: `{"a":1,"b":"x","c":{"d":true}}`,
+			to:   `{"a":2,"c":{"d":true},"e/f":null}`,
+			want: []Change{
+				{Op: ChangeOpReplace, Path: "/a", From: json.Number("1"), To: json.Number("2")},
+				{Op: ChangeOpRemove, Path: "/b", From: "x"},
+			},
+		},
+		{
+			name: "escaped paths",
+			from: `{}`,
+			to:   `{"default/eg~1":1}`,
+			want: []Change{
+				{Op: ChangeOpAdd, Path: "/default~1eg~01", To: json.Number("1")},
+			},
+		},
+		{
+			name: "list by index",
+			from: `{"l":[1,2,3]}`,
+			to:   `{"l":[1,4]}`,
+			want: []Change{
+				{Op: ChangeOpReplace, Path: "/l/1", From: json.Number("2"), To: json.Number("4")},
+				{Op: ChangeOpRemove, Path: "/l/2", From: json.Number("3")},
+			},
+		},
+		{
+			name: "list by name",
+			from: `{"http":[{"name":"a","port":80},{"name":"b","port":81}]}`,
+			to:   `{"http":[{"name":"c","port":82},{"name":"a","port":8080},{"name":"b","port":81}]}`,
+			want: []Change{
+				{Op: ChangeOpReplace, Path: "/http/a/port", From: json.Number("80"), To: json.Number("8080")},
+				{Op: ChangeOpAdd, Path: "/http/c", To: map[string]any{"name": "c", "port": json.Number("82")}},
+			},
+		},
+		{
+			name: "deleted",
+			from: `{"a":1}`,
+			want: []Change{
+				{Op: ChangeOpRemove, Path: "", From: map[string]any{"a": json.Number("1")}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var from, to json.RawMessage
+			if tc.from != "" {
+				from = json.RawMessage(tc.from)
+			}
+			if tc.to != "" {
+				to = json.RawMessage(tc.to)
+			}
+			changes, err := Diff(from, to)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, changes)
+		})
+	}
+}
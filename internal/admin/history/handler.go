@@ -0,0 +1,122 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/envoyproxy/gateway/internal/logging"
+)
+
+const (
+	// keyParam is the query parameter selecting the IR key.
+	keyParam = "key"
+	// versionParam is the query parameter selecting a revision by version.
+	versionParam = "version"
+	// atParam is the query parameter selecting the revision in effect at an RFC 3339 time.
+	atParam = "at"
+	// fromParam is the query parameter selecting the version a diff starts from.
+	fromParam = "from"
+	// toParam is the query parameter selecting the version a diff ends at.
+	toParam = "to"
+)
+
+// newHandler returns the handler serving the revisions of a store:
+//   - without key, the revisions retained for each IR key.
+//   - with key, the resources of the latest revision of the IR key, or of the revision
+//     selected by version or at.
+func newHandler(store *Store, logger logging.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		key := query.Get(keyParam)
+		if key == "" {
+			writeJSON(w, logger, store.Histories())
+			return
+		}
+
+		var entry *Entry
+		var err error
+		if at := query.Get(atParam); at != "" {
+			var t time.Time
+			if t, err = time.Parse(time.RFC3339, at); err != nil {
+				http.Error(w, fmt.Sprintf("invalid %s: %v", atParam, err), http.StatusBadRequest)
+				return
+			}
+			entry, err = store.GetAt(key, t)
+		} else {
+			var version uint64
+			if version, err = parseVersion(query.Get(versionParam)); err != nil {
+				http.Error(w, fmt.Sprintf("invalid %s: %v", versionParam, err), http.StatusBadRequest)
+				return
+			}
+			entry, err = store.Get(key, version)
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, logger, entry)
+	})
+}
+
+// newDiffHandler returns the handler serving the diff between two revisions of an IR key of a
+// store. The diff ends at the latest revision unless to is set, and starts from the revision
+// preceding it unless from is set.
+func newDiffHandler(store *Store, logger logging.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		key := query.Get(keyParam)
+		if key == "" {
+			http.Error(w, fmt.Sprintf("missing %s", keyParam), http.StatusBadRequest)
+			return
+		}
+		from, err := parseVersion(query.Get(fromParam))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s: %v", fromParam, err), http.StatusBadRequest)
+			return
+		}
+		to, err := parseVersion(query.Get(toParam))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s: %v", toParam, err), http.StatusBadRequest)
+			return
+		}
+
+		diff, err := store.Diff(key, from, to)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, logger, diff)
+	})
+}
+
+// parseVersion parses a version query parameter, returning 0 if unset.
+func parseVersion(value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func writeJSON(w http.ResponseWriter, logger logging.Logger, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		logger.Error(err, "failed to write the history")
+	}
+}
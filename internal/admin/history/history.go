@@ -0,0 +1,191 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when the history has no revision matching a request.
+var ErrNotFound = errors.New("revision not found")
+
+// Revision describes a revision of the resources of an IR key.
+type Revision struct {
+	// Version is the sequence number of the revision, starting at 1 for each IR key.
+	Version uint64 `json:"version"`
+	// Time is the time the revision was published.
+	Time time.Time `json:"time"`
+	// Deleted is true if the resources of the IR key were deleted.
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// Entry is a revision and the resources of the IR key at that revision.
+type Entry struct {
+	Revision
+	// Resources are the resources encoded in JSON, or nil if they were deleted.
+	Resources json.RawMessage `json:"resources,omitempty"`
+}
+
+// KeyHistory lists the revisions of an IR key retained in the history.
+type KeyHistory struct {
+	Key       string     `json:"key"`
+	Revisions []Revision `json:"revisions"`
+}
+
+// DiffResult is the difference between the resources of two revisions of an IR key.
+type DiffResult struct {
+	Key     string   `json:"key"`
+	From    Revision `json:"from"`
+	To      Revision `json:"to"`
+	Changes []Change `json:"changes"`
+}
+
+// Store retains the last revisions of the resources of each IR key.
+type Store struct {
+	mu       sync.RWMutex
+	limit    int
+	now      func() time.Time
+	entries  map[string][]*Entry
+	versions map[string]uint64
+}
+
+// NewStore returns a store retaining up to limit revisions per IR key.
+func NewStore(limit int) *Store {
+	return &Store{
+		limit:    limit,
+		now:      time.Now,
+		entries:  make(map[string][]*Entry),
+		versions: make(map[string]uint64),
+	}
+}
+
+// Record records a revision of the resources of an IR key, or its deletion if resources is nil.
+// The revision is ignored if the resources did not change since the last revision.
+func (s *Store) Record(key string, resources json.RawMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.entries[key]
+	if n := len(entries); n > 0 {
+		last := entries[n-1]
+		if last.Deleted == (resources == nil) && bytes.Equal(last.Resources, resources) {
+			return
+		}
+	} else if resources == nil {
+		// Nothing to record for a key deleted before its first revision.
+		return
+	}
+
+	s.versions[key]++
+	entries = append(entries, &Entry{
+		Revision: Revision{
+			Version: s.versions[key],
+			Time:    s.now(),
+			Deleted: resources == nil,
+		},
+		Resources: resources,
+	})
+	if len(entries) > s.limit {
+		entries = entries[len(entries)-s.limit:]
+	}
+	s.entries[key] = entries
+}
+
+// Histories returns the revisions retained for each IR key, sorted by key.
+func (s *Store) Histories() []KeyHistory {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	histories := make([]KeyHistory, 0, len(s.entries))
+	for key, entries := range s.entries {
+		history := KeyHistory{Key: key, Revisions: make([]Revision, 0, len(entries))}
+		for _, entry := range entries {
+			history.Revisions = append(history.Revisions, entry.Revision)
+		}
+		histories = append(histories, history)
+	}
+	sort.Slice(histories, func(i, j int) bool { return histories[i].Key < histories[j].Key })
+	return histories
+}
+
+// Get returns the revision of an IR key with the given version, or the latest revision if
+// version is 0.
+func (s *Store) Get(key string, version uint64) (*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.get(key, version)
+}
+
+// GetAt returns the revision of an IR key which was in effect at the given time.
+func (s *Store) GetAt(key string, at time.Time) (*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := s.entries[key]
+	for i := len(entries) - 1; i >= 0; i-- {
+		if !entries[i].Time.After(at) {
+			return entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no revision of %s at %s", ErrNotFound, key, at.Format(time.RFC3339))
+}
+
+// Diff returns the difference between two revisions of an IR key. The latest revision is used if
+// to is 0, and the revision preceding it if from is 0.
+func (s *Store) Diff(key string, from, to uint64) (*DiffResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	toEntry, err := s.get(key, to)
+	if err != nil {
+		return nil, err
+	}
+	if from == 0 {
+		if toEntry.Version == 1 {
+			return nil, fmt.Errorf("%w: %s has no revision before version %d", ErrNotFound, key, toEntry.Version)
+		}
+		from = toEntry.Version - 1
+	}
+	fromEntry, err := s.get(key, from)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := Diff(fromEntry.Resources, toEntry.Resources)
+	if err != nil {
+		return nil, err
+	}
+	return &DiffResult{
+		Key:     key,
+		From:    fromEntry.Revision,
+		To:      toEntry.Revision,
+		Changes: changes,
+	}, nil
+}
+
+// get returns the revision of an IR key with the given version, or the latest one if version is 0.
+// The caller must hold the lock.
+func (s *Store) get(key string, version uint64) (*Entry, error) {
+	entries := s.entries[key]
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: no revision of %s", ErrNotFound, key)
+	}
+	if version == 0 {
+		return entries[len(entries)-1], nil
+	}
+	for _, entry := range entries {
+		if entry.Version == version {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: version %d of %s is not retained", ErrNotFound, version, key)
+}
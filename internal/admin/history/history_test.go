@@ -0,0 +1,212 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package history
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func newTestStore(limit int) (*Store, *time.Time) {
+	now := time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC)
+	store := NewStore(limit)
+	store.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+	return store, &now
+}
+
+func TestStore(t *testing.T) {
+	store, _ := newTestStore(3)
+
+	// A deletion is not recorded before the first revision.
+	store.Record("eg", nil)
+	require.Empty(t, store.Histories())
+
+	store.Record("eg", json.RawMessage(`{"v":1}`))
+	store.Record("eg", json.RawMessage(`{"v":1}`))
+	store.Record("eg", json.RawMessage(`{"v":2}`))
+	store.Record("eg", nil)
+	store.Record("eg", json.RawMessage(`{"v":3}`))
+	store.Record("other", json.RawMessage(`{}`))
+
+	// Unchanged revisions are ignored, and only the last three revisions are retained.
+	require.Equal(t, []KeyHistory{
+		{
+			Key: "eg",
+			Revisions: []Revision{
+				{Version: 2, Time: time.Date(2024, 1, 1, 14, 2, 0, 0, time.UTC)},
+				{Version: 3, Time: time.Date(2024, 1, 1, 14, 3, 0, 0, time.UTC), Deleted: true},
+				{Version: 4, Time: time.Date(2024, 1, 1, 14, 4, 0, 0, time.UTC)},
+			},
+		},
+		{
+			Key:       "other",
+			Revisions: []Revision{{Version: 1, Time: time.Date(2024, 1, 1, 14, 5, 0, 0, time.UTC)}},
+		},
+	}, store.Histories())
+
+	entry, err := store.Get("eg", 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), entry.Version)
+	entry, err = store.Get("eg", 2)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"v":2}`, string(entry.Resources))
+	_, err = store.Get("eg", 1)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = store.Get("missing", 0)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	entry, err = store.GetAt("eg", time.Date(2024, 1, 1, 14, 2, 30, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), entry.Version)
+	_, err = store.GetAt("eg", time.Date(2024, 1, 1, 14, 1, 0, 0, time.UTC))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	diff, err := store.Diff("eg", 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), diff.From.Version)
+	require.Equal(t, uint64(4), diff.To.Version)
+	require.Equal(t, []Change{
+		{Op: ChangeOpReplace, Path: "/v", From: json.Number("2"), To: json.Number("3")},
+	}, diff.Changes)
+
+	// The diff starts from the preceding revision by default.
+	diff, err = store.Diff("eg", 0, 3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), diff.From.Version)
+	require.Equal(t, []Change{
+		{Op: ChangeOpRemove, Path: "", From: map[string]any{"v": json.Number("2")}},
+	}, diff.Changes)
+	_, err = store.Diff("other", 0, 0)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestHandlers(t *testing.T) {
+	store, _ := newTestStore(10)
+	store.Record("default/eg", json.RawMessage(`{"v":1}`))
+	store.Record("default/eg", json.RawMessage(`{"v":2}`))
+	logger := logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo)
+
+	testCases := []struct {
+		name     string
+		handler  http.Handler
+		query    string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "histories",
+			handler:  newHandler(store, logger),
+			wantCode: http.StatusOK,
+			wantBody: `[{"key":"default/eg","revisions":[{"version":1,"time":"2024-01-01T14:01:00Z"},{"version":2,"time":"2024-01-01T14:02:00Z"}]}]`,
+		},
+		{
+			name:     "latest revision",
+			handler:  newHandler(store, logger),
+			query:    "?key=default/eg",
+			wantCode: http.StatusOK,
+			wantBody: `{"version":2,"time":"2024-01-01T14:02:00Z","resources":{"v":2}}`,
+		},
+		{
+			name:     "revision by version",
+			handler:  newHandler(store, logger),
+			query:    "?key=default/eg&version=1",
+			wantCode: http.StatusOK,
+			wantBody: `{"version":1,"time":"2024-01-01T14:01:00Z","resources":{"v":1}}`,
+		},
+		{
+			name:     "revision by time",
+			handler:  newHandler(store, logger),
+			query:    "?key=default/eg&at=2024-01-01T14:01:30Z",
+			wantCode: http.StatusOK,
+			wantBody: `{"version":1,"time":"2024-01-01T14:01:00Z","resources":{"v":1}}`,
+		},
+		{
+			name:     "invalid time",
+			handler:  newHandler(store, logger),
+			query:    "?key=default/eg&at=14:02",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown key",
+			handler:  newHandler(store, logger),
+			query:    "?key=default/other",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "diff",
+			handler:  newDiffHandler(store, logger),
+			query:    "?key=default/eg",
+			wantCode: http.StatusOK,
+			wantBody: `{"key":"default/eg","from":{"version":1,"time":"2024-01-01T14:01:00Z"},"to":{"version":2,"time":"2024-01-01T14:02:00Z"},"changes":[{"op":"replace","path":"/v","from":1,"to":2}]}`,
+		},
+		{
+			name:     "diff without key",
+			handler:  newDiffHandler(store, logger),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "diff with invalid version",
+			handler:  newDiffHandler(store, logger),
+			query:    "?key=default/eg&from=first",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tc.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, IRPath+tc.query, nil))
+			require.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantBody != "" {
+				require.JSONEq(t, tc.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestEncodeXdsResources(t *testing.T) {
+	secret := &tlsv3.Secret{
+		Name: "cert",
+		Type: &tlsv3.Secret_TlsCertificate{
+			TlsCertificate: &tlsv3.TlsCertificate{
+				CertificateChain: &corev3.DataSource{Specifier: &corev3.DataSource_InlineString{InlineString: "chain"}},
+				PrivateKey:       &corev3.DataSource{Specifier: &corev3.DataSource_InlineBytes{InlineBytes: []byte("key")}},
+			},
+		},
+	}
+	data, err := encodeXdsResources(xdstypes.XdsResources{
+		resourcev3.ListenerType: []types.Resource{&listenerv3.Listener{Name: "listener"}},
+		resourcev3.SecretType:   []types.Resource{secret},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"`+resourcev3.ListenerType+`": {"listener": {"name": "listener"}},
+		"`+resourcev3.SecretType+`": {"cert": {"name": "cert", "tlsCertificate": {
+			"certificateChain": {"inlineString": "chain"},
+			"privateKey": {"inlineString": "[redacted]"}
+		}}}
+	}`, string(data))
+	// The original secret is not modified.
+	require.Equal(t, []byte("key"), secret.GetTlsCertificate().GetPrivateKey().GetInlineBytes())
+}
@@ -0,0 +1,173 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package history
+
+import (
+	"context"
+	"encoding/json"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/telepresenceio/watchable"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/envoyproxy/gateway/internal/admin"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// IRPath is the path of the admin server endpoint serving the history of the xDS IR.
+	IRPath = "/api/history/ir"
+	// XdsPath is the path of the admin server endpoint serving the history of the xDS resources.
+	XdsPath = "/api/history/xds"
+	// diffPathSuffix is appended to the path of a history to serve the diff between two revisions.
+	diffPathSuffix = "/diff"
+
+	// runnerName is the name of the runner, and of its logger.
+	runnerName = "admin-history"
+
+	// redacted replaces the secret values of the recorded xDS resources.
+	redacted = "[redacted]"
+)
+
+type Config struct {
+	config.Server
+	XdsIR *message.XdsIR
+	Xds   *message.Xds
+}
+
+// Runner records the history of the xDS IR and xDS resources of each IR key, and serves it
+// on the admin server.
+type Runner struct {
+	Config
+	ir  *Store
+	xds *Store
+}
+
+func New(cfg *Config) *Runner {
+	return &Runner{Config: *cfg}
+}
+
+func (r *Runner) Name() string {
+	return runnerName
+}
+
+// Close implements Runner interface.
+func (r *Runner) Close() error { return nil }
+
+// Start starts the history runner
+func (r *Runner) Start(ctx context.Context) error {
+	r.Logger = r.Logger.WithName(r.Name()).WithValues("runner", r.Name())
+
+	limit := r.EnvoyGateway.GetEnvoyGatewayAdmin().GetHistoryLimit()
+	if limit == 0 {
+		r.Logger.Info("history is disabled")
+		return nil
+	}
+	r.ir = NewStore(limit)
+	r.xds = NewStore(limit)
+	admin.RegisterHandler(IRPath, newHandler(r.ir, r.Logger))
+	admin.RegisterHandler(IRPath+diffPathSuffix, newDiffHandler(r.ir, r.Logger))
+	admin.RegisterHandler(XdsPath, newHandler(r.xds, r.Logger))
+	admin.RegisterHandler(XdsPath+diffPathSuffix, newDiffHandler(r.xds, r.Logger))
+
+	// Do not call .Subscribe() inside Goroutine since it is supposed to be called from the same
+	// Goroutine where Close() is called.
+	go r.subscribeXdsIR(r.XdsIR.Subscribe(ctx))
+	go r.subscribeXds(r.Xds.Subscribe(ctx))
+	r.Logger.Info("started", "limit", limit)
+	return nil
+}
+
+func (r *Runner) subscribeXdsIR(sub <-chan watchable.Snapshot[string, *ir.Xds]) {
+	message.HandleSubscription(message.Metadata{Runner: runnerName, Message: "xds-ir"}, sub,
+		func(update message.Update[string, *ir.Xds], errChan chan error) {
+			if update.Delete || update.Value == nil {
+				r.ir.Record(update.Key, nil)
+				return
+			}
+			// The secrets of the IR are redacted when it is encoded.
+			data, err := json.Marshal(update.Value)
+			if err != nil {
+				r.Logger.Error(err, "failed to encode the xds ir", "ir-key", update.Key)
+				errChan <- err
+				return
+			}
+			r.ir.Record(update.Key, data)
+		},
+	)
+	r.Logger.Info("xds ir subscriber shutting down")
+}
+
+func (r *Runner) subscribeXds(sub <-chan watchable.Snapshot[string, *xdstypes.ResourceVersionTable]) {
+	message.HandleSubscription(message.Metadata{Runner: runnerName, Message: "xds"}, sub,
+		func(update message.Update[string, *xdstypes.ResourceVersionTable], errChan chan error) {
+			if update.Delete || update.Value == nil {
+				r.xds.Record(update.Key, nil)
+				return
+			}
+			data, err := encodeXdsResources(update.Value.XdsResources)
+			if err != nil {
+				r.Logger.Error(err, "failed to encode the xds resources", "ir-key", update.Key)
+				errChan <- err
+				return
+			}
+			r.xds.Record(update.Key, data)
+		},
+	)
+	r.Logger.Info("xds subscriber shutting down")
+}
+
+// encodeXdsResources encodes the xDS resources in JSON as an object mapping the type URLs to
+// the resources indexed by name. The private keys and secrets of the Secret resources are redacted.
+func encodeXdsResources(resources xdstypes.XdsResources) (json.RawMessage, error) {
+	encoded := make(map[string]map[string]json.RawMessage, len(resources))
+	for typeURL, typeResources := range resources {
+		byName := make(map[string]json.RawMessage, len(typeResources))
+		for _, resource := range typeResources {
+			if secret, ok := resource.(*tlsv3.Secret); ok && typeURL == resourcev3.SecretType {
+				resource = redactSecret(secret)
+			}
+			data, err := protojson.Marshal(resource)
+			if err != nil {
+				return nil, err
+			}
+			byName[cachev3.GetResourceName(resource)] = data
+		}
+		encoded[typeURL] = byName
+	}
+	return json.Marshal(encoded)
+}
+
+// redactSecret returns a copy of a Secret whose private values are redacted.
+func redactSecret(secret *tlsv3.Secret) *tlsv3.Secret {
+	secret = proto.Clone(secret).(*tlsv3.Secret)
+	redactedSource := &corev3.DataSource{Specifier: &corev3.DataSource_InlineString{InlineString: redacted}}
+	switch t := secret.Type.(type) {
+	case *tlsv3.Secret_TlsCertificate:
+		if t.TlsCertificate.PrivateKey != nil {
+			t.TlsCertificate.PrivateKey = redactedSource
+		}
+		if t.TlsCertificate.Password != nil {
+			t.TlsCertificate.Password = redactedSource
+		}
+	case *tlsv3.Secret_GenericSecret:
+		if t.GenericSecret.Secret != nil {
+			t.GenericSecret.Secret = redactedSource
+		}
+	case *tlsv3.Secret_SessionTicketKeys:
+		for i := range t.SessionTicketKeys.Keys {
+			t.SessionTicketKeys.Keys[i] = redactedSource
+		}
+	}
+	return secret
+}
@@ -0,0 +1,146 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package introspection
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/telepresenceio/watchable"
+	"k8s.io/apimachinery/pkg/types"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// redacted replaces the values of the Secrets of the provider resources.
+const redacted = "[redacted]"
+
+// Statuses are the statuses published by the control plane, by resource.
+type Statuses struct {
+	GatewayClasses         map[string]*gwapiv1.GatewayClassStatus `json:"gatewayClasses,omitempty"`
+	Gateways               map[string]*gwapiv1.GatewayStatus      `json:"gateways,omitempty"`
+	HTTPRoutes             map[string]*gwapiv1.HTTPRouteStatus    `json:"httpRoutes,omitempty"`
+	GRPCRoutes             map[string]*gwapiv1.GRPCRouteStatus    `json:"grpcRoutes,omitempty"`
+	TLSRoutes              map[string]*gwapiv1a2.TLSRouteStatus   `json:"tlsRoutes,omitempty"`
+	TCPRoutes              map[string]*gwapiv1a2.TCPRouteStatus   `json:"tcpRoutes,omitempty"`
+	UDPRoutes              map[string]*gwapiv1a2.UDPRouteStatus   `json:"udpRoutes,omitempty"`
+	ClientTrafficPolicies  map[string]*gwapiv1a2.PolicyStatus     `json:"clientTrafficPolicies,omitempty"`
+	BackendTrafficPolicies map[string]*gwapiv1a2.PolicyStatus     `json:"backendTrafficPolicies,omitempty"`
+	EnvoyPatchPolicies     map[string]*gwapiv1a2.PolicyStatus     `json:"envoyPatchPolicies,omitempty"`
+	SecurityPolicies       map[string]*gwapiv1a2.PolicyStatus     `json:"securityPolicies,omitempty"`
+	BackendTLSPolicies     map[string]*gwapiv1a2.PolicyStatus     `json:"backendTLSPolicies,omitempty"`
+	EnvoyExtensionPolicies map[string]*gwapiv1a2.PolicyStatus     `json:"envoyExtensionPolicies,omitempty"`
+	// ExtensionPolicies are indexed by <kind>.<group>/<namespace>/<name>.
+	ExtensionPolicies map[string]*gwapiv1a2.PolicyStatus `json:"extensionPolicies,omitempty"`
+	Backends          map[string]*egv1a1.BackendStatus   `json:"backends,omitempty"`
+	// Xds are the statuses of the xDS resources reported by the proxies, by IR key.
+	Xds map[string]*xdstypes.XdsStatus `json:"xds,omitempty"`
+}
+
+// providerResources returns the provider resources by GatewayClass name, or only those of the
+// GatewayClass of the request. The values of the Secrets are redacted.
+func (r *Runner) providerResources(req *http.Request) (any, error) {
+	gatewayClass := req.URL.Query().Get(GatewayClassParam)
+
+	resources := map[string]*resource.Resources{}
+	// LoadAll returns deep copies, so the Secrets can be redacted in place.
+	for _, controllerResources := range r.ProviderResources.GatewayAPIResources.LoadAll() {
+		if controllerResources == nil {
+			continue
+		}
+		for _, res := range *controllerResources {
+			if res == nil || res.GatewayClass == nil {
+				continue
+			}
+			if gatewayClass != "" && res.GatewayClass.Name != gatewayClass {
+				continue
+			}
+			redactSecrets(res)
+			resources[res.GatewayClass.Name] = res
+		}
+	}
+	if gatewayClass != "" && len(resources) == 0 {
+		return nil, errNotFound(fmt.Sprintf("no resources for GatewayClass %s", gatewayClass))
+	}
+	return resources, nil
+}
+
+// xdsIR returns the xDS IR by IR key, or only the IR of the key of the request. The secrets of
+// the IR are redacted when it is encoded.
+func (r *Runner) xdsIR(req *http.Request) (any, error) {
+	return loadByKey(&r.XdsIR.Map, req.URL.Query().Get(KeyParam))
+}
+
+// infraIR returns the infra IR by IR key, or only the IR of the key of the request.
+func (r *Runner) infraIR(req *http.Request) (any, error) {
+	return loadByKey(&r.InfraIR.Map, req.URL.Query().Get(KeyParam))
+}
+
+func loadByKey[V any](m *watchable.Map[string, V], key string) (any, error) {
+	if key == "" {
+		return m.LoadAll(), nil
+	}
+	value, ok := m.Load(key)
+	if !ok {
+		return nil, errNotFound(fmt.Sprintf("no IR for key %s", key))
+	}
+	return map[string]V{key: value}, nil
+}
+
+// statuses returns the statuses published by the control plane.
+func (r *Runner) statuses(_ *http.Request) (any, error) {
+	p := r.ProviderResources
+	return &Statuses{
+		GatewayClasses:         loadStatuses(&p.GatewayClassStatuses, types.NamespacedName.String),
+		Gateways:               loadStatuses(&p.GatewayStatuses, types.NamespacedName.String),
+		HTTPRoutes:             loadStatuses(&p.HTTPRouteStatuses, types.NamespacedName.String),
+		GRPCRoutes:             loadStatuses(&p.GRPCRouteStatuses, types.NamespacedName.String),
+		TLSRoutes:              loadStatuses(&p.TLSRouteStatuses, types.NamespacedName.String),
+		TCPRoutes:              loadStatuses(&p.TCPRouteStatuses, types.NamespacedName.String),
+		UDPRoutes:              loadStatuses(&p.UDPRouteStatuses, types.NamespacedName.String),
+		ClientTrafficPolicies:  loadStatuses(&p.ClientTrafficPolicyStatuses, types.NamespacedName.String),
+		BackendTrafficPolicies: loadStatuses(&p.BackendTrafficPolicyStatuses, types.NamespacedName.String),
+		EnvoyPatchPolicies:     loadStatuses(&p.EnvoyPatchPolicyStatuses, types.NamespacedName.String),
+		SecurityPolicies:       loadStatuses(&p.SecurityPolicyStatuses, types.NamespacedName.String),
+		BackendTLSPolicies:     loadStatuses(&p.BackendTLSPolicyStatuses, types.NamespacedName.String),
+		EnvoyExtensionPolicies: loadStatuses(&p.EnvoyExtensionPolicyStatuses, types.NamespacedName.String),
+		ExtensionPolicies: loadStatuses(&p.ExtensionPolicyStatuses, func(key message.NamespacedNameAndGVK) string {
+			return fmt.Sprintf("%s/%s", key.GroupKind(), key.NamespacedName)
+		}),
+		Backends: loadStatuses(&p.BackendStatuses, types.NamespacedName.String),
+		Xds:      loadStatuses(&p.XdsStatuses, func(key string) string { return key }),
+	}, nil
+}
+
+func loadStatuses[K comparable, V any](m *watchable.Map[K, V], keyString func(K) string) map[string]V {
+	all := m.LoadAll()
+	if len(all) == 0 {
+		return nil
+	}
+	statuses := make(map[string]V, len(all))
+	for key, status := range all {
+		statuses[keyString(key)] = status
+	}
+	return statuses
+}
+
+// redactSecrets redacts the values of the Secrets of the resources.
+func redactSecrets(res *resource.Resources) {
+	for _, secret := range res.Secrets {
+		resource.RedactSecretMetadata(secret)
+		for key := range secret.Data {
+			secret.Data[key] = []byte(redacted)
+		}
+		for key := range secret.StringData {
+			secret.StringData[key] = redacted
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package introspection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/internal/admin"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+const (
+	// ProviderResourcesPath is the path of the admin server endpoint serving the provider
+	// resources consumed by the translator, by GatewayClass name.
+	ProviderResourcesPath = "/api/resources/provider"
+	// XdsIRPath is the path of the admin server endpoint serving the xDS IR, by IR key.
+	XdsIRPath = "/api/ir/xds"
+	// InfraIRPath is the path of the admin server endpoint serving the infra IR, by IR key.
+	InfraIRPath = "/api/ir/infra"
+	// StatusesPath is the path of the admin server endpoint serving the published statuses.
+	StatusesPath = "/api/statuses"
+
+	// GatewayClassParam is the query parameter selecting the provider resources of a GatewayClass.
+	GatewayClassParam = "gatewayClass"
+	// KeyParam is the query parameter selecting the IR of an IR key, which is the namespaced
+	// name of a Gateway, or the name of a GatewayClass when its Gateways are merged.
+	KeyParam = "key"
+	// OutputParam is the query parameter selecting the output format, json or yaml.
+	OutputParam = "output"
+
+	// runnerName is the name of the runner, and of its logger.
+	runnerName = "admin-introspection"
+)
+
+type Config struct {
+	config.Server
+	ProviderResources *message.ProviderResources
+	XdsIR             *message.XdsIR
+	InfraIR           *message.InfraIR
+}
+
+// Runner serves the current provider resources, IRs and statuses of the control plane on the
+// admin server.
+type Runner struct {
+	Config
+}
+
+func New(cfg *Config) *Runner {
+	return &Runner{Config: *cfg}
+}
+
+func (r *Runner) Name() string {
+	return runnerName
+}
+
+// Close implements Runner interface.
+func (r *Runner) Close() error { return nil }
+
+// Start registers the introspection endpoints on the admin server.
+func (r *Runner) Start(_ context.Context) error {
+	r.Logger = r.Logger.WithName(r.Name()).WithValues("runner", r.Name())
+
+	admin.RegisterHandler(ProviderResourcesPath, r.handler(r.providerResources))
+	admin.RegisterHandler(XdsIRPath, r.handler(r.xdsIR))
+	admin.RegisterHandler(InfraIRPath, r.handler(r.infraIR))
+	admin.RegisterHandler(StatusesPath, r.handler(r.statuses))
+	r.Logger.Info("started")
+	return nil
+}
+
+// errNotFound is returned by the load functions when the requested resources don't exist.
+type errNotFound string
+
+func (e errNotFound) Error() string { return string(e) }
+
+// handler returns a handler serving the value returned by load in the output format of the request.
+func (r *Runner) handler(load func(req *http.Request) (any, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		output := req.URL.Query().Get(OutputParam)
+		if output != "" && output != "json" && output != "yaml" {
+			http.Error(w, fmt.Sprintf("invalid %s %q, must be json or yaml", OutputParam, output), http.StatusBadRequest)
+			return
+		}
+
+		value, err := load(req)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if _, ok := err.(errNotFound); ok {
+				code = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+
+		data, err := json.Marshal(value)
+		if err == nil && output == "yaml" {
+			data, err = yaml.JSONToYAML(data)
+		}
+		if err != nil {
+			r.Logger.Error(err, "failed to encode the response", "path", req.URL.Path)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if output == "yaml" {
+			w.Header().Set("Content-Type", "application/yaml")
+		} else {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		if _, err := w.Write(data); err != nil {
+			r.Logger.Error(err, "failed to write the response", "path", req.URL.Path)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package introspection
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func newTestRunner(t *testing.T) *Runner {
+	t.Helper()
+	pResources := new(message.ProviderResources)
+	xdsIR := new(message.XdsIR)
+	infraIR := new(message.InfraIR)
+	t.Cleanup(func() {
+		pResources.Close()
+		xdsIR.Close()
+		infraIR.Close()
+	})
+
+	pResources.GatewayAPIResources.Store("envoy-gateway", &resource.ControllerResources{
+		{
+			GatewayClass: &gwapiv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "eg"}},
+			Secrets: []*corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "cert",
+						Annotations: map[string]string{
+							"kubectl.kubernetes.io/last-applied-configuration": `{"data":{"tls.key":"a2V5"}}`,
+						},
+						ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+					},
+					Data: map[string][]byte{"tls.key": []byte("key")},
+				},
+			},
+		},
+		{
+			GatewayClass: &gwapiv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		},
+	})
+	pResources.GatewayStatuses.Store(types.NamespacedName{Namespace: "default", Name: "eg"}, &gwapiv1.GatewayStatus{
+		Conditions: []metav1.Condition{{Type: "Programmed", Status: metav1.ConditionTrue}},
+	})
+	pResources.XdsStatuses.Store("default/eg", &xdstypes.XdsStatus{
+		NACKs: []xdstypes.NACK{{NodeID: "envoy-1", TypeURL: "listener", Message: "invalid"}},
+	})
+	xdsIR.Store("default/eg", &ir.Xds{
+		HTTP: []*ir.HTTPListener{
+			{
+				CoreListenerDetails: ir.CoreListenerDetails{Name: "http"},
+				TLS: &ir.TLSConfig{
+					Certificates: []ir.TLSCertificate{{Name: "cert", PrivateKey: ir.PrivateBytes("key")}},
+				},
+			},
+		},
+	})
+	infraIR.Store("default/eg", &ir.Infra{Proxy: &ir.ProxyInfra{Name: "default/eg"}})
+
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+	cfg.Logger = logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo)
+	return New(&Config{
+		Server:            *cfg,
+		ProviderResources: pResources,
+		XdsIR:             xdsIR,
+		InfraIR:           infraIR,
+	})
+}
+
+func TestHandlers(t *testing.T) {
+	r := newTestRunner(t)
+
+	testCases := []struct {
+		name     string
+		load     func(req *http.Request) (any, error)
+		query    string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "provider resources of a GatewayClass with redacted secrets",
+			load:     r.providerResources,
+			query:    "?gatewayClass=eg",
+			wantCode: http.StatusOK,
+			wantBody: `{"eg":{"gatewayClass":{"metadata":{"name":"eg","creationTimestamp":null},"spec":{"controllerName":""},"status":{}},` +
+				`"secrets":[{"metadata":{"name":"cert","namespace":"default","creationTimestamp":null},"data":{"tls.key":"W3JlZGFjdGVkXQ=="}}]}}`,
+		},
+		{
+			name:     "provider resources of an unknown GatewayClass",
+			load:     r.providerResources,
+			query:    "?gatewayClass=unknown",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "xds ir with redacted secrets",
+			load:     r.xdsIR,
+			query:    "?key=default/eg",
+			wantCode: http.StatusOK,
+			wantBody: `{"default/eg":{"http":[{"name":"http","address":"","port":0,"hostnames":null,"isHTTP2":false,"path":{"mergeSlashes":false,"escapedSlashesAction":""},` +
+				`"tls":{"certificates":[{"name":"cert","privateKey":"[redacted]"}],"alpnProtocols":null}}]}}`,
+		},
+		{
+			name:     "infra ir of an unknown key",
+			load:     r.infraIR,
+			query:    "?key=default/unknown",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "statuses",
+			load:     r.statuses,
+			wantCode: http.StatusOK,
+			wantBody: `{"gateways":{"default/eg":{"conditions":[{"type":"Programmed","status":"True","lastTransitionTime":null,"reason":"","message":""}]}},` +
+				`"xds":{"default/eg":{"nacks":[{"nodeID":"envoy-1","typeURL":"listener","message":"invalid"}]}}}`,
+		},
+		{
+			name:     "invalid output",
+			load:     r.statuses,
+			query:    "?output=xml",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r.handler(tc.load).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/test"+tc.query, nil))
+			require.Equal(t, tc.wantCode, rec.Code, rec.Body.String())
+			if tc.wantBody != "" {
+				require.JSONEq(t, tc.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerYAMLOutput(t *testing.T) {
+	r := newTestRunner(t)
+
+	rec := httptest.NewRecorder()
+	r.handler(r.infraIR).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, InfraIRPath+"?output=yaml", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/yaml", rec.Header().Get("Content-Type"))
+	require.Contains(t, rec.Body.String(), "default/eg:\n  proxy:\n")
+}
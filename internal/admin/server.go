@@ -8,6 +8,7 @@ package admin
 import (
 	"net/http"
 	"net/http/pprof"
+	"sync"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
@@ -15,6 +16,35 @@ import (
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 )
 
+// apiPathPrefix is the path prefix of the endpoints registered by the runners.
+const apiPathPrefix = "/api/"
+
+var (
+	apiHandlersMu sync.RWMutex
+	apiHandlers   = map[string]http.Handler{}
+)
+
+// RegisterHandler registers the handler of an admin server endpoint, whose path must start
+// with /api/. It replaces the handler previously registered for the same path, e.g. by a
+// runner which was restarted after the configuration changed.
+func RegisterHandler(path string, handler http.Handler) {
+	apiHandlersMu.Lock()
+	defer apiHandlersMu.Unlock()
+	apiHandlers[path] = handler
+}
+
+// serveAPI serves the requests of the endpoints registered by the runners.
+func serveAPI(w http.ResponseWriter, req *http.Request) {
+	apiHandlersMu.RLock()
+	handler, ok := apiHandlers[req.URL.Path]
+	apiHandlersMu.RUnlock()
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	handler.ServeHTTP(w, req)
+}
+
 func Init(cfg *config.Server) error {
 	if cfg.EnvoyGateway.GetEnvoyGatewayAdmin().EnableDumpConfig {
 		spewConfig := spew.NewDefaultConfig()
@@ -41,6 +71,7 @@ func start(cfg *config.Server) error {
 		handlers.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 		handlers.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
 	}
+	handlers.HandleFunc(apiPathPrefix, serveAPI)
 
 	adminServer := &http.Server{
 		Handler:           handlers,
@@ -6,6 +6,8 @@
 package admin
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -27,3 +29,22 @@ func TestInitAdminServer(t *testing.T) {
 	err := Init(svrConfig)
 	require.NoError(t, err)
 }
+
+func TestRegisterHandler(t *testing.T) {
+	RegisterHandler("/api/test", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("first"))
+	}))
+	// The handler of a path is replaced when registered again.
+	RegisterHandler("/api/test", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("second"))
+	}))
+
+	rec := httptest.NewRecorder()
+	serveAPI(rec, httptest.NewRequest(http.MethodGet, "/api/test", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "second", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	serveAPI(rec, httptest.NewRequest(http.MethodGet, "/api/unknown", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
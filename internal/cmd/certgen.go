@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	clicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
 
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
@@ -26,30 +27,32 @@ import (
 // cfgPath is the path to the EnvoyGateway configuration file.
 var overwriteControlPlaneCerts bool
 
-// TODO: make this path configurable or use server config directly.
-const defaultLocalCertPath = "/tmp/envoy-gateway/certs"
-
 // GetCertGenCommand returns the certGen cobra command to be executed.
 func GetCertGenCommand() *cobra.Command {
-	var local bool
+	var (
+		local     bool
+		localPath string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "certgen",
 		Short: "Generate Control Plane Certificates",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return certGen(cmd.Context(), cmd.OutOrStdout(), local)
+			return certGen(cmd.Context(), cmd.OutOrStdout(), local, localPath)
 		},
 	}
 
 	cmd.PersistentFlags().BoolVarP(&local, "local", "l", false,
 		"Generate all the certificates locally.")
+	cmd.PersistentFlags().StringVar(&localPath, "local-path", egv1a1.DefaultHostCertificatesDir,
+		"The directory where the certificates are generated locally.")
 	cmd.PersistentFlags().BoolVarP(&overwriteControlPlaneCerts, "overwrite", "o", false,
 		"Updates the secrets containing the control plane certs.")
 	return cmd
 }
 
 // certGen generates control plane certificates.
-func certGen(ctx context.Context, logOut io.Writer, local bool) error {
+func certGen(ctx context.Context, logOut io.Writer, local bool, localPath string) error {
 	cfg, err := config.New(logOut)
 	if err != nil {
 		return err
@@ -72,8 +75,8 @@ func certGen(ctx context.Context, logOut io.Writer, local bool) error {
 			return fmt.Errorf("failed to output certificates: %w", err)
 		}
 	} else {
-		log.Info("generated certificates", "path", defaultLocalCertPath)
-		if err = outputCertsForLocal(defaultLocalCertPath, certs); err != nil {
+		log.Info("generated certificates", "path", localPath)
+		if err = outputCertsForLocal(localPath, certs); err != nil {
 			return fmt.Errorf("failed to output certificates locally: %w", err)
 		}
 	}
@@ -106,40 +109,40 @@ func outputCertsForKubernetes(ctx context.Context, cli client.Client, cfg *confi
 
 // outputCertsForLocal outputs the provided certs to the local directory as files.
 func outputCertsForLocal(localPath string, certs *crypto.Certificates) (err error) {
-	egDir := path.Join(localPath, "envoy-gateway")
-	if err = file.WriteDir(certs.CACertificate, egDir, "ca.crt"); err != nil {
+	egDir := path.Join(localPath, crypto.LocalEnvoyGatewayDir)
+	if err = file.WriteDir(certs.CACertificate, egDir, crypto.LocalCACertFilename); err != nil {
 		return err
 	}
-	if err = file.WriteDir(certs.EnvoyGatewayCertificate, egDir, "tls.crt"); err != nil {
+	if err = file.WriteDir(certs.EnvoyGatewayCertificate, egDir, crypto.LocalTLSCertFilename); err != nil {
 		return err
 	}
-	if err = file.WriteDir(certs.EnvoyGatewayPrivateKey, egDir, "tls.key"); err != nil {
+	if err = file.WriteDir(certs.EnvoyGatewayPrivateKey, egDir, crypto.LocalTLSKeyFilename); err != nil {
 		return err
 	}
 
-	envoyDir := path.Join(localPath, "envoy")
-	if err = file.WriteDir(certs.CACertificate, envoyDir, "ca.crt"); err != nil {
+	envoyDir := path.Join(localPath, crypto.LocalEnvoyDir)
+	if err = file.WriteDir(certs.CACertificate, envoyDir, crypto.LocalCACertFilename); err != nil {
 		return err
 	}
-	if err = file.WriteDir(certs.EnvoyCertificate, envoyDir, "tls.crt"); err != nil {
+	if err = file.WriteDir(certs.EnvoyCertificate, envoyDir, crypto.LocalTLSCertFilename); err != nil {
 		return err
 	}
-	if err = file.WriteDir(certs.EnvoyPrivateKey, envoyDir, "tls.key"); err != nil {
+	if err = file.WriteDir(certs.EnvoyPrivateKey, envoyDir, crypto.LocalTLSKeyFilename); err != nil {
 		return err
 	}
 
-	rlDir := path.Join(localPath, "envoy-rate-limit")
-	if err = file.WriteDir(certs.CACertificate, rlDir, "ca.crt"); err != nil {
+	rlDir := path.Join(localPath, crypto.LocalEnvoyRateLimitDir)
+	if err = file.WriteDir(certs.CACertificate, rlDir, crypto.LocalCACertFilename); err != nil {
 		return err
 	}
-	if err = file.WriteDir(certs.EnvoyRateLimitCertificate, rlDir, "tls.crt"); err != nil {
+	if err = file.WriteDir(certs.EnvoyRateLimitCertificate, rlDir, crypto.LocalTLSCertFilename); err != nil {
 		return err
 	}
-	if err = file.WriteDir(certs.EnvoyRateLimitPrivateKey, rlDir, "tls.key"); err != nil {
+	if err = file.WriteDir(certs.EnvoyRateLimitPrivateKey, rlDir, crypto.LocalTLSKeyFilename); err != nil {
 		return err
 	}
 
-	if err = file.WriteDir(certs.OIDCHMACSecret, path.Join(localPath, "envoy-oidc-hmac"), "hmac-secret"); err != nil {
+	if err = file.WriteDir(certs.OIDCHMACSecret, path.Join(localPath, crypto.LocalOIDCHMACDir), crypto.LocalOIDCHMACSecretFilename); err != nil {
 		return err
 	}
 
@@ -0,0 +1,261 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/internal/cmd/options"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// analysisSeverity is the severity of a finding of the analysis.
+type analysisSeverity string
+
+const (
+	analysisSeverityInfo    analysisSeverity = "info"
+	analysisSeverityWarning analysisSeverity = "warning"
+	analysisSeverityError   analysisSeverity = "error"
+)
+
+// analysisSeverityLevels orders the severities, so that the findings can be filtered by severity.
+var analysisSeverityLevels = map[analysisSeverity]int{
+	analysisSeverityInfo:    0,
+	analysisSeverityWarning: 1,
+	analysisSeverityError:   2,
+}
+
+// analysisObject identifies the resource, or the section of the resource, of a finding.
+type analysisObject struct {
+	Kind        string `json:"kind"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+func (o analysisObject) String() string {
+	s := o.Kind + " " + o.Name
+	if o.Namespace != "" {
+		s = o.Kind + " " + o.Namespace + "/" + o.Name
+	}
+	if o.SectionName != "" {
+		s += " section " + o.SectionName
+	}
+	return s
+}
+
+// analysisFinding is a risky configuration found by an analyzer.
+type analysisFinding struct {
+	Code     string           `json:"code"`
+	Severity analysisSeverity `json:"severity"`
+	Object   analysisObject   `json:"object"`
+	Message  string           `json:"message"`
+}
+
+// analysisContext is the translation of a set of resources inspected by the analyzers.
+type analysisContext struct {
+	resources *resource.Resources
+	result    *gatewayapi.TranslateResult
+	// keys are the sorted IR keys of the result.
+	keys []string
+	// xds are the xDS resources translated from the xDS IR, by IR key.
+	xds map[string]*xdstypes.ResourceVersionTable
+}
+
+// analyzer reports a kind of risky configuration, with a code and a severity.
+type analyzer struct {
+	code     string
+	severity analysisSeverity
+	analyze  func(ctx *analysisContext) []analysisFinding
+}
+
+// analyzers are the analyzers run by egctl x analyze, in the order of their codes.
+var analyzers = []analyzer{
+	{code: "EGA001", severity: analysisSeverityWarning, analyze: analyzeShadowedRoutes},
+	{code: "EGA002", severity: analysisSeverityInfo, analyze: analyzeRouteTimeouts},
+	{code: "EGA003", severity: analysisSeverityWarning, analyze: analyzeOverriddenPolicies},
+	{code: "EGA004", severity: analysisSeverityError, analyze: analyzeEnvoyPatchPolicies},
+	{code: "EGA005", severity: analysisSeverityWarning, analyze: analyzeOIDCWithoutTLS},
+	{code: "EGA006", severity: analysisSeverityWarning, analyze: analyzeRateLimitClientSelectors},
+	{code: "EGA007", severity: analysisSeverityInfo, analyze: analyzeBackendHealthChecks},
+}
+
+func newAnalyzeCommand() *cobra.Command {
+	var (
+		inFile, gatewayClass, output, failOn string
+	)
+
+	analyzeCommand := &cobra.Command{
+		Use:   "analyze",
+		Short: "Report the risky configurations of Gateway API and Envoy Gateway resources",
+		Long: `Translate a set of Gateway API and Envoy Gateway resources, from a file or from the cluster, and report the
+configurations that are valid but risky, each with a code, a severity and the offending resource:
+
+  EGA001 (warning) a route is shadowed by an earlier route that matches all its requests
+  EGA002 (info)    an HTTPRoute rule has no request timeout
+  EGA003 (warning) a policy is overridden by a more specific policy for some routes
+  EGA004 (error)   an EnvoyPatchPolicy patch matches no xDS resource or fails to apply
+  EGA005 (warning) OIDC authentication is enabled on a listener without TLS
+  EGA006 (warning) a rate limit rule has no client selectors and is shared by all the clients
+  EGA007 (info)    the backends of a route have no active or passive health check`,
+		Example: `  # Analyze the resources of a file.
+  egctl x analyze -f resources.yaml
+
+  # Analyze the resources of a GatewayClass in the cluster, failing on warnings.
+  egctl x analyze --gateway-class eg --fail-on warning
+
+  # Analyze the resources of a file, with a JSON report.
+  egctl x analyze -f resources.yaml -o json
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAnalyze(cmd.Context(), cmd.OutOrStdout(), inFile, gatewayClass, output, failOn)
+		},
+	}
+
+	options.AddKubeConfigFlags(analyzeCommand.Flags())
+	analyzeCommand.Flags().StringVarP(&inFile, "file", "f", "", "Location of the resources to analyze. Use - for stdin.")
+	analyzeCommand.Flags().StringVar(&gatewayClass, "gateway-class", "", "Name of the GatewayClass whose resources are analyzed in the cluster, if --file is not set.")
+	analyzeCommand.Flags().StringVarP(&output, "output", "o", textOutput, "One of 'text', 'yaml' or 'json'")
+	analyzeCommand.Flags().StringVar(&failOn, "fail-on", string(analysisSeverityError), "Lowest severity of the findings that fail the command, one of 'info', 'warning', 'error' or 'none'")
+
+	return analyzeCommand
+}
+
+func runAnalyze(ctx context.Context, w io.Writer, inFile, gatewayClass, output, failOn string) error {
+	switch output {
+	case textOutput, yamlOutput, jsonOutput:
+	default:
+		return fmt.Errorf("invalid output %q, must be text, yaml or json", output)
+	}
+	failLevel, ok := analysisSeverityLevels[analysisSeverity(failOn)]
+	if !ok && failOn != "none" {
+		return fmt.Errorf("invalid --fail-on %q, must be info, warning, error or none", failOn)
+	}
+	if (inFile == "") == (gatewayClass == "") {
+		return fmt.Errorf("exactly one of --file and --gateway-class must be set")
+	}
+
+	var resources *resource.Resources
+	if inFile != "" {
+		inBytes, err := getInputBytes(inFile)
+		if err != nil {
+			return fmt.Errorf("unable to read input file: %w", err)
+		}
+		if resources, err = resource.LoadResourcesFromYAMLBytes(inBytes, false); err != nil {
+			return fmt.Errorf("unable to load resources: %w", err)
+		}
+	} else {
+		cli, err := newK8sClient()
+		if err != nil {
+			return err
+		}
+		if resources, err = loadClusterResources(ctx, cli, gatewayClass); err != nil {
+			return err
+		}
+	}
+
+	findings, err := analyzeResources(resources)
+	if err != nil {
+		return err
+	}
+	if err := writeAnalysisFindings(w, findings, output); err != nil {
+		return err
+	}
+
+	if failOn == "none" {
+		return nil
+	}
+	failed := 0
+	for _, f := range findings {
+		if analysisSeverityLevels[f.Severity] >= failLevel {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("found %d findings with severity %s or higher", failed, failOn)
+	}
+	return nil
+}
+
+// analyzeResources translates a set of resources and runs the analyzers on the translation.
+func analyzeResources(resources *resource.Resources) ([]analysisFinding, error) {
+	if resources.GatewayClass == nil {
+		return nil, fmt.Errorf("the GatewayClass resource is required")
+	}
+	// The HMAC secret of OIDC is generated by Envoy Gateway, so it is not expected among the resources.
+	addOIDCHMACSecret(resources)
+
+	result, _ := newGatewayAPITranslator(config.DefaultNamespace, false, resources).Translate(resources)
+	ctx := &analysisContext{
+		resources: resources,
+		result:    result,
+		xds:       make(map[string]*xdstypes.ResourceVersionTable, len(result.XdsIR)),
+	}
+	for key := range result.XdsIR {
+		ctx.keys = append(ctx.keys, key)
+	}
+	sort.Strings(ctx.keys)
+	for _, key := range ctx.keys {
+		// The errors of the translation are reported in the statuses of the resources, such as
+		// those of the EnvoyPatchPolicies, so the resources that could be translated are analyzed.
+		xRes, err := newXdsTranslator(config.DefaultNamespace, config.DefaultDNSDomain, resources).Translate(result.XdsIR[key])
+		if xRes == nil {
+			return nil, fmt.Errorf("failed to translate xds ir for key %s: %w", key, err)
+		}
+		ctx.xds[key] = xRes
+	}
+
+	var findings []analysisFinding
+	for _, a := range analyzers {
+		for _, f := range a.analyze(ctx) {
+			f.Code, f.Severity = a.code, a.severity
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
+}
+
+func writeAnalysisFindings(w io.Writer, findings []analysisFinding, output string) error {
+	if output != textOutput {
+		if findings == nil {
+			findings = []analysisFinding{}
+		}
+		data, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return err
+		}
+		if output == yamlOutput {
+			if data, err = yaml.JSONToYAML(data); err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	if len(findings) == 0 {
+		_, err := fmt.Fprintln(w, "No findings.")
+		return err
+	}
+	body := make([][]string, 0, len(findings))
+	for _, f := range findings {
+		body = append(body, []string{f.Code, string(f.Severity), f.Object.String(), f.Message})
+	}
+	table := newStatusTableWriter(w)
+	writeStatusTable(table, []string{"CODE", "SEVERITY", "OBJECT", "MESSAGE"}, body)
+	return table.Flush()
+}
@@ -0,0 +1,332 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// analyzeShadowedRoutes reports the routes of a virtual host that are never matched, since an
+// earlier route of the virtual host matches all their requests.
+func analyzeShadowedRoutes(ctx *analysisContext) []analysisFinding {
+	var findings []analysisFinding
+	for _, key := range ctx.keys {
+		var routeConfigs []*routev3.RouteConfiguration
+		for _, res := range ctx.xds[key].XdsResources[resourcev3.RouteType] {
+			if rc, ok := res.(*routev3.RouteConfiguration); ok {
+				routeConfigs = append(routeConfigs, rc)
+			}
+		}
+		sort.Slice(routeConfigs, func(i, j int) bool { return routeConfigs[i].Name < routeConfigs[j].Name })
+
+		for _, rc := range routeConfigs {
+			for _, vh := range rc.VirtualHosts {
+				for i, route := range vh.Routes {
+					source := routeSource(route.Metadata)
+					if source == nil {
+						continue
+					}
+					for _, earlier := range vh.Routes[:i] {
+						if !routeMatchCovers(earlier.Match, route.Match) {
+							continue
+						}
+						shadowing := earlier.Name
+						if earlierSource := routeSource(earlier.Metadata); earlierSource != nil {
+							shadowing += " of " + earlierSource.String()
+						}
+						findings = append(findings, analysisFinding{
+							Object: analysisObject(*source),
+							Message: fmt.Sprintf("Route %s of virtual host %s is never matched, since the earlier route %s matches all its requests.",
+								route.Name, vh.Name, shadowing),
+						})
+						break
+					}
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// routeMatchCovers returns whether all the requests matched by a route match b are also matched
+// by a route match a. It only considers the path, headers and query parameters, so a match with
+// other conditions never covers another one.
+func routeMatchCovers(a, b *routev3.RouteMatch) bool {
+	if a == nil || b == nil || a.RuntimeFraction != nil || a.Grpc != nil || a.TlsContext != nil || len(a.DynamicMetadata) > 0 {
+		return false
+	}
+	if (a.CaseSensitive != nil) != (b.CaseSensitive != nil) || a.GetCaseSensitive().GetValue() != b.GetCaseSensitive().GetValue() {
+		return false
+	}
+	if !pathCovers(a, b) {
+		return false
+	}
+	for _, h := range a.Headers {
+		if !containsProto(b.Headers, h) {
+			return false
+		}
+	}
+	for _, q := range a.QueryParameters {
+		if !containsProto(b.QueryParameters, q) {
+			return false
+		}
+	}
+	return true
+}
+
+// pathCovers returns whether all the paths matched by b are matched by a.
+func pathCovers(a, b *routev3.RouteMatch) bool {
+	// The exact, prefix and path separated prefix matches of b match paths starting with its value.
+	var bPath string
+	var bExact, bLiteral bool
+	switch p := b.PathSpecifier.(type) {
+	case *routev3.RouteMatch_Path:
+		bPath, bExact, bLiteral = p.Path, true, true
+	case *routev3.RouteMatch_Prefix:
+		bPath, bLiteral = p.Prefix, true
+	case *routev3.RouteMatch_PathSeparatedPrefix:
+		bPath, bLiteral = p.PathSeparatedPrefix, true
+	}
+
+	switch p := a.PathSpecifier.(type) {
+	case *routev3.RouteMatch_Prefix:
+		if p.Prefix == "" || p.Prefix == "/" {
+			return true
+		}
+		return bLiteral && strings.HasPrefix(bPath, p.Prefix)
+	case *routev3.RouteMatch_PathSeparatedPrefix:
+		if !bLiteral {
+			return false
+		}
+		prefix := strings.TrimSuffix(p.PathSeparatedPrefix, "/")
+		if bPath == prefix {
+			// A prefix of b equal to the prefix of a also matches the paths that only start
+			// with it, such as /apis for /api.
+			_, separated := b.PathSpecifier.(*routev3.RouteMatch_PathSeparatedPrefix)
+			return bExact || separated
+		}
+		return strings.HasPrefix(bPath, prefix+"/")
+	case *routev3.RouteMatch_Path:
+		return bExact && bPath == p.Path
+	case *routev3.RouteMatch_SafeRegex:
+		if !bExact {
+			return false
+		}
+		matched, err := fullMatch(p.SafeRegex.GetRegex(), bPath)
+		return err == nil && matched
+	default:
+		return false
+	}
+}
+
+func containsProto[T proto.Message](list []T, msg T) bool {
+	for _, m := range list {
+		if proto.Equal(m, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeRouteTimeouts reports the HTTPRoute rules without a request timeout, set by the rule or
+// by a BackendTrafficPolicy.
+func analyzeRouteTimeouts(ctx *analysisContext) []analysisFinding {
+	return analyzeHTTPRouteRules(ctx, func(route *ir.HTTPRoute) string {
+		if route.Metadata.Kind != resource.KindHTTPRoute || route.Timeout != nil ||
+			(route.Traffic != nil && route.Traffic.Timeout != nil && route.Traffic.Timeout.HTTP != nil &&
+				route.Traffic.Timeout.HTTP.RequestTimeout != nil) {
+			return ""
+		}
+		return "Rule %s has no request timeout, set by the timeouts of the rule or by a BackendTrafficPolicy."
+	})
+}
+
+// analyzeBackendHealthChecks reports the route rules whose backends have neither an active nor a
+// passive health check.
+func analyzeBackendHealthChecks(ctx *analysisContext) []analysisFinding {
+	return analyzeHTTPRouteRules(ctx, func(route *ir.HTTPRoute) string {
+		if route.Traffic != nil && route.Traffic.HealthCheck != nil &&
+			(route.Traffic.HealthCheck.Active != nil || route.Traffic.HealthCheck.Passive != nil) {
+			return ""
+		}
+		return "The backends of rule %s have no active or passive health check set by a BackendTrafficPolicy."
+	})
+}
+
+// analyzeHTTPRouteRules reports the rules of the routes of the HTTP listeners that forward the
+// requests to backends and for which check returns a message format, whose verb is replaced by
+// the index of the rule. The IR routes of a rule, which are generated for each match and hostname, are only
+// reported once.
+func analyzeHTTPRouteRules(ctx *analysisContext, check func(route *ir.HTTPRoute) string) []analysisFinding {
+	var findings []analysisFinding
+	reported := sets.New[string]()
+	for _, key := range ctx.keys {
+		for _, listener := range ctx.result.XdsIR[key].HTTP {
+			for _, route := range listener.Routes {
+				if route.Metadata == nil || route.Destination == nil || len(route.Destination.Settings) == 0 {
+					continue
+				}
+				format := check(route)
+				if format == "" {
+					continue
+				}
+				object := analysisObject{Kind: route.Metadata.Kind, Namespace: route.Metadata.Namespace, Name: route.Metadata.Name, SectionName: route.Metadata.SectionName}
+				rule := routeRule(route.Name)
+				if reported.Has(object.String() + "/" + rule) {
+					continue
+				}
+				reported.Insert(object.String() + "/" + rule)
+				findings = append(findings, analysisFinding{Object: object, Message: fmt.Sprintf(format, rule)})
+			}
+		}
+	}
+	return findings
+}
+
+// routeRule returns the index of the rule of an IR route from its name, such as 0 for
+// httproute/default/backend/rule/0/match/0/www_example_com, or the name if it has no rule.
+func routeRule(name string) string {
+	segments := strings.Split(name, "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == "rule" {
+			return segments[i+1]
+		}
+	}
+	return name
+}
+
+// analyzeOverriddenPolicies reports the policies attached to a Gateway that are overridden by
+// more specific policies attached to some of its routes or listeners, for which the more specific
+// policy silently wins.
+func analyzeOverriddenPolicies(ctx *analysisContext) []analysisFinding {
+	var findings []analysisFinding
+	add := func(kind string, obj metav1.Object, ancestors []gwapiv1a2.PolicyAncestorStatus) {
+		for _, ancestor := range ancestors {
+			for _, c := range ancestor.Conditions {
+				if c.Type != string(egv1a1.PolicyConditionOverridden) || c.Status != metav1.ConditionTrue {
+					continue
+				}
+				findings = append(findings, analysisFinding{
+					Object:  analysisObject{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()},
+					Message: fmt.Sprintf("On %s: %s", parentRefString(ancestor.AncestorRef, obj.GetNamespace()), c.Message),
+				})
+			}
+		}
+	}
+	for _, p := range ctx.result.ClientTrafficPolicies {
+		add(resource.KindClientTrafficPolicy, p, p.Status.Ancestors)
+	}
+	for _, p := range ctx.result.BackendTrafficPolicies {
+		add(resource.KindBackendTrafficPolicy, p, p.Status.Ancestors)
+	}
+	for _, p := range ctx.result.SecurityPolicies {
+		add(resource.KindSecurityPolicy, p, p.Status.Ancestors)
+	}
+	for _, p := range ctx.result.EnvoyExtensionPolicies {
+		add(resource.KindEnvoyExtensionPolicy, p, p.Status.Ancestors)
+	}
+	return findings
+}
+
+// analyzeEnvoyPatchPolicies reports the EnvoyPatchPolicies whose patches could not be applied,
+// because the patched xDS resource does not exist, their JSONPath matches nothing or the patched
+// resource is invalid.
+func analyzeEnvoyPatchPolicies(ctx *analysisContext) []analysisFinding {
+	var findings []analysisFinding
+	for _, key := range ctx.keys {
+		for _, s := range ctx.xds[key].EnvoyPatchPolicyStatuses {
+			if s.Status == nil {
+				continue
+			}
+			for _, ancestor := range s.Status.Ancestors {
+				for _, c := range ancestor.Conditions {
+					if c.Type != string(egv1a1.PolicyConditionProgrammed) || c.Status != metav1.ConditionFalse {
+						continue
+					}
+					findings = append(findings, analysisFinding{
+						Object:  analysisObject{Kind: resource.KindEnvoyPatchPolicy, Namespace: s.Namespace, Name: s.Name},
+						Message: fmt.Sprintf("The patches are not applied to %s: %s", parentRefString(ancestor.AncestorRef, s.Namespace), c.Message),
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// analyzeOIDCWithoutTLS reports the SecurityPolicies enabling OIDC authentication for the routes
+// of a listener without TLS, which sends the tokens and session cookies in clear text.
+func analyzeOIDCWithoutTLS(ctx *analysisContext) []analysisFinding {
+	var findings []analysisFinding
+	reported := sets.New[string]()
+	for _, key := range ctx.keys {
+		for _, listener := range ctx.result.XdsIR[key].HTTP {
+			if listener.TLS != nil {
+				continue
+			}
+			for _, route := range listener.Routes {
+				if route.Security == nil || route.Security.OIDC == nil {
+					continue
+				}
+				// The name of the OIDC configuration is securitypolicy/<namespace>/<name>.
+				segments := strings.Split(route.Security.OIDC.Name, "/")
+				if len(segments) != 3 || reported.Has(route.Security.OIDC.Name+"/"+listener.Name) {
+					continue
+				}
+				reported.Insert(route.Security.OIDC.Name + "/" + listener.Name)
+				findings = append(findings, analysisFinding{
+					Object: analysisObject{Kind: resource.KindSecurityPolicy, Namespace: segments[1], Name: segments[2]},
+					Message: fmt.Sprintf("OIDC authentication is enabled on listener %s without TLS, which sends the tokens and session cookies in clear text.",
+						listener.Name),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// analyzeRateLimitClientSelectors reports the rate limit rules of the BackendTrafficPolicies
+// without client selectors, whose limit is shared by all the clients of a route.
+func analyzeRateLimitClientSelectors(ctx *analysisContext) []analysisFinding {
+	var findings []analysisFinding
+	for _, p := range ctx.resources.BackendTrafficPolicies {
+		if p.Spec.RateLimit == nil {
+			continue
+		}
+		check := func(rateLimit string, rules []egv1a1.RateLimitRule) {
+			for i, rule := range rules {
+				if len(rule.ClientSelectors) > 0 {
+					continue
+				}
+				findings = append(findings, analysisFinding{
+					Object: analysisObject{Kind: resource.KindBackendTrafficPolicy, Namespace: p.Namespace, Name: p.Name},
+					Message: fmt.Sprintf("Rule %d of the %s rate limit has no client selectors, so its limit is shared by all the clients of each route.",
+						i, rateLimit),
+				})
+			}
+		}
+		if p.Spec.RateLimit.Global != nil {
+			check("global", p.Spec.RateLimit.Global.Rules)
+		}
+		if p.Spec.RateLimit.Local != nil {
+			check("local", p.Spec.RateLimit.Local.Rules)
+		}
+	}
+	return findings
+}
@@ -0,0 +1,212 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+)
+
+const analyzeRiskyFile = "testdata/analyze/risky.yaml"
+
+func TestAnalyzeResources(t *testing.T) {
+	inBytes, err := getInputBytes(analyzeRiskyFile)
+	require.NoError(t, err)
+	resources, err := resource.LoadResourcesFromYAMLBytes(inBytes, false)
+	require.NoError(t, err)
+
+	findings, err := analyzeResources(resources)
+	require.NoError(t, err)
+
+	route := func(name string) analysisObject {
+		return analysisObject{Kind: resource.KindHTTPRoute, Namespace: "default", Name: name}
+	}
+	expected := []struct {
+		code     string
+		severity analysisSeverity
+		object   analysisObject
+		message  string
+	}{
+		{
+			code:     "EGA001",
+			severity: analysisSeverityWarning,
+			object:   route("api-copy"),
+			message: "Route httproute/default/api-copy/rule/0/match/0/api_example_com of virtual host default/eg/http/api_example_com is never matched, " +
+				"since the earlier route httproute/default/api-v1/rule/0/match/0/api_example_com of HTTPRoute default/api-v1 matches all its requests.",
+		},
+		{
+			code:     "EGA002",
+			severity: analysisSeverityInfo,
+			object:   route("api"),
+			message:  "Rule 0 has no request timeout, set by the timeouts of the rule or by a BackendTrafficPolicy.",
+		},
+		{
+			code:     "EGA003",
+			severity: analysisSeverityWarning,
+			object:   analysisObject{Kind: resource.KindBackendTrafficPolicy, Namespace: "default", Name: "gateway-defaults"},
+			message:  "On Gateway default/eg: This policy is being overridden by other backendTrafficPolicies for these routes: [default/api-copy]",
+		},
+		{
+			code:     "EGA004",
+			severity: analysisSeverityError,
+			object:   analysisObject{Kind: resource.KindEnvoyPatchPolicy, Namespace: "default", Name: "patch"},
+		},
+		{
+			code:     "EGA005",
+			severity: analysisSeverityWarning,
+			object:   analysisObject{Kind: resource.KindSecurityPolicy, Namespace: "default", Name: "oidc"},
+			message:  "OIDC authentication is enabled on listener default/eg/http without TLS, which sends the tokens and session cookies in clear text.",
+		},
+		{
+			code:     "EGA006",
+			severity: analysisSeverityWarning,
+			object:   analysisObject{Kind: resource.KindBackendTrafficPolicy, Namespace: "default", Name: "gateway-defaults"},
+			message:  "Rule 0 of the global rate limit has no client selectors, so its limit is shared by all the clients of each route.",
+		},
+		{
+			code:     "EGA007",
+			severity: analysisSeverityInfo,
+			object:   route("api-v1"),
+			message:  "The backends of rule 0 have no active or passive health check set by a BackendTrafficPolicy.",
+		},
+		{
+			code:     "EGA007",
+			severity: analysisSeverityInfo,
+			object:   route("api"),
+			message:  "The backends of rule 0 have no active or passive health check set by a BackendTrafficPolicy.",
+		},
+	}
+
+	require.Len(t, findings, len(expected))
+	for i, e := range expected {
+		require.Equal(t, e.code, findings[i].Code)
+		require.Equal(t, e.severity, findings[i].Severity)
+		require.Equal(t, e.object, findings[i].Object)
+		if e.message != "" {
+			require.Equal(t, e.message, findings[i].Message)
+		}
+	}
+	require.Contains(t, findings[3].Message, "The patches are not applied to Gateway default/eg: No jsonPointers were found")
+}
+
+func TestRunAnalyze(t *testing.T) {
+	testCases := []struct {
+		name    string
+		failOn  string
+		wantErr string
+	}{
+		{
+			name:    "fail on error",
+			failOn:  "error",
+			wantErr: "found 1 findings with severity error or higher",
+		},
+		{
+			name:    "fail on warning",
+			failOn:  "warning",
+			wantErr: "found 5 findings with severity warning or higher",
+		},
+		{
+			name:   "fail on none",
+			failOn: "none",
+		},
+		{
+			name:    "invalid fail on",
+			failOn:  "critical",
+			wantErr: `invalid --fail-on "critical", must be info, warning, error or none`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := runAnalyze(context.Background(), &out, analyzeRiskyFile, "", textOutput, tc.failOn)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, out.String(), "CODE      SEVERITY   OBJECT")
+			require.Contains(t, out.String(), "EGA005    warning    SecurityPolicy default/oidc")
+		})
+	}
+}
+
+func TestRunAnalyzeJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, runAnalyze(context.Background(), &out, "testdata/diff/before.yaml", "", jsonOutput, "warning"))
+	var findings []analysisFinding
+	require.NoError(t, json.Unmarshal(out.Bytes(), &findings))
+	for _, f := range findings {
+		require.Equal(t, analysisSeverityInfo, f.Severity)
+	}
+}
+
+func TestRunAnalyzeInvalid(t *testing.T) {
+	require.EqualError(t, runAnalyze(context.Background(), &bytes.Buffer{}, "", "", textOutput, "error"),
+		"exactly one of --file and --gateway-class must be set")
+	require.EqualError(t, runAnalyze(context.Background(), &bytes.Buffer{}, analyzeRiskyFile, "", "markdown", "error"),
+		`invalid output "markdown", must be text, yaml or json`)
+}
+
+func TestRouteMatchCovers(t *testing.T) {
+	prefix := func(p string) *routev3.RouteMatch {
+		return &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_Prefix{Prefix: p}}
+	}
+	separated := func(p string) *routev3.RouteMatch {
+		return &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_PathSeparatedPrefix{PathSeparatedPrefix: p}}
+	}
+	exact := func(p string) *routev3.RouteMatch {
+		return &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_Path{Path: p}}
+	}
+	regex := func(r string) *routev3.RouteMatch {
+		return &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_SafeRegex{SafeRegex: &matcherv3.RegexMatcher{Regex: r}}}
+	}
+	withHeader := func(m *routev3.RouteMatch, name, value string) *routev3.RouteMatch {
+		m.Headers = append(m.Headers, &routev3.HeaderMatcher{
+			Name: name,
+			HeaderMatchSpecifier: &routev3.HeaderMatcher_StringMatch{
+				StringMatch: &matcherv3.StringMatcher{MatchPattern: &matcherv3.StringMatcher_Exact{Exact: value}},
+			},
+		})
+		return m
+	}
+
+	testCases := []struct {
+		name     string
+		a, b     *routev3.RouteMatch
+		expected bool
+	}{
+		{name: "root prefix", a: prefix("/"), b: regex("/.*"), expected: true},
+		{name: "longer prefix", a: prefix("/api"), b: prefix("/api/v1"), expected: true},
+		{name: "shorter prefix", a: prefix("/api/v1"), b: prefix("/api"), expected: false},
+		{name: "separated prefix of exact path", a: separated("/api"), b: exact("/api"), expected: true},
+		{name: "separated prefix of same separated prefix", a: separated("/api"), b: separated("/api"), expected: true},
+		{name: "separated prefix of same prefix", a: separated("/api"), b: prefix("/api"), expected: false},
+		{name: "separated prefix of sub path", a: separated("/api"), b: prefix("/api/v1"), expected: true},
+		{name: "separated prefix of sibling path", a: separated("/api"), b: prefix("/apis"), expected: false},
+		{name: "exact path", a: exact("/api"), b: exact("/api"), expected: true},
+		{name: "exact path of prefix", a: exact("/api"), b: prefix("/api"), expected: false},
+		{name: "regex of exact path", a: regex("/api/v[0-9]+"), b: exact("/api/v1"), expected: true},
+		{name: "regex of prefix", a: regex("/api/.*"), b: prefix("/api/"), expected: false},
+		{name: "headers subset", a: withHeader(prefix("/"), "x-version", "1"), b: withHeader(withHeader(prefix("/api"), "x-version", "1"), "x-user", "a"), expected: true},
+		{name: "missing header", a: withHeader(prefix("/"), "x-version", "1"), b: prefix("/api"), expected: false},
+		{name: "different header", a: withHeader(prefix("/"), "x-version", "1"), b: withHeader(prefix("/api"), "x-version", "2"), expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, routeMatchCovers(tc.a, tc.b))
+		})
+	}
+}
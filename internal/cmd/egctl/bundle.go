@@ -0,0 +1,178 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+)
+
+const (
+	// redactedValue replaces the values of the Secrets that are neither certificates nor private keys.
+	redactedValue = "redacted"
+	// bundleHeader starts a bundle, so that its resources are translated as those of the cluster.
+	bundleHeader = "# Bundle of the resources of a GatewayClass, written by egctl x translate --bundle.\n"
+)
+
+// redactSecrets replaces the values of the Secrets with placeholders, so that the resources of a
+// cluster can be shared. Certificates are kept, since they are public and validated by the
+// translation, and private keys are replaced with generated keys of the same format.
+func redactSecrets(resources *resource.Resources) error {
+	placeholderKeys := map[string][]byte{}
+	for _, secret := range resources.Secrets {
+		for key, value := range secret.Data {
+			redacted, err := redactSecretValue(value, placeholderKeys)
+			if err != nil {
+				return fmt.Errorf("failed to redact %s of Secret %s/%s: %w", key, secret.Namespace, secret.Name, err)
+			}
+			secret.Data[key] = redacted
+		}
+		for key := range secret.StringData {
+			secret.StringData[key] = redactedValue
+		}
+		resource.RedactSecretMetadata(secret)
+	}
+	return nil
+}
+
+// redactSecretValue returns the value of a Secret if it only holds PEM certificates, a
+// placeholder private key of the same format if it holds a private key, and the redacted value
+// otherwise. placeholderKeys caches the placeholder keys by format.
+func redactSecretValue(value []byte, placeholderKeys map[string][]byte) ([]byte, error) {
+	block, rest := pem.Decode(value)
+	if block == nil {
+		return []byte(redactedValue), nil
+	}
+
+	if block.Type == "CERTIFICATE" {
+		for block != nil && block.Type == "CERTIFICATE" {
+			block, rest = pem.Decode(rest)
+		}
+		if block == nil && len(bytes.TrimSpace(rest)) == 0 {
+			return value, nil
+		}
+		return []byte(redactedValue), nil
+	}
+
+	if key, ok := placeholderKeys[block.Type]; ok {
+		return key, nil
+	}
+	key, err := placeholderPrivateKey(block.Type)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return []byte(redactedValue), nil
+	}
+	placeholderKeys[block.Type] = key
+	return key, nil
+}
+
+// placeholderPrivateKey generates a private key in the PEM format of a type, or returns nil if
+// the type is not a supported private key format.
+func placeholderPrivateKey(pemType string) ([]byte, error) {
+	var der []byte
+	switch pemType {
+	case "RSA PRIVATE KEY":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		der = x509.MarshalPKCS1PrivateKey(key)
+	case "EC PRIVATE KEY", "PRIVATE KEY":
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		if pemType == "EC PRIVATE KEY" {
+			der, err = x509.MarshalECPrivateKey(key)
+		} else {
+			der, err = x509.MarshalPKCS8PrivateKey(key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: der}), nil
+}
+
+// writeBundleFile writes the resources to a file as a bundle.
+func writeBundleFile(file string, resources *resource.Resources) error {
+	var buf bytes.Buffer
+	if err := writeBundle(&buf, resources); err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
+
+// writeBundle writes the resources as Kubernetes YAML documents after the bundle header, which
+// can be loaded again with resource.LoadResourcesFromYAMLBytes. Only the name, namespace and
+// labels of the metadata are written, and the statuses are dropped.
+func writeBundle(w io.Writer, resources *resource.Resources) error {
+	if _, err := io.WriteString(w, bundleHeader); err != nil {
+		return err
+	}
+	scheme := envoygateway.GetScheme()
+	for i, obj := range resourceObjects(resources) {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return err
+		}
+		doc, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s %s/%s: %w", gvk.Kind, obj.GetNamespace(), obj.GetName(), err)
+		}
+		doc["apiVersion"], doc["kind"] = gvk.GroupVersion().String(), gvk.Kind
+		metadata := map[string]any{"name": obj.GetName()}
+		if obj.GetNamespace() != "" {
+			metadata["namespace"] = obj.GetNamespace()
+		}
+		if len(obj.GetLabels()) > 0 {
+			metadata["labels"] = obj.GetLabels()
+		}
+		doc["metadata"] = metadata
+		delete(doc, "status")
+
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isBundle returns whether the data of a file is a bundle.
+func isBundle(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(bundleHeader))
+}
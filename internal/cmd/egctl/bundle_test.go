@@ -0,0 +1,281 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+)
+
+// newTestCertificate returns a self-signed certificate for *.example.com and its PKCS8 private key.
+func newTestCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "*.example.com"},
+		DNSNames:     []string{"*.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestRedactSecretValue(t *testing.T) {
+	cert, key := newTestCertificate(t)
+
+	testCases := []struct {
+		name     string
+		value    []byte
+		keepSame bool
+		pemType  string
+	}{
+		{
+			name:     "certificate",
+			value:    cert,
+			keepSame: true,
+		},
+		{
+			name:     "certificate chain",
+			value:    append(append([]byte{}, cert...), cert...),
+			keepSame: true,
+		},
+		{
+			name:  "certificate and private key",
+			value: append(append([]byte{}, cert...), key...),
+		},
+		{
+			name:    "PKCS8 private key",
+			value:   key,
+			pemType: "PRIVATE KEY",
+		},
+		{
+			name:    "EC private key",
+			value:   pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")}),
+			pemType: "EC PRIVATE KEY",
+		},
+		{
+			name:  "password",
+			value: []byte("password"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			redacted, err := redactSecretValue(tc.value, map[string][]byte{})
+			require.NoError(t, err)
+			switch {
+			case tc.keepSame:
+				require.Equal(t, tc.value, redacted)
+			case tc.pemType != "":
+				require.NotEqual(t, tc.value, redacted)
+				block, _ := pem.Decode(redacted)
+				require.NotNil(t, block)
+				require.Equal(t, tc.pemType, block.Type)
+			default:
+				require.Equal(t, redactedValue, string(redacted))
+			}
+		})
+	}
+}
+
+func TestBundleClusterResources(t *testing.T) {
+	cert, key := newTestCertificate(t)
+	namespace := gwapiv1.Namespace("envoy-gateway-system")
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(
+		&gwapiv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "eg"},
+			Spec: gwapiv1.GatewayClassSpec{
+				ControllerName: egv1a1.GatewayControllerName,
+				ParametersRef: &gwapiv1.ParametersReference{
+					Group:     gwapiv1.Group(egv1a1.GroupName),
+					Kind:      resource.KindEnvoyProxy,
+					Name:      "proxy",
+					Namespace: &namespace,
+				},
+			},
+		},
+		&egv1a1.EnvoyProxy{ObjectMeta: metav1.ObjectMeta{Namespace: string(namespace), Name: "proxy"}},
+		&gwapiv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "eg"},
+			Spec: gwapiv1.GatewaySpec{
+				GatewayClassName: "eg",
+				Listeners: []gwapiv1.Listener{{
+					Name:     "https",
+					Protocol: gwapiv1.HTTPSProtocolType,
+					Port:     443,
+					TLS: &gwapiv1.GatewayTLSConfig{
+						CertificateRefs: []gwapiv1.SecretObjectReference{{Name: "tls"}},
+					},
+					AllowedRoutes: &gwapiv1.AllowedRoutes{
+						Namespaces: &gwapiv1.RouteNamespaces{
+							From:     ptr.To(gwapiv1.NamespacesFromSelector),
+							Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "apps"}},
+						},
+					},
+				}},
+				Infrastructure: &gwapiv1.GatewayInfrastructure{
+					ParametersRef: &gwapiv1.LocalParametersReference{
+						Group: gwapiv1.Group(egv1a1.GroupName),
+						Kind:  resource.KindEnvoyProxy,
+						Name:  "gateway-proxy",
+					},
+				},
+			},
+		},
+		&egv1a1.EnvoyProxy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway-proxy"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps", Labels: map[string]string{"team": "apps"}}},
+		&gwapiv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "backend"},
+			Spec: gwapiv1.HTTPRouteSpec{
+				CommonRouteSpec: gwapiv1.CommonRouteSpec{
+					ParentRefs: []gwapiv1.ParentReference{{Namespace: ptr.To(gwapiv1.Namespace("default")), Name: "eg"}},
+				},
+				Hostnames: []gwapiv1.Hostname{"www.example.com"},
+				// The API server defaults the match of the rule, as the loader of the bundle does.
+				Rules: []gwapiv1.HTTPRouteRule{{
+					Matches: []gwapiv1.HTTPRouteMatch{{
+						Path: &gwapiv1.HTTPPathMatch{Type: ptr.To(gwapiv1.PathMatchPathPrefix), Value: ptr.To("/")},
+					}},
+					BackendRefs: []gwapiv1.HTTPBackendRef{{
+						BackendRef: gwapiv1.BackendRef{
+							BackendObjectReference: gwapiv1.BackendObjectReference{Name: "backend", Port: ptr.To(gwapiv1.PortNumber(8080))},
+						},
+					}},
+				}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "backend"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "10.0.0.1",
+				Ports:     []corev1.ServicePort{{Name: "http", Port: 8080, Protocol: corev1.ProtocolTCP}},
+			},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "apps",
+				Name:      "backend-abcde",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "backend"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.1.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)}}},
+			Ports:       []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To(int32(8080)), Protocol: ptr.To(corev1.ProtocolTCP)}},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "tls",
+				Annotations: map[string]string{"kubectl.kubernetes.io/last-applied-configuration": string(key)},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: key},
+		},
+		&egv1a1.SecurityPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "basic-auth"},
+			Spec: egv1a1.SecurityPolicySpec{
+				PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+					TargetRefs: []gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{{
+						LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+							Group: gwapiv1.GroupName,
+							Kind:  resource.KindHTTPRoute,
+							Name:  "backend",
+						},
+					}},
+				},
+				BasicAuth: &egv1a1.BasicAuth{Users: gwapiv1.SecretObjectReference{Name: "users"}},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "users"},
+			Data:       map[string][]byte{egv1a1.BasicAuthUsersSecretKey: []byte("user:password")},
+		},
+	).Build()
+
+	resources, err := loadClusterResources(context.Background(), cli, "eg")
+	require.NoError(t, err)
+	require.NoError(t, redactSecrets(resources))
+
+	var bundle bytes.Buffer
+	require.NoError(t, writeBundle(&bundle, resources))
+	require.NotContains(t, bundle.String(), "last-applied-configuration")
+	require.NotContains(t, bundle.String(), base64.StdEncoding.EncodeToString([]byte("password")))
+	require.NotContains(t, bundle.String(), "status")
+
+	require.True(t, isBundle(bundle.Bytes()))
+	replayed, err := resource.LoadResourcesFromYAMLBytes(bundle.Bytes(), false)
+	require.NoError(t, err)
+
+	require.Equal(t, "proxy", replayed.EnvoyProxyForGatewayClass.Name)
+	require.Len(t, replayed.EnvoyProxiesForGateways, 1)
+	require.Equal(t, "gateway-proxy", replayed.EnvoyProxiesForGateways[0].Name)
+	require.Len(t, replayed.EndpointSlices, 1)
+	require.Equal(t, "backend", replayed.EndpointSlices[0].Labels[discoveryv1.LabelServiceName])
+
+	tls := replayed.GetSecret("default", "tls")
+	require.NotNil(t, tls)
+	require.Equal(t, cert, tls.Data[corev1.TLSCertKey])
+	require.NotEqual(t, key, tls.Data[corev1.TLSPrivateKeyKey])
+	require.Equal(t, []byte(redactedValue), replayed.GetSecret("apps", "users").Data[egv1a1.BasicAuthUsersSecretKey])
+
+	// The replayed bundle is translated to the same xDS as the resources of the cluster, and the
+	// route is attached through the labels of its namespace.
+	diffs, err := diffGatewayAPI(resources, replayed)
+	require.NoError(t, err)
+	require.Empty(t, diffs)
+
+	_, dumps, err := translateGatewayAPIToConfigDumps(config.DefaultNamespace, config.DefaultDNSDomain, false, replayed)
+	require.NoError(t, err)
+	traceConfig, err := newTraceConfig(dumps["default/eg"])
+	require.NoError(t, err)
+	require.Contains(t, traceConfig.clusters, "httproute/apps/backend/rule/0")
+
+	// The backends of a bundle are routed to as the EnvoyProxy sets, i.e. to the endpoints of the
+	// Service by default, rather than to its Cluster IP.
+	for _, tc := range []struct {
+		endpointRouting bool
+		host            string
+	}{
+		{endpointRouting: true, host: "10.1.0.1"},
+		{endpointRouting: false, host: "10.0.0.1"},
+	} {
+		res, err := translateGatewayAPIToIR(config.DefaultNamespace, tc.endpointRouting, replayed)
+		require.NoError(t, err)
+		route := res.XdsIR["default/eg"].HTTP[0].Routes[0]
+		require.Equal(t, tc.host, route.Destination.Settings[0].Endpoints[0].Host)
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	mcsapiv1a1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+)
+
+// loadClusterResources lists the resources of a GatewayClass from the cluster, in the form consumed
+// by the translator. The routes, policies and backends of all the namespaces are listed, whereas
+// only the Secrets and ConfigMaps referenced by the resources are fetched.
+func loadClusterResources(ctx context.Context, cli client.Client, gatewayClassName string) (*resource.Resources, error) {
+	resources := resource.NewResources()
+
+	gatewayClass := &gwapiv1.GatewayClass{}
+	if err := cli.Get(ctx, types.NamespacedName{Name: gatewayClassName}, gatewayClass); err != nil {
+		return nil, fmt.Errorf("failed to get GatewayClass %s: %w", gatewayClassName, err)
+	}
+	resources.GatewayClass = gatewayClass
+	if ref := gatewayClass.Spec.ParametersRef; ref != nil && ref.Kind == resource.KindEnvoyProxy && ref.Namespace != nil {
+		envoyProxy := &egv1a1.EnvoyProxy{}
+		if err := cli.Get(ctx, types.NamespacedName{Namespace: string(*ref.Namespace), Name: ref.Name}, envoyProxy); err != nil {
+			return nil, fmt.Errorf("failed to get EnvoyProxy %s/%s: %w", *ref.Namespace, ref.Name, err)
+		}
+		resources.EnvoyProxyForGatewayClass = envoyProxy
+	}
+
+	gateways := &gwapiv1.GatewayList{}
+	if err := listClusterResources(ctx, cli, gateways); err != nil {
+		return nil, err
+	}
+	for i := range gateways.Items {
+		gateway := &gateways.Items[i]
+		if string(gateway.Spec.GatewayClassName) != gatewayClassName {
+			continue
+		}
+		resources.Gateways = append(resources.Gateways, gateway)
+
+		if gateway.Spec.Infrastructure == nil || gateway.Spec.Infrastructure.ParametersRef == nil ||
+			gateway.Spec.Infrastructure.ParametersRef.Kind != resource.KindEnvoyProxy {
+			continue
+		}
+		envoyProxy := &egv1a1.EnvoyProxy{}
+		nn := types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Spec.Infrastructure.ParametersRef.Name}
+		if err := cli.Get(ctx, nn, envoyProxy); err != nil {
+			return nil, fmt.Errorf("failed to get EnvoyProxy %s: %w", nn, err)
+		}
+		resources.EnvoyProxiesForGateways = append(resources.EnvoyProxiesForGateways, envoyProxy)
+	}
+
+	var (
+		httpRoutes             gwapiv1.HTTPRouteList
+		grpcRoutes             gwapiv1.GRPCRouteList
+		tlsRoutes              gwapiv1a2.TLSRouteList
+		tcpRoutes              gwapiv1a2.TCPRouteList
+		udpRoutes              gwapiv1a2.UDPRouteList
+		referenceGrants        gwapiv1b1.ReferenceGrantList
+		namespaces             corev1.NamespaceList
+		services               corev1.ServiceList
+		serviceImports         mcsapiv1a1.ServiceImportList
+		endpointSlices         discoveryv1.EndpointSliceList
+		envoyPatchPolicies     egv1a1.EnvoyPatchPolicyList
+		clientTrafficPolicies  egv1a1.ClientTrafficPolicyList
+		backendTrafficPolicies egv1a1.BackendTrafficPolicyList
+		securityPolicies       egv1a1.SecurityPolicyList
+		backendTLSPolicies     gwapiv1a3.BackendTLSPolicyList
+		envoyExtensionPolicies egv1a1.EnvoyExtensionPolicyList
+		backends               egv1a1.BackendList
+		httpRouteFilters       egv1a1.HTTPRouteFilterList
+	)
+	for _, list := range []client.ObjectList{
+		&httpRoutes, &grpcRoutes, &tlsRoutes, &tcpRoutes, &udpRoutes, &referenceGrants,
+		&namespaces, &services, &serviceImports, &endpointSlices,
+		&envoyPatchPolicies, &clientTrafficPolicies, &backendTrafficPolicies, &securityPolicies,
+		&backendTLSPolicies, &envoyExtensionPolicies, &backends, &httpRouteFilters,
+	} {
+		if err := listClusterResources(ctx, cli, list); err != nil {
+			return nil, err
+		}
+	}
+	resources.HTTPRoutes = itemPointers(httpRoutes.Items)
+	resources.GRPCRoutes = itemPointers(grpcRoutes.Items)
+	resources.TLSRoutes = itemPointers(tlsRoutes.Items)
+	resources.TCPRoutes = itemPointers(tcpRoutes.Items)
+	resources.UDPRoutes = itemPointers(udpRoutes.Items)
+	resources.ReferenceGrants = itemPointers(referenceGrants.Items)
+	resources.Namespaces = itemPointers(namespaces.Items)
+	resources.Services = itemPointers(services.Items)
+	resources.ServiceImports = itemPointers(serviceImports.Items)
+	resources.EndpointSlices = itemPointers(endpointSlices.Items)
+	resources.EnvoyPatchPolicies = itemPointers(envoyPatchPolicies.Items)
+	resources.ClientTrafficPolicies = itemPointers(clientTrafficPolicies.Items)
+	resources.BackendTrafficPolicies = itemPointers(backendTrafficPolicies.Items)
+	resources.SecurityPolicies = itemPointers(securityPolicies.Items)
+	resources.BackendTLSPolicies = itemPointers(backendTLSPolicies.Items)
+	resources.EnvoyExtensionPolicies = itemPointers(envoyExtensionPolicies.Items)
+	resources.Backends = itemPointers(backends.Items)
+	resources.HTTPRouteFilters = itemPointers(httpRouteFilters.Items)
+
+	// The Secrets and ConfigMaps that are not found are reported by the translation.
+	refs := resourceReferences(resources)
+	for _, nn := range sortedNamespacedNames(refs.Secrets) {
+		secret := &corev1.Secret{}
+		found, err := getClusterResource(ctx, cli, nn, secret)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			resources.Secrets = append(resources.Secrets, secret)
+		}
+	}
+	for _, nn := range sortedNamespacedNames(refs.ConfigMaps) {
+		configMap := &corev1.ConfigMap{}
+		found, err := getClusterResource(ctx, cli, nn, configMap)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			resources.ConfigMaps = append(resources.ConfigMaps, configMap)
+		}
+	}
+
+	// The items of the typed lists have no kind, which the translator uses to tell the routes apart.
+	scheme := envoygateway.GetScheme()
+	for _, obj := range resourceObjects(resources) {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+
+	return resources, nil
+}
+
+// listClusterResources lists the resources of a kind, ignoring the kinds whose CRD is not
+// installed in the cluster.
+func listClusterResources(ctx context.Context, cli client.Client, list client.ObjectList, opts ...client.ListOption) error {
+	if err := cli.List(ctx, list, opts...); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list %T: %w", list, err)
+	}
+	return nil
+}
+
+// getClusterResource gets a resource, and returns whether it was found.
+func getClusterResource(ctx context.Context, cli client.Client, nn types.NamespacedName, obj client.Object) (bool, error) {
+	if err := cli.Get(ctx, nn, obj); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get %T %s: %w", obj, nn, err)
+	}
+	return true, nil
+}
+
+// resourceReferences returns the objects referenced by the EnvoyProxies, Gateways, routes,
+// policies, Backends and HTTPRouteFilters.
+func resourceReferences(resources *resource.Resources) *resource.References {
+	refs := resource.NewReferences()
+	if ep := resources.EnvoyProxyForGatewayClass; ep != nil {
+		refs.Add(ep.Namespace, ep.Spec)
+	}
+	for _, ep := range resources.EnvoyProxiesForGateways {
+		refs.Add(ep.Namespace, ep.Spec)
+	}
+	for _, gateway := range resources.Gateways {
+		refs.Add(gateway.Namespace, gateway.Spec)
+	}
+	for _, route := range resources.HTTPRoutes {
+		refs.Add(route.Namespace, route.Spec)
+	}
+	for _, route := range resources.GRPCRoutes {
+		refs.Add(route.Namespace, route.Spec)
+	}
+	for _, route := range resources.TLSRoutes {
+		refs.Add(route.Namespace, route.Spec)
+	}
+	for _, route := range resources.TCPRoutes {
+		refs.Add(route.Namespace, route.Spec)
+	}
+	for _, route := range resources.UDPRoutes {
+		refs.Add(route.Namespace, route.Spec)
+	}
+	for _, policy := range resources.ClientTrafficPolicies {
+		refs.Add(policy.Namespace, policy.Spec)
+	}
+	for _, policy := range resources.BackendTrafficPolicies {
+		refs.Add(policy.Namespace, policy.Spec)
+	}
+	for _, policy := range resources.SecurityPolicies {
+		refs.Add(policy.Namespace, policy.Spec)
+	}
+	for _, policy := range resources.BackendTLSPolicies {
+		refs.Add(policy.Namespace, policy.Spec)
+	}
+	for _, policy := range resources.EnvoyExtensionPolicies {
+		refs.Add(policy.Namespace, policy.Spec)
+	}
+	for _, backend := range resources.Backends {
+		refs.Add(backend.Namespace, backend.Spec)
+	}
+	for _, filter := range resources.HTTPRouteFilters {
+		refs.Add(filter.Namespace, filter.Spec)
+	}
+	return refs
+}
+
+// sortedNamespacedNames returns the namespaced names of a set sorted by namespace and name.
+func sortedNamespacedNames(s sets.Set[types.NamespacedName]) []types.NamespacedName {
+	names := s.UnsortedList()
+	slices.SortFunc(names, func(a, b types.NamespacedName) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return names
+}
+
+// itemPointers returns pointers to the items of a list.
+func itemPointers[T any](items []T) []*T {
+	if len(items) == 0 {
+		return nil
+	}
+	pointers := make([]*T, len(items))
+	for i := range items {
+		pointers[i] = &items[i]
+	}
+	return pointers
+}
+
+// resourceObjects returns the Kubernetes objects of the resources, in the order they are written
+// to a bundle.
+func resourceObjects(resources *resource.Resources) []client.Object {
+	var objs []client.Object
+	if resources.GatewayClass != nil {
+		objs = append(objs, resources.GatewayClass)
+	}
+	if resources.EnvoyProxyForGatewayClass != nil {
+		objs = append(objs, resources.EnvoyProxyForGatewayClass)
+	}
+	for _, kindObjs := range [][]client.Object{
+		objectsOf(resources.EnvoyProxiesForGateways),
+		objectsOf(resources.Gateways),
+		objectsOf(resources.HTTPRoutes),
+		objectsOf(resources.GRPCRoutes),
+		objectsOf(resources.TLSRoutes),
+		objectsOf(resources.TCPRoutes),
+		objectsOf(resources.UDPRoutes),
+		objectsOf(resources.ReferenceGrants),
+		objectsOf(resources.Namespaces),
+		objectsOf(resources.Services),
+		objectsOf(resources.ServiceImports),
+		objectsOf(resources.EndpointSlices),
+		objectsOf(resources.Secrets),
+		objectsOf(resources.ConfigMaps),
+		objectsOf(resources.EnvoyPatchPolicies),
+		objectsOf(resources.ClientTrafficPolicies),
+		objectsOf(resources.BackendTrafficPolicies),
+		objectsOf(resources.SecurityPolicies),
+		objectsOf(resources.BackendTLSPolicies),
+		objectsOf(resources.EnvoyExtensionPolicies),
+		objectsOf(resources.Backends),
+		objectsOf(resources.HTTPRouteFilters),
+	} {
+		objs = append(objs, kindObjs...)
+	}
+	return objs
+}
+
+func objectsOf[T client.Object](objs []T) []client.Object {
+	result := make([]client.Object, len(objs))
+	for i, obj := range objs {
+		result[i] = obj
+	}
+	return result
+}
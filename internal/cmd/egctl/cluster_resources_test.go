@@ -0,0 +1,105 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+)
+
+func TestLoadClusterResources(t *testing.T) {
+	namespace := gwapiv1.Namespace("envoy-gateway-system")
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(
+		&gwapiv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "eg"},
+			Spec: gwapiv1.GatewayClassSpec{
+				ControllerName: egv1a1.GatewayControllerName,
+				ParametersRef: &gwapiv1.ParametersReference{
+					Group:     gwapiv1.Group(egv1a1.GroupName),
+					Kind:      resource.KindEnvoyProxy,
+					Name:      "proxy",
+					Namespace: &namespace,
+				},
+			},
+		},
+		&egv1a1.EnvoyProxy{ObjectMeta: metav1.ObjectMeta{Namespace: string(namespace), Name: "proxy"}},
+		&gwapiv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "eg"},
+			Spec: gwapiv1.GatewaySpec{
+				GatewayClassName: "eg",
+				Listeners: []gwapiv1.Listener{{
+					Name:     "https",
+					Protocol: gwapiv1.HTTPSProtocolType,
+					Port:     443,
+					TLS: &gwapiv1.GatewayTLSConfig{
+						CertificateRefs: []gwapiv1.SecretObjectReference{{Name: "tls"}, {Name: "missing"}},
+					},
+				}},
+				Infrastructure: &gwapiv1.GatewayInfrastructure{
+					ParametersRef: &gwapiv1.LocalParametersReference{
+						Group: gwapiv1.Group(egv1a1.GroupName),
+						Kind:  resource.KindEnvoyProxy,
+						Name:  "gateway-proxy",
+					},
+				},
+			},
+		},
+		&egv1a1.EnvoyProxy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway-proxy"}},
+		&gwapiv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "other"},
+			Spec:       gwapiv1.GatewaySpec{GatewayClassName: "other"},
+		},
+		&gwapiv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "backend"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "backend"}},
+		&gwapiv1a3.BackendTLSPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "backend"},
+			Spec: gwapiv1a3.BackendTLSPolicySpec{
+				Validation: gwapiv1a3.BackendTLSPolicyValidation{
+					CACertificateRefs: []gwapiv1.LocalObjectReference{{Kind: resource.KindConfigMap, Name: "ca"}},
+				},
+			},
+		},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tls"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unreferenced"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "ca"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "unreferenced"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "tls"}},
+	).Build()
+
+	resources, err := loadClusterResources(context.Background(), cli, "eg")
+	require.NoError(t, err)
+
+	require.Equal(t, "eg", resources.GatewayClass.Name)
+	require.Equal(t, "proxy", resources.EnvoyProxyForGatewayClass.Name)
+	require.Len(t, resources.EnvoyProxiesForGateways, 1)
+	require.Equal(t, "gateway-proxy", resources.EnvoyProxiesForGateways[0].Name)
+	require.Len(t, resources.Gateways, 1)
+	require.Equal(t, "eg", resources.Gateways[0].Name)
+	require.Len(t, resources.HTTPRoutes, 1)
+	require.Equal(t, resource.KindHTTPRoute, resources.HTTPRoutes[0].Kind)
+	require.Len(t, resources.Services, 1)
+	// Only the Secrets and ConfigMaps referenced by the resources are fetched, if they exist.
+	require.Len(t, resources.Secrets, 1)
+	require.Equal(t, "default", resources.Secrets[0].Namespace)
+	require.Equal(t, "tls", resources.Secrets[0].Name)
+	require.Len(t, resources.ConfigMaps, 1)
+	require.Equal(t, "apps", resources.ConfigMaps[0].Namespace)
+	require.Equal(t, "ca", resources.ConfigMaps[0].Name)
+
+	_, err = loadClusterResources(context.Background(), cli, "missing")
+	require.Error(t, err)
+}
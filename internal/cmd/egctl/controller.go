@@ -0,0 +1,130 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/internal/admin/introspection"
+	"github.com/envoyproxy/gateway/internal/cmd/options"
+)
+
+// controllerResourcePaths maps the resources served by the admin server of Envoy Gateway to the
+// paths of their endpoints.
+var controllerResourcePaths = map[string]string{
+	"provider-resources": introspection.ProviderResourcesPath,
+	"xds-ir":             introspection.XdsIRPath,
+	"infra-ir":           introspection.InfraIRPath,
+	"statuses":           introspection.StatusesPath,
+}
+
+func newControllerCommand() *cobra.Command {
+	var gatewayClass, key, output string
+
+	controllerCommand := &cobra.Command{
+		Use:       "controller <provider-resources|xds-ir|infra-ir|statuses>",
+		Aliases:   []string{"eg"},
+		Short:     "Retrieve the resources of a running Envoy Gateway controller",
+		Long:      "Retrieve the provider resources consumed by the translator, the xDS IR, the infra IR or the statuses published by each running Envoy Gateway pod.",
+		ValidArgs: []string{"provider-resources", "xds-ir", "infra-ir", "statuses"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		Example: `  # Retrieve the provider resources of all the GatewayClasses.
+  egctl x controller provider-resources
+
+  # Retrieve the provider resources of a GatewayClass as YAML.
+  egctl x controller provider-resources --gateway-class eg -o yaml
+
+  # Retrieve the xDS IR of a Gateway.
+  egctl x controller xds-ir --key default/eg
+
+  # Retrieve the infra IR of all the Gateways.
+  egctl x controller infra-ir
+
+  # Retrieve the statuses published by the controller.
+  egctl x controller statuses
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := controllerResourcePath(args[0], gatewayClass, key)
+			if err != nil {
+				return err
+			}
+			cli, err := getCLIClient()
+			if err != nil {
+				return err
+			}
+			responses, err := fetchEnvoyGatewayAdmin(cli, path)
+			if err != nil {
+				return err
+			}
+			return writeControllerResponses(cmd.OutOrStdout(), responses, output)
+		},
+	}
+
+	options.AddKubeConfigFlags(controllerCommand.Flags())
+	controllerCommand.Flags().StringVar(&gatewayClass, "gateway-class", "", "Name of the GatewayClass whose provider resources are retrieved.")
+	controllerCommand.Flags().StringVar(&key, "key", "", "IR key whose IR is retrieved, which is the <namespace>/<name> of a Gateway, or the name of its GatewayClass if its Gateways are merged.")
+	controllerCommand.Flags().StringVarP(&output, "output", "o", "json", "One of 'yaml' or 'json'")
+
+	return controllerCommand
+}
+
+// controllerResourcePath returns the path and query of the admin server endpoint serving a resource.
+func controllerResourcePath(resource, gatewayClass, key string) (string, error) {
+	path, ok := controllerResourcePaths[resource]
+	if !ok {
+		return "", fmt.Errorf("unknown resource %q", resource)
+	}
+
+	query := url.Values{}
+	if gatewayClass != "" {
+		if resource != "provider-resources" {
+			return "", fmt.Errorf("--gateway-class is only supported by provider-resources")
+		}
+		query.Set(introspection.GatewayClassParam, gatewayClass)
+	}
+	if key != "" {
+		if !strings.HasSuffix(resource, "-ir") {
+			return "", fmt.Errorf("--key is only supported by xds-ir and infra-ir")
+		}
+		query.Set(introspection.KeyParam, key)
+	}
+	if len(query) == 0 {
+		return path, nil
+	}
+	return path + "?" + query.Encode(), nil
+}
+
+// writeControllerResponses writes the responses of the Envoy Gateway pods as an object indexed by pod.
+func writeControllerResponses(out io.Writer, responses []adminResponse, output string) error {
+	byPod := make(map[string]json.RawMessage, len(responses))
+	for _, resp := range responses {
+		byPod[resp.Pod.String()] = resp.Body
+	}
+
+	data, err := json.MarshalIndent(byPod, "", "  ")
+	if err != nil {
+		return err
+	}
+	switch output {
+	case "json":
+	case "yaml":
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid output %q, must be json or yaml", output)
+	}
+
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
@@ -0,0 +1,96 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestControllerResourcePath(t *testing.T) {
+	testCases := []struct {
+		name         string
+		resource     string
+		gatewayClass string
+		key          string
+		want         string
+		wantErr      bool
+	}{
+		{
+			name:     "statuses",
+			resource: "statuses",
+			want:     "/api/statuses",
+		},
+		{
+			name:         "provider resources of a GatewayClass",
+			resource:     "provider-resources",
+			gatewayClass: "eg",
+			want:         "/api/resources/provider?gatewayClass=eg",
+		},
+		{
+			name:     "xds ir of a Gateway",
+			resource: "xds-ir",
+			key:      "default/eg",
+			want:     "/api/ir/xds?key=default%2Feg",
+		},
+		{
+			name:         "GatewayClass of an IR",
+			resource:     "infra-ir",
+			gatewayClass: "eg",
+			wantErr:      true,
+		},
+		{
+			name:     "key of the statuses",
+			resource: "statuses",
+			key:      "default/eg",
+			wantErr:  true,
+		},
+		{
+			name:     "unknown resource",
+			resource: "routes",
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := controllerResourcePath(tc.resource, tc.gatewayClass, tc.key)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, path)
+		})
+	}
+}
+
+func TestWriteControllerResponses(t *testing.T) {
+	responses := []adminResponse{
+		{
+			Pod:  types.NamespacedName{Namespace: "envoy-gateway-system", Name: "envoy-gateway-0"},
+			Body: []byte(`{"default/eg":{"proxy":{"name":"default/eg"}}}`),
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeControllerResponses(&out, responses, "yaml"))
+	require.Equal(t, `envoy-gateway-system/envoy-gateway-0:
+  default/eg:
+    proxy:
+      name: default/eg
+
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, writeControllerResponses(&out, responses, "json"))
+	require.JSONEq(t, `{"envoy-gateway-system/envoy-gateway-0":{"default/eg":{"proxy":{"name":"default/eg"}}}}`, out.String())
+
+	require.Error(t, writeControllerResponses(&out, responses, "xml"))
+}
@@ -0,0 +1,366 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	adminv3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/internal/admin/history"
+	"github.com/envoyproxy/gateway/internal/cmd/options"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+)
+
+const (
+	textOutput     = "text"
+	markdownOutput = "markdown"
+)
+
+// diffResourceTypes are the types of the compared xDS resources, in the order they are reported.
+var diffResourceTypes = []envoyConfigType{
+	ListenerEnvoyConfigType,
+	RouteEnvoyConfigType,
+	ClusterEnvoyConfigType,
+	EndpointEnvoyConfigType,
+}
+
+// gatewayDiff is the difference between the xDS resources of an IR key.
+type gatewayDiff struct {
+	// Key is the IR key, which is the <namespace>/<name> of a Gateway, or the name of its
+	// GatewayClass if its Gateways are merged.
+	Key string `json:"key"`
+	// Op is add or remove if all the resources of the IR key were added or removed, and
+	// replace otherwise.
+	Op        history.ChangeOp  `json:"op"`
+	Resources []xdsResourceDiff `json:"resources"`
+}
+
+// xdsResourceDiff is the difference of an xDS resource.
+type xdsResourceDiff struct {
+	Type envoyConfigType  `json:"type"`
+	Name string           `json:"name"`
+	Op   history.ChangeOp `json:"op"`
+	// Changes are the changes of the fields of a replaced resource.
+	Changes []history.Change `json:"changes,omitempty"`
+}
+
+func newDiffCommand() *cobra.Command {
+	var (
+		files               []string
+		addMissingResources bool
+		output              string
+	)
+
+	diffCommand := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the xDS of two sets of Gateway API resources",
+		Long: `Translate two sets of Gateway API resources to xDS, and print the listeners, routes, clusters and endpoints
+that differ, grouped by Gateway. With a single file, the resources of its GatewayClass in the cluster are compared
+to the file.`,
+		Example: `  # Compare the xDS of two sets of resources.
+  egctl x diff -f before.yaml -f after.yaml
+
+  # Compare the resources of the cluster to a file.
+  egctl x diff -f after.yaml
+
+  # Print the differences as Markdown, e.g. to comment on a pull request.
+  egctl x diff -f before.yaml -f after.yaml -o markdown
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd.Context(), cmd.OutOrStdout(), files, addMissingResources, output)
+		},
+	}
+
+	options.AddKubeConfigFlags(diffCommand.Flags())
+	diffCommand.Flags().StringArrayVarP(&files, "file", "f", nil, "Location of the Gateway API resources after the change, preceded by those before the change if it is set twice. Use - for stdin.")
+	diffCommand.Flags().BoolVarP(&addMissingResources, "add-missing-resources", "", false, "Provides dummy resources if missed")
+	diffCommand.Flags().StringVarP(&output, "output", "o", textOutput, "One of 'text', 'markdown', 'yaml' or 'json'")
+
+	return diffCommand
+}
+
+func runDiff(ctx context.Context, w io.Writer, files []string, addMissingResources bool, output string) error {
+	switch output {
+	case textOutput, markdownOutput, yamlOutput, jsonOutput:
+	default:
+		return fmt.Errorf("invalid output %q, must be text, markdown, yaml or json", output)
+	}
+	if len(files) != 1 && len(files) != 2 {
+		return fmt.Errorf("--file must be set once or twice")
+	}
+
+	after, err := loadDiffResources(files[len(files)-1], addMissingResources)
+	if err != nil {
+		return err
+	}
+	var before *resource.Resources
+	if len(files) == 2 {
+		if before, err = loadDiffResources(files[0], addMissingResources); err != nil {
+			return err
+		}
+	} else {
+		if after.GatewayClass == nil {
+			return fmt.Errorf("the GatewayClass resource is required")
+		}
+		cli, err := newK8sClient()
+		if err != nil {
+			return err
+		}
+		if before, err = loadClusterResources(ctx, cli, after.GatewayClass.Name); err != nil {
+			return err
+		}
+	}
+
+	diffs, err := diffGatewayAPI(before, after)
+	if err != nil {
+		return err
+	}
+	return writeDiffs(w, diffs, output)
+}
+
+func loadDiffResources(file string, addMissingResources bool) (*resource.Resources, error) {
+	inBytes, err := getInputBytes(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read input file: %w", err)
+	}
+	resources, err := resource.LoadResourcesFromYAMLBytes(inBytes, addMissingResources)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load resources from %s: %w", file, err)
+	}
+	return resources, nil
+}
+
+// diffGatewayAPI translates two sets of Gateway API resources to xDS, and returns the differences
+// of their xDS resources sorted by IR key.
+func diffGatewayAPI(before, after *resource.Resources) ([]gatewayDiff, error) {
+	_, beforeDumps, err := translateGatewayAPIToConfigDumps(config.DefaultNamespace, config.DefaultDNSDomain, false, before)
+	if err != nil {
+		return nil, err
+	}
+	_, afterDumps, err := translateGatewayAPIToConfigDumps(config.DefaultNamespace, config.DefaultDNSDomain, false, after)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(beforeDumps)+len(afterDumps))
+	for key := range beforeDumps {
+		keys = append(keys, key)
+	}
+	for key := range afterDumps {
+		if _, ok := beforeDumps[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var diffs []gatewayDiff
+	for _, key := range keys {
+		diff, err := diffConfigDumps(key, beforeDumps[key], afterDumps[key])
+		if err != nil {
+			return nil, err
+		}
+		if len(diff.Resources) > 0 {
+			diffs = append(diffs, *diff)
+		}
+	}
+	return diffs, nil
+}
+
+// diffConfigDumps returns the differences between the xDS resources of two config dumps of an IR
+// key, either of which may be nil.
+func diffConfigDumps(key string, before, after *adminv3.ConfigDump) (*gatewayDiff, error) {
+	diff := &gatewayDiff{Key: key, Op: history.ChangeOpReplace}
+	switch {
+	case before == nil:
+		diff.Op = history.ChangeOpAdd
+	case after == nil:
+		diff.Op = history.ChangeOpRemove
+	}
+
+	beforeResources, err := configDumpResources(before)
+	if err != nil {
+		return nil, err
+	}
+	afterResources, err := configDumpResources(after)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rType := range diffResourceTypes {
+		names := make([]string, 0, len(beforeResources[rType])+len(afterResources[rType]))
+		for name := range beforeResources[rType] {
+			names = append(names, name)
+		}
+		for name := range afterResources[rType] {
+			if _, ok := beforeResources[rType][name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			from, to := beforeResources[rType][name], afterResources[rType][name]
+			switch {
+			case from == nil:
+				diff.Resources = append(diff.Resources, xdsResourceDiff{Type: rType, Name: name, Op: history.ChangeOpAdd})
+			case to == nil:
+				diff.Resources = append(diff.Resources, xdsResourceDiff{Type: rType, Name: name, Op: history.ChangeOpRemove})
+			default:
+				changes, err := history.Diff(from, to)
+				if err != nil {
+					return nil, err
+				}
+				if len(changes) > 0 {
+					diff.Resources = append(diff.Resources, xdsResourceDiff{Type: rType, Name: name, Op: history.ChangeOpReplace, Changes: changes})
+				}
+			}
+		}
+	}
+	return diff, nil
+}
+
+// configDumpResources returns the JSON of the dynamic xDS resources of a config dump, by type and name.
+func configDumpResources(dump *adminv3.ConfigDump) (map[envoyConfigType]map[string]json.RawMessage, error) {
+	resources := map[envoyConfigType]map[string]json.RawMessage{}
+	if dump == nil {
+		return resources, nil
+	}
+	config, err := newTraceConfig(dump)
+	if err != nil {
+		return nil, err
+	}
+
+	add := func(rType envoyConfigType, name string, msg proto.Message) error {
+		data, err := protojson.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		if resources[rType] == nil {
+			resources[rType] = map[string]json.RawMessage{}
+		}
+		resources[rType][name] = data
+		return nil
+	}
+	for _, l := range config.listeners {
+		if err := add(ListenerEnvoyConfigType, l.Name, l); err != nil {
+			return nil, err
+		}
+	}
+	for name, rc := range config.routeConfigs {
+		if err := add(RouteEnvoyConfigType, name, rc); err != nil {
+			return nil, err
+		}
+	}
+	for name, cluster := range config.clusters {
+		if err := add(ClusterEnvoyConfigType, name, cluster); err != nil {
+			return nil, err
+		}
+	}
+	for name, cla := range config.endpoints {
+		if err := add(EndpointEnvoyConfigType, name, cla); err != nil {
+			return nil, err
+		}
+	}
+	return resources, nil
+}
+
+func writeDiffs(w io.Writer, diffs []gatewayDiff, output string) error {
+	switch output {
+	case yamlOutput, jsonOutput:
+		if diffs == nil {
+			diffs = []gatewayDiff{}
+		}
+		data, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return err
+		}
+		if output == yamlOutput {
+			if data, err = yaml.JSONToYAML(data); err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	if len(diffs) == 0 {
+		_, err := fmt.Fprintln(w, "No differences in the xDS resources.")
+		return err
+	}
+
+	var sb strings.Builder
+	for i, diff := range diffs {
+		if output == markdownOutput {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			fmt.Fprintf(&sb, "#### Gateway `%s`%s\n\n```diff\n", diff.Key, gatewayDiffSuffix(diff.Op))
+			writeResourceDiffs(&sb, diff.Resources, "")
+			sb.WriteString("```\n")
+			continue
+		}
+		fmt.Fprintf(&sb, "Gateway %s%s:\n", diff.Key, gatewayDiffSuffix(diff.Op))
+		writeResourceDiffs(&sb, diff.Resources, "  ")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func gatewayDiffSuffix(op history.ChangeOp) string {
+	switch op {
+	case history.ChangeOpAdd:
+		return " (added)"
+	case history.ChangeOpRemove:
+		return " (removed)"
+	default:
+		return ""
+	}
+}
+
+// writeResourceDiffs writes the resource differences in the form of a unified diff: added and
+// removed resources are prefixed with + and -, and the changed fields of a replaced resource are
+// listed under it with their values before and after the change.
+func writeResourceDiffs(sb *strings.Builder, resources []xdsResourceDiff, indent string) {
+	for _, res := range resources {
+		switch res.Op {
+		case history.ChangeOpAdd:
+			fmt.Fprintf(sb, "+ %s%s %s\n", indent, res.Type, res.Name)
+		case history.ChangeOpRemove:
+			fmt.Fprintf(sb, "- %s%s %s\n", indent, res.Type, res.Name)
+		default:
+			fmt.Fprintf(sb, "  %s%s %s:\n", indent, res.Type, res.Name)
+			for _, change := range res.Changes {
+				if change.Op != history.ChangeOpAdd {
+					fmt.Fprintf(sb, "- %s    %s: %s\n", indent, change.Path, diffValue(change.From))
+				}
+				if change.Op != history.ChangeOpRemove {
+					fmt.Fprintf(sb, "+ %s    %s: %s\n", indent, change.Path, diffValue(change.To))
+				}
+			}
+		}
+	}
+}
+
+// diffValue formats a changed value as compact JSON.
+func diffValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,127 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/admin/history"
+)
+
+const (
+	diffBeforeFile = "testdata/diff/before.yaml"
+	diffAfterFile  = "testdata/diff/after.yaml"
+)
+
+func TestDiffGatewayAPI(t *testing.T) {
+	before, err := loadDiffResources(diffBeforeFile, false)
+	require.NoError(t, err)
+	after, err := loadDiffResources(diffAfterFile, false)
+	require.NoError(t, err)
+
+	diffs, err := diffGatewayAPI(before, after)
+	require.NoError(t, err)
+	require.Len(t, diffs, 3)
+
+	require.Equal(t, gatewayDiff{
+		Key: "default/eg",
+		Op:  history.ChangeOpReplace,
+		Resources: []xdsResourceDiff{{
+			Type: RouteEnvoyConfigType,
+			Name: "default/eg/http",
+			Op:   history.ChangeOpReplace,
+			Changes: []history.Change{{
+				Op:   history.ChangeOpReplace,
+				Path: "/virtualHosts/default~1eg~1http~1api_example_com/routes/httproute~1default~1api-v1~1rule~10~1match~10~1api_example_com/match/pathSeparatedPrefix",
+				From: "/api",
+				To:   "/v1",
+			}},
+		}},
+	}, diffs[0])
+
+	require.Equal(t, "default/internal", diffs[1].Key)
+	require.Equal(t, history.ChangeOpAdd, diffs[1].Op)
+	require.Contains(t, diffs[1].Resources, xdsResourceDiff{Type: ClusterEnvoyConfigType, Name: "httproute/default/api-v2/rule/0", Op: history.ChangeOpAdd})
+
+	require.Equal(t, "default/legacy", diffs[2].Key)
+	require.Equal(t, history.ChangeOpRemove, diffs[2].Op)
+	for _, res := range diffs[2].Resources {
+		require.Equal(t, history.ChangeOpRemove, res.Op)
+	}
+
+	same, err := diffGatewayAPI(after, after)
+	require.NoError(t, err)
+	require.Empty(t, same)
+}
+
+func TestRunDiff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    []string
+		output   string
+		expected string
+	}{
+		{
+			name:   "text",
+			files:  []string{diffBeforeFile, diffBeforeFile},
+			output: textOutput,
+			expected: `No differences in the xDS resources.
+`,
+		},
+		{
+			name:   "markdown",
+			files:  []string{diffBeforeFile, diffAfterFile},
+			output: markdownOutput,
+			expected: "#### Gateway `default/eg`\n\n```diff\n" +
+				"  route default/eg/http:\n" +
+				`-     /virtualHosts/default~1eg~1http~1api_example_com/routes/httproute~1default~1api-v1~1rule~10~1match~10~1api_example_com/match/pathSeparatedPrefix: "/api"` + "\n" +
+				`+     /virtualHosts/default~1eg~1http~1api_example_com/routes/httproute~1default~1api-v1~1rule~10~1match~10~1api_example_com/match/pathSeparatedPrefix: "/v1"` + "\n" +
+				"```\n\n" +
+				"#### Gateway `default/internal` (added)\n\n```diff\n" +
+				"+ listener default/internal/http\n" +
+				"+ listener envoy-gateway-proxy-ready-0.0.0.0-19003\n" +
+				"+ route default/internal/http\n" +
+				"+ cluster httproute/default/api-v2/rule/0\n" +
+				"+ endpoint httproute/default/api-v2/rule/0\n" +
+				"```\n\n" +
+				"#### Gateway `default/legacy` (removed)\n\n```diff\n" +
+				"- listener default/legacy/http\n" +
+				"- listener envoy-gateway-proxy-ready-0.0.0.0-19003\n" +
+				"- route default/legacy/http\n" +
+				"- cluster httproute/default/api-v1/rule/0\n" +
+				"- endpoint httproute/default/api-v1/rule/0\n" +
+				"```\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, runDiff(context.Background(), &out, tc.files, false, tc.output))
+			require.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestRunDiffJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, runDiff(context.Background(), &out, []string{diffAfterFile, diffAfterFile}, false, jsonOutput))
+	var diffs []gatewayDiff
+	require.NoError(t, json.Unmarshal(out.Bytes(), &diffs))
+	require.Empty(t, diffs)
+	require.Equal(t, "[]\n", out.String())
+}
+
+func TestRunDiffInvalid(t *testing.T) {
+	require.EqualError(t, runDiff(context.Background(), &bytes.Buffer{}, nil, false, textOutput), "--file must be set once or twice")
+	require.EqualError(t, runDiff(context.Background(), &bytes.Buffer{}, []string{diffBeforeFile, diffAfterFile}, false, "html"),
+		`invalid output "html", must be text, markdown, yaml or json`)
+}
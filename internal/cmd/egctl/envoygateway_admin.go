@@ -0,0 +1,110 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	kube "github.com/envoyproxy/gateway/internal/kubernetes"
+	"github.com/envoyproxy/gateway/internal/utils"
+)
+
+// envoyGatewayLabelSelector selects the pods of Envoy Gateway.
+const envoyGatewayLabelSelector = "control-plane=envoy-gateway"
+
+// adminResponse is the response of the admin server of an Envoy Gateway pod.
+type adminResponse struct {
+	Pod  types.NamespacedName
+	Body []byte
+}
+
+// fetchEnvoyGatewayAdmin sends a GET request to an endpoint of the admin server of each running
+// Envoy Gateway pod through a port forwarding, and returns the responses sorted by pod.
+func fetchEnvoyGatewayAdmin(cli kube.CLIClient, path string) ([]adminResponse, error) {
+	pods, err := cli.PodsForSelector(metav1.NamespaceAll, envoyGatewayLabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("list EG pods failed: %w", err)
+	}
+
+	var responses []adminResponse
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != "Running" {
+			continue
+		}
+
+		nn := utils.NamespacedName(&pod)
+		body, err := envoyGatewayAdminRequest(cli, nn, path)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, adminResponse{Pod: nn, Body: body})
+	}
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("no running Envoy Gateway pods found for label selector %s", envoyGatewayLabelSelector)
+	}
+
+	sort.Slice(responses, func(i, j int) bool {
+		return responses[i].Pod.String() < responses[j].Pod.String()
+	})
+	return responses, nil
+}
+
+// fetchEnvoyGatewayAdminJSON fetches a JSON endpoint of the admin server of each running Envoy
+// Gateway pod, and returns the decoded responses by pod, and the pods sorted.
+func fetchEnvoyGatewayAdminJSON[T any](cli kube.CLIClient, path string) (map[types.NamespacedName]T, []types.NamespacedName, error) {
+	responses, err := fetchEnvoyGatewayAdmin(cli, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make(map[types.NamespacedName]T, len(responses))
+	pods := make([]types.NamespacedName, 0, len(responses))
+	for _, resp := range responses {
+		var value T
+		if err := json.Unmarshal(resp.Body, &value); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode the response of pod %s: %w", resp.Pod, err)
+		}
+		values[resp.Pod] = value
+		pods = append(pods, resp.Pod)
+	}
+	return values, pods, nil
+}
+
+func envoyGatewayAdminRequest(cli kube.CLIClient, nn types.NamespacedName, path string) ([]byte, error) {
+	fw, err := portForwarder(cli, nn, egv1a1.GatewayAdminPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize pod-forwarding for %s: %w", nn, err)
+	}
+	if err := fw.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start port forwarding for pod %s: %w", nn, err)
+	}
+	defer fw.Stop()
+
+	resp, err := http.Get(fmt.Sprintf("http://%s%s", fw.Address(), path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to pod %s: %w", nn, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from pod %s: %s", resp.Status, nn, body)
+	}
+	return body, nil
+}
@@ -30,6 +30,10 @@ func newExperimentalCommand() *cobra.Command {
 	experimentalCommand.AddCommand(newUnInstallCommand())
 	experimentalCommand.AddCommand(newCollectCommand())
 	experimentalCommand.AddCommand(newValidateCommand())
+	experimentalCommand.AddCommand(newControllerCommand())
+	experimentalCommand.AddCommand(newTraceCommand())
+	experimentalCommand.AddCommand(newDiffCommand())
+	experimentalCommand.AddCommand(newAnalyzeCommand())
 
 	return experimentalCommand
 }
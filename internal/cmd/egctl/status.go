@@ -72,6 +72,12 @@ func newStatusCommand() *cobra.Command {
 
   # Show the status of all resources under all namespaces.
   egctl x status all -A
+
+  # Show the status of the staged rollouts of the xDS snapshots.
+  egctl x status rollouts
+
+  # Show the status of the proxies connected to the xDS server.
+  egctl x status proxies
 	`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -90,6 +96,10 @@ func newStatusCommand() *cobra.Command {
 			}
 
 			switch strings.ToLower(resourceType) {
+			case "rollout", "rollouts":
+				return runRolloutStatus(cmd.OutOrStdout())
+			case "proxy", "proxies":
+				return runProxiesStatus(cmd.OutOrStdout())
 			case "all":
 				for _, rt := range supportedAllTypes {
 					if err = runStatus(ctx, cmd.OutOrStdout(), k8sClient, rt, namespace, quiet, verbose, allNamespaces, true, true); err != nil {
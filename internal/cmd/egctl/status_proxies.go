@@ -0,0 +1,71 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	xdsserverrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// runProxiesStatus writes the status of the proxies connected to the xDS server of each
+// Envoy Gateway pod.
+func runProxiesStatus(out io.Writer) error {
+	cli, err := getCLIClient()
+	if err != nil {
+		return err
+	}
+
+	proxies, pods, err := fetchEnvoyGatewayAdminJSON[[]xdstypes.ProxyStatus](cli, xdsserverrunner.ProxiesPath)
+	if err != nil {
+		return err
+	}
+
+	writeProxiesStatus(out, pods, proxies, time.Now())
+	return nil
+}
+
+// writeProxiesStatus writes the summary table of the proxies connected to the given pods.
+func writeProxiesStatus(out io.Writer, pods []types.NamespacedName, proxies map[types.NamespacedName][]xdstypes.ProxyStatus, now time.Time) {
+	table := newStatusTableWriter(out)
+	header := []string{"CONTROLLER", "NODE ID", "IR KEY", "ENVOY VERSION", "AGE", "SYNCED", "LAST NACK"}
+
+	var body [][]string
+	for _, pod := range pods {
+		for _, proxy := range proxies[pod] {
+			lastNACK := ""
+			if nack := proxy.LastNACK; nack != nil {
+				lastNACK = fmt.Sprintf("%s %s ago: %s", shortTypeURL(nack.TypeURL), duration.HumanDuration(now.Sub(nack.Time)), nack.Message)
+			}
+			body = append(body, []string{
+				pod.String(),
+				proxy.NodeID,
+				proxy.IRKey,
+				proxy.BuildVersion,
+				duration.HumanDuration(now.Sub(proxy.ConnectTime)),
+				strconv.FormatBool(proxy.Synced),
+				lastNACK,
+			})
+		}
+	}
+
+	writeStatusTable(table, header, body)
+	_ = table.Flush()
+}
+
+// shortTypeURL returns the name of the resource type of a type URL, e.g. Listener for
+// type.googleapis.com/envoy.config.listener.v3.Listener.
+func shortTypeURL(typeURL string) string {
+	return typeURL[strings.LastIndex(typeURL, ".")+1:]
+}
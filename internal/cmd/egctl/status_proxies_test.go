@@ -0,0 +1,54 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestWriteProxiesStatus(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pod := types.NamespacedName{Namespace: "envoy-gateway-system", Name: "envoy-gateway-0"}
+	proxies := map[types.NamespacedName][]xdstypes.ProxyStatus{
+		pod: {
+			{
+				NodeID:       "envoy-1",
+				IRKey:        "default/eg",
+				BuildVersion: "v1.31.2",
+				ConnectTime:  now.Add(-2 * time.Hour),
+				Synced:       true,
+			},
+			{
+				NodeID:       "envoy-2",
+				IRKey:        "default/eg",
+				BuildVersion: "v1.30.0",
+				ConnectTime:  now.Add(-3 * time.Minute),
+				LastNACK: &xdstypes.ProxyNACK{
+					NACK: xdstypes.NACK{
+						NodeID:  "envoy-2",
+						TypeURL: "type.googleapis.com/envoy.config.listener.v3.Listener",
+						Message: "invalid listener",
+					},
+					Time: now.Add(-time.Minute),
+				},
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	writeProxiesStatus(&out, []types.NamespacedName{pod}, proxies, now)
+	require.Equal(t, `CONTROLLER                             NODE ID   IR KEY       ENVOY VERSION   AGE       SYNCED    LAST NACK
+envoy-gateway-system/envoy-gateway-0   envoy-1   default/eg   v1.31.2         120m      true      
+envoy-gateway-system/envoy-gateway-0   envoy-2   default/eg   v1.30.0         3m        false     Listener 60s ago: invalid listener
+`, out.String())
+}
@@ -0,0 +1,58 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	xdsserverrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// runRolloutStatus writes the status of the staged rollouts of the xDS snapshots of
+// each Envoy Gateway pod.
+func runRolloutStatus(out io.Writer) error {
+	cli, err := getCLIClient()
+	if err != nil {
+		return err
+	}
+
+	rollouts, pods, err := fetchEnvoyGatewayAdminJSON[[]xdstypes.RolloutStatus](cli, xdsserverrunner.RolloutsPath)
+	if err != nil {
+		return err
+	}
+
+	writeRolloutStatus(out, pods, rollouts, time.Now())
+	return nil
+}
+
+// writeRolloutStatus writes the summary table of the rollouts of the given pods.
+func writeRolloutStatus(out io.Writer, pods []types.NamespacedName, rollouts map[types.NamespacedName][]xdstypes.RolloutStatus, now time.Time) {
+	table := newStatusTableWriter(out)
+	header := []string{"CONTROLLER", "IR KEY", "PHASE", "CANARIES", "AGE", "REASON"}
+
+	var body [][]string
+	for _, pod := range pods {
+		for _, rollout := range rollouts[pod] {
+			body = append(body, []string{
+				pod.String(),
+				rollout.IRKey,
+				string(rollout.Phase),
+				strings.Join(rollout.Canaries, ","),
+				duration.HumanDuration(now.Sub(rollout.StartTime)),
+				rollout.Reason,
+			})
+		}
+	}
+
+	writeStatusTable(table, header, body)
+	_ = table.Flush()
+}
@@ -0,0 +1,46 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestWriteRolloutStatus(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pod := types.NamespacedName{Namespace: "envoy-gateway-system", Name: "envoy-gateway-0"}
+	rollouts := map[types.NamespacedName][]xdstypes.RolloutStatus{
+		pod: {
+			{
+				IRKey:     "default/eg",
+				Phase:     xdstypes.RolloutPhaseBaking,
+				Canaries:  []string{"envoy-1", "envoy-2"},
+				StartTime: now.Add(-20 * time.Second),
+			},
+			{
+				IRKey:     "default/other",
+				Phase:     xdstypes.RolloutPhaseHeld,
+				Canaries:  []string{"envoy-3"},
+				StartTime: now.Add(-5 * time.Minute),
+				Reason:    "proxy envoy-3 rejected the listener resources",
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	writeRolloutStatus(&out, []types.NamespacedName{pod}, rollouts, now)
+	require.Equal(t, `CONTROLLER                             IR KEY          PHASE     CANARIES          AGE       REASON
+envoy-gateway-system/envoy-gateway-0   default/eg      Baking    envoy-1,envoy-2   20s       
+envoy-gateway-system/envoy-gateway-0   default/other   Held      envoy-3           5m        proxy envoy-3 rejected the listener resources
+`, out.String())
+}
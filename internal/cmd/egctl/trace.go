@@ -0,0 +1,487 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+	"reflect"
+	"sort"
+	"strings"
+
+	adminv3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/yaml"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// traceOptions are the inputs of the trace command.
+type traceOptions struct {
+	// file is the file of the Gateway API resources, translated to trace the request.
+	file                string
+	addMissingResources bool
+	// gateway restricts the trace to the proxies of a Gateway, as <namespace>/<name>.
+	gateway string
+	// configDump is the file of the config dump of a proxy.
+	configDump string
+	// pod is the proxy pod whose config dump is fetched, as <namespace>/<name>.
+	pod    types.NamespacedName
+	output string
+
+	request  traceRequest
+	sourceIP string
+	headers  []string
+}
+
+func newTraceCommand() *cobra.Command {
+	opts := &traceOptions{}
+
+	traceCommand := &cobra.Command{
+		Use:   "trace [pod-name]",
+		Short: "Trace how a request would be routed by the proxies",
+		Long: `Trace a synthetic request through the listener, filter chain, virtual host, route, filters and
+cluster a proxy would select for it, and explain why the routes evaluated before the selected one
+did not match. The xDS configuration is translated from Gateway API resources, read from a config
+dump, or fetched from a running proxy.`,
+		Example: `  # Trace a request through the xDS translated from Gateway API resources.
+  egctl x trace -f <input file> --host www.example.com --path /api/v2
+
+  # Trace a request with headers through the proxies of a Gateway.
+  egctl x trace -f <input file> --gateway default/eg --port 80 --host www.example.com -H x-version:v2
+
+  # Trace a TLS request through a config dump of a proxy, in YAML output.
+  egctl x trace --config-dump config_dump.json --port 443 --sni www.example.com --host www.example.com -o yaml
+
+  # Trace a request through the config dump of a running proxy.
+  egctl x trace envoy-default-eg-e41e7b31-6d9cb6f8c9-4xmjh -n envoy-gateway-system --host www.example.com
+	`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.pod.Name = args[0]
+			}
+			return runTrace(cmd.OutOrStdout(), opts)
+		},
+	}
+
+	flags := traceCommand.Flags()
+	flags.StringVarP(&opts.file, "file", "f", "", "Location of the Gateway API resources to translate.")
+	flags.BoolVarP(&opts.addMissingResources, "add-missing-resources", "", false, "Provides dummy resources if missed")
+	flags.StringVarP(&opts.gateway, "gateway", "", "", "Only trace the proxies of a Gateway, as <namespace>/<name>.")
+	flags.StringVarP(&opts.configDump, "config-dump", "", "", "Location of the config dump of a proxy, as served by its admin interface.")
+	flags.StringVarP(&opts.pod.Namespace, "namespace", "n", "envoy-gateway-system", "Namespace of the proxy pod.")
+	flags.StringVarP(&opts.output, "output", "o", "", "One of 'yaml' or 'json', or a summary if unspecified.")
+	flags.Uint32VarP(&opts.request.Port, "port", "", 0, "Port of the Gateway listener receiving the request. All the listeners are traced if unspecified.")
+	flags.StringVarP(&opts.request.SNI, "sni", "", "", "Server name of the TLS handshake, if the request is sent over TLS.")
+	flags.StringVarP(&opts.sourceIP, "source-ip", "", "", "IP address of the client.")
+	flags.StringVarP(&opts.request.Host, "host", "", "", "Host of the request.")
+	flags.StringVarP(&opts.request.Method, "method", "X", "GET", "Method of the request.")
+	flags.StringVarP(&opts.request.Path, "path", "", "/", "Path of the request, including its query.")
+	flags.StringArrayVarP(&opts.headers, "header", "H", nil, "Header of the request, as <name>:<value>. Can be repeated.")
+
+	return traceCommand
+}
+
+func runTrace(w io.Writer, opts *traceOptions) error {
+	if err := opts.complete(); err != nil {
+		return err
+	}
+
+	var (
+		results []traceResult
+		err     error
+	)
+	switch {
+	case opts.file != "":
+		results, err = traceGatewayAPI(opts)
+	case opts.configDump != "":
+		results, err = traceConfigDumpFile(opts)
+	default:
+		results, err = traceProxy(opts)
+	}
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("no listener receives the requests to port %d", opts.request.Port)
+	}
+
+	return writeTraceResults(w, results, opts.output)
+}
+
+// complete validates the options and parses the request.
+func (o *traceOptions) complete() error {
+	inputs := 0
+	for _, set := range []bool{o.file != "", o.configDump != "", o.pod.Name != ""} {
+		if set {
+			inputs++
+		}
+	}
+	if inputs != 1 {
+		return fmt.Errorf("exactly one of --file, --config-dump or a pod name must be specified")
+	}
+	if o.gateway != "" && o.file == "" {
+		return fmt.Errorf("--gateway is only supported with --file")
+	}
+	if o.output != "" && o.output != yamlOutput && o.output != jsonOutput {
+		return fmt.Errorf("output format %s is not supported, must be one of 'yaml' or 'json'", o.output)
+	}
+
+	if o.sourceIP != "" {
+		addr, err := netip.ParseAddr(o.sourceIP)
+		if err != nil {
+			return fmt.Errorf("invalid source IP %q: %w", o.sourceIP, err)
+		}
+		o.request.SourceIP = addr
+	}
+	o.request.Method = strings.ToUpper(o.request.Method)
+	if !strings.HasPrefix(o.request.Path, "/") {
+		o.request.Path = "/" + o.request.Path
+	}
+	o.request.Headers = make(map[string]string, len(o.headers))
+	for _, header := range o.headers {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid header %q, must be <name>:<value>", header)
+		}
+		o.request.Headers[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+	}
+	return nil
+}
+
+// traceGatewayAPI traces the request through the xDS translated from Gateway API resources.
+func traceGatewayAPI(opts *traceOptions) ([]traceResult, error) {
+	inBytes, err := getInputBytes(opts.file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read input file: %w", err)
+	}
+	resources, err := resource.LoadResourcesFromYAMLBytes(inBytes, opts.addMissingResources)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal input: %w", err)
+	}
+
+	gRes, configDumps, err := translateGatewayAPIToConfigDumps(config.DefaultNamespace, config.DefaultDNSDomain, false, resources)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(configDumps))
+	for key := range configDumps {
+		if opts.gateway == "" || key == opts.gateway {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no proxies are translated for gateway %s", opts.gateway)
+	}
+	sort.Strings(keys)
+
+	var results []traceResult
+	for _, key := range keys {
+		cfg, err := newTraceConfig(configDumps[key])
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range cfg.trace(&opts.request) {
+			result.IRKey = key
+			if result.Route != "" {
+				result.Features = routeFeatures(findIRRoute(gRes.XdsIR[key], result.Route))
+				result.Policies = attachedPolicies(gRes, key, &result)
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// traceConfigDumpFile traces the request through a config dump read from a file.
+func traceConfigDumpFile(opts *traceOptions) ([]traceResult, error) {
+	inBytes, err := getInputBytes(opts.configDump)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config dump: %w", err)
+	}
+	return traceConfigDumpBytes(inBytes, &opts.request)
+}
+
+// traceProxy traces the request through the config dump of a running proxy.
+func traceProxy(opts *traceOptions) ([]traceResult, error) {
+	cli, err := getCLIClient()
+	if err != nil {
+		return nil, err
+	}
+	pods, err := fetchRunningEnvoyPods(cli, opts.pod, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	fw, err := portForwarder(cli, pods[0], adminPort)
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Start(); err != nil {
+		return nil, err
+	}
+	defer fw.Stop()
+
+	out, err := configDumpRequest(fw.Address(), true)
+	if err != nil {
+		return nil, err
+	}
+	return traceConfigDumpBytes(out, &opts.request)
+}
+
+func traceConfigDumpBytes(in []byte, req *traceRequest) ([]traceResult, error) {
+	jsonBytes, err := yaml.YAMLToJSON(in)
+	if err != nil {
+		return nil, err
+	}
+	dump := &adminv3.ConfigDump{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(jsonBytes, dump); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal config dump: %w", err)
+	}
+	cfg, err := newTraceConfig(dump)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.trace(req), nil
+}
+
+// findIRRoute returns the HTTP route of the IR an xDS route is translated from.
+func findIRRoute(xdsIR *ir.Xds, name string) *ir.HTTPRoute {
+	if xdsIR == nil {
+		return nil
+	}
+	for _, listener := range xdsIR.HTTP {
+		for _, route := range listener.Routes {
+			if route.Name == name {
+				return route
+			}
+		}
+	}
+	return nil
+}
+
+// routeFeatures returns the traffic, security and extension features set on a route of the IR.
+func routeFeatures(route *ir.HTTPRoute) []string {
+	if route == nil {
+		return nil
+	}
+	var features []string
+	features = append(features, setFields("traffic", route.Traffic)...)
+	features = append(features, setFields("security", route.Security)...)
+	features = append(features, setFields("envoyExtensions", route.EnvoyExtensions)...)
+	return features
+}
+
+// setFields returns the JSON names of the set feature fields of a struct pointer, prefixed with prefix.
+func setFields(prefix string, v any) []string {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return nil
+	}
+	value = value.Elem()
+
+	var fields []string
+	for i := range value.NumField() {
+		if value.Field(i).IsZero() {
+			continue
+		}
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		// The name and metadata identify the features rather than being ones.
+		if name == "" || name == "-" || name == "name" || name == "metadata" {
+			continue
+		}
+		fields = append(fields, prefix+"."+name)
+	}
+	return fields
+}
+
+// attachedPolicies returns the SecurityPolicies and BackendTrafficPolicies attached to the
+// route of a trace result, or to the Gateway of its IR key and listener, with the conditions of
+// their status for the Gateway.
+func attachedPolicies(gRes *gatewayapi.TranslateResult, irKey string, result *traceResult) []tracePolicy {
+	gwNamespace, gwName, ok := strings.Cut(irKey, "/")
+	if !ok {
+		// The IR key of merged Gateways is the name of their GatewayClass.
+		gwNamespace, gwName = "", ""
+	}
+	var gwLabels, routeLabels labels.Set
+	for _, gw := range gRes.Gateways {
+		if gw.Namespace == gwNamespace && gw.Name == gwName {
+			gwLabels = gw.Labels
+		}
+	}
+	if source := result.source; source != nil {
+		for _, route := range gRes.HTTPRoutes {
+			if source.Kind == resource.KindHTTPRoute && route.Namespace == source.Namespace && route.Name == source.Name {
+				routeLabels = route.Labels
+			}
+		}
+		for _, route := range gRes.GRPCRoutes {
+			if source.Kind == resource.KindGRPCRoute && route.Namespace == source.Namespace && route.Name == source.Name {
+				routeLabels = route.Labels
+			}
+		}
+	}
+
+	// target returns what the policy of a namespace is attached to, if it's attached to the
+	// route or to the Gateway of the trace.
+	target := func(namespace string, refs egv1a1.PolicyTargetReferences) string {
+		if source := result.source; source != nil && namespace == source.Namespace &&
+			targetsResource(refs, source.Kind, source.Name, source.SectionName, routeLabels) {
+			return source.String()
+		}
+		if gwName != "" && namespace == gwNamespace &&
+			targetsResource(refs, resource.KindGateway, gwName, result.sectionName, gwLabels) {
+			return fmt.Sprintf("%s %s/%s", resource.KindGateway, gwNamespace, gwName)
+		}
+		return ""
+	}
+
+	var policies []tracePolicy
+	for _, p := range gRes.SecurityPolicies {
+		if t := target(p.Namespace, p.Spec.PolicyTargetReferences); t != "" {
+			policies = append(policies, tracePolicy{
+				Kind: resource.KindSecurityPolicy, Namespace: p.Namespace, Name: p.Name, Target: t,
+				Conditions: ancestorConditions(p.Status.Ancestors, gwName),
+			})
+		}
+	}
+	for _, p := range gRes.BackendTrafficPolicies {
+		if t := target(p.Namespace, p.Spec.PolicyTargetReferences); t != "" {
+			policies = append(policies, tracePolicy{
+				Kind: resource.KindBackendTrafficPolicy, Namespace: p.Namespace, Name: p.Name, Target: t,
+				Conditions: ancestorConditions(p.Status.Ancestors, gwName),
+			})
+		}
+	}
+	return policies
+}
+
+// targetsResource returns whether the target references of a policy select a resource, or the
+// section of a resource if sectionName isn't empty.
+func targetsResource(refs egv1a1.PolicyTargetReferences, kind, name, sectionName string, resourceLabels labels.Set) bool {
+	for _, ref := range refs.GetTargetRefs() {
+		if string(ref.Kind) != kind || string(ref.Name) != name {
+			continue
+		}
+		if ref.SectionName == nil || string(*ref.SectionName) == sectionName {
+			return true
+		}
+	}
+	for _, selector := range refs.TargetSelectors {
+		if string(selector.Kind) != kind {
+			continue
+		}
+		s, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+			MatchLabels:      selector.MatchLabels,
+			MatchExpressions: selector.MatchExpressions,
+		})
+		if err == nil && s.Matches(resourceLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// ancestorConditions returns the conditions of the status of a policy for a Gateway.
+func ancestorConditions(ancestors []gwapiv1a2.PolicyAncestorStatus, gwName string) []string {
+	var conditions []string
+	for _, ancestor := range ancestors {
+		if string(ancestor.AncestorRef.Name) != gwName {
+			continue
+		}
+		for _, c := range ancestor.Conditions {
+			condition := fmt.Sprintf("%s=%s", c.Type, c.Status)
+			if c.Status != metav1.ConditionTrue || c.Type != string(gwapiv1a2.PolicyConditionAccepted) {
+				condition += ": " + c.Message
+			}
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+// writeTraceResults writes the trace results in the output format, or a summary of each.
+func writeTraceResults(w io.Writer, results []traceResult, output string) error {
+	switch output {
+	case yamlOutput:
+		out, err := yaml.Marshal(results)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	case jsonOutput:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+
+	for i, result := range results {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		writeTraceField(w, "IR key", result.IRKey)
+		listener := result.Listener
+		if result.Address != "" {
+			listener = fmt.Sprintf("%s (%s)", listener, result.Address)
+		}
+		writeTraceField(w, "Listener", listener)
+		writeTraceField(w, "Filter chain", result.FilterChain)
+		writeTraceField(w, "Route config", result.RouteConfig)
+		writeTraceField(w, "Virtual host", result.VirtualHost)
+		route := result.Route
+		if result.RouteSource != "" {
+			route = fmt.Sprintf("%s (%s)", route, result.RouteSource)
+		}
+		writeTraceField(w, "Route", route)
+		writeTraceField(w, "Action", result.Action)
+		for _, cluster := range result.Clusters {
+			description := cluster.Name
+			if cluster.Weight != 0 {
+				description += fmt.Sprintf(" weight %d", cluster.Weight)
+			}
+			if len(cluster.Endpoints) > 0 {
+				description += fmt.Sprintf(" endpoints %s", strings.Join(cluster.Endpoints, ", "))
+			}
+			writeTraceField(w, "Cluster", description)
+		}
+		writeTraceField(w, "Filters", strings.Join(result.Filters, ", "))
+		writeTraceField(w, "Features", strings.Join(result.Features, ", "))
+		for _, policy := range result.Policies {
+			writeTraceField(w, "Policy", fmt.Sprintf("%s %s/%s on %s: %s", policy.Kind, policy.Namespace, policy.Name,
+				policy.Target, strings.Join(policy.Conditions, ", ")))
+		}
+		writeTraceField(w, "No match", result.NoMatch)
+		if len(result.Unmatched) > 0 {
+			fmt.Fprintln(w, "Unmatched routes:")
+			for _, m := range result.Unmatched {
+				fmt.Fprintf(w, "  %s: %s\n", m.Route, m.Reason)
+			}
+		}
+	}
+	return nil
+}
+
+func writeTraceField(w io.Writer, name, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(w, "%-14s%s\n", name+":", value)
+}
@@ -0,0 +1,754 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	adminv3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	tcpv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// wellKnownPortShift is added by Envoy Gateway to the privileged ports of the Gateway
+	// listeners to get the ports of the Envoy listeners.
+	wellKnownPortShift = 10000
+	// envoyGatewayMetadataNamespace is the filter metadata namespace of the resources an
+	// xDS resource is translated from.
+	envoyGatewayMetadataNamespace = "envoy-gateway"
+	// internalListenerPrefix is the prefix of the names of the listeners of the readiness
+	// probe and stats of the proxies, which don't serve the Gateways.
+	internalListenerPrefix = "envoy-gateway-proxy-"
+)
+
+// traceRequest is a synthetic request traced through the xDS configuration of a proxy.
+type traceRequest struct {
+	// Port is the port of the Gateway listener receiving the request, or 0 for all listeners.
+	Port uint32
+	// SNI is the server name of the TLS handshake, if the request is sent over TLS.
+	SNI string
+	// SourceIP is the address of the client, if specified.
+	SourceIP netip.Addr
+	Host     string
+	Method   string
+	// Path is the path of the request, including its query.
+	Path string
+	// Headers are the headers of the request, keyed by their lower-case names.
+	Headers map[string]string
+}
+
+// header returns the value of a header of the request, including its pseudo-headers.
+func (r *traceRequest) header(name string) (string, bool) {
+	switch name = strings.ToLower(name); name {
+	case ":authority", "host":
+		return r.Host, true
+	case ":method":
+		return r.Method, true
+	case ":path":
+		return r.Path, true
+	case ":scheme":
+		if r.SNI != "" {
+			return "https", true
+		}
+		return "http", true
+	}
+	value, ok := r.Headers[name]
+	return value, ok
+}
+
+// traceResult is the outcome of tracing a request through an Envoy listener.
+type traceResult struct {
+	IRKey       string `json:"irKey,omitempty"`
+	Listener    string `json:"listener"`
+	Address     string `json:"address,omitempty"`
+	FilterChain string `json:"filterChain,omitempty"`
+	RouteConfig string `json:"routeConfig,omitempty"`
+	VirtualHost string `json:"virtualHost,omitempty"`
+	Route       string `json:"route,omitempty"`
+	// RouteSource is the Gateway API resource the route is translated from.
+	RouteSource string         `json:"routeSource,omitempty"`
+	Action      string         `json:"action,omitempty"`
+	Clusters    []traceCluster `json:"clusters,omitempty"`
+	// Filters are the HTTP filters applied to the request, in order.
+	Filters []string `json:"filters,omitempty"`
+	// Features are the traffic and security features of the route in the IR.
+	Features []string      `json:"features,omitempty"`
+	Policies []tracePolicy `json:"policies,omitempty"`
+	// Unmatched are the routes evaluated before the selected one, and why they did not match.
+	Unmatched []traceMismatch `json:"unmatched,omitempty"`
+	// NoMatch is why the request is not routed, if it isn't.
+	NoMatch string `json:"noMatch,omitempty"`
+
+	// source identifies the route resource, and sectionName the listener of the Gateway,
+	// to find the policies attached to them.
+	source      *traceResource
+	sectionName string
+}
+
+type traceCluster struct {
+	Name      string   `json:"name"`
+	Weight    uint32   `json:"weight,omitempty"`
+	Type      string   `json:"type,omitempty"`
+	Endpoints []string `json:"endpoints,omitempty"`
+}
+
+type traceMismatch struct {
+	Route  string `json:"route"`
+	Reason string `json:"reason"`
+}
+
+type tracePolicy struct {
+	Kind       string   `json:"kind"`
+	Namespace  string   `json:"namespace"`
+	Name       string   `json:"name"`
+	Target     string   `json:"target"`
+	Conditions []string `json:"conditions,omitempty"`
+}
+
+// traceResource is a resource an xDS resource is translated from.
+type traceResource struct {
+	Kind, Namespace, Name, SectionName string
+}
+
+func (r *traceResource) String() string {
+	return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+// traceConfig indexes the xDS resources of a config dump.
+type traceConfig struct {
+	listeners    []*listenerv3.Listener
+	routeConfigs map[string]*routev3.RouteConfiguration
+	clusters     map[string]*clusterv3.Cluster
+	endpoints    map[string]*endpointv3.ClusterLoadAssignment
+}
+
+// newTraceConfig indexes the dynamic listeners, routes, clusters and endpoints of a config dump.
+func newTraceConfig(dump *adminv3.ConfigDump) (*traceConfig, error) {
+	c := &traceConfig{
+		routeConfigs: map[string]*routev3.RouteConfiguration{},
+		clusters:     map[string]*clusterv3.Cluster{},
+		endpoints:    map[string]*endpointv3.ClusterLoadAssignment{},
+	}
+	for _, cfg := range dump.Configs {
+		msg, err := cfg.UnmarshalNew()
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", cfg.TypeUrl, err)
+		}
+		switch msg := msg.(type) {
+		case *adminv3.ListenersConfigDump:
+			for _, l := range msg.DynamicListeners {
+				listener := &listenerv3.Listener{}
+				if l.ActiveState == nil {
+					continue
+				}
+				if err := l.ActiveState.Listener.UnmarshalTo(listener); err != nil {
+					return nil, err
+				}
+				c.listeners = append(c.listeners, listener)
+			}
+		case *adminv3.RoutesConfigDump:
+			for _, r := range msg.DynamicRouteConfigs {
+				rc := &routev3.RouteConfiguration{}
+				if err := r.RouteConfig.UnmarshalTo(rc); err != nil {
+					return nil, err
+				}
+				c.routeConfigs[rc.Name] = rc
+			}
+		case *adminv3.ClustersConfigDump:
+			for _, cl := range msg.DynamicActiveClusters {
+				cluster := &clusterv3.Cluster{}
+				if err := cl.Cluster.UnmarshalTo(cluster); err != nil {
+					return nil, err
+				}
+				c.clusters[cluster.Name] = cluster
+			}
+		case *adminv3.EndpointsConfigDump:
+			for _, e := range msg.DynamicEndpointConfigs {
+				cla := &endpointv3.ClusterLoadAssignment{}
+				if err := e.EndpointConfig.UnmarshalTo(cla); err != nil {
+					return nil, err
+				}
+				c.endpoints[cla.ClusterName] = cla
+			}
+		}
+	}
+	return c, nil
+}
+
+// trace traces a request through the listeners of the port of the request.
+func (c *traceConfig) trace(req *traceRequest) []traceResult {
+	var results []traceResult
+	for _, l := range c.listeners {
+		if strings.HasPrefix(l.Name, internalListenerPrefix) || !listenerMatchesPort(l, req.Port) {
+			continue
+		}
+		results = append(results, c.traceListener(l, req))
+	}
+	return results
+}
+
+// listenerMatchesPort returns whether a listener receives the requests to a port of a Gateway.
+func listenerMatchesPort(l *listenerv3.Listener, port uint32) bool {
+	if port == 0 {
+		return true
+	}
+	listenerPort := l.GetAddress().GetSocketAddress().GetPortValue()
+	return listenerPort == port || (port < 1024 && listenerPort == port+wellKnownPortShift)
+}
+
+func (c *traceConfig) traceListener(l *listenerv3.Listener, req *traceRequest) traceResult {
+	result := traceResult{Listener: l.Name}
+	if addr := l.GetAddress().GetSocketAddress(); addr != nil {
+		result.Address = net.JoinHostPort(addr.Address, strconv.Itoa(int(addr.GetPortValue())))
+	}
+
+	fc := selectFilterChain(l, req)
+	if fc == nil {
+		result.NoMatch = "no filter chain matches the server name, transport protocol and source address of the request"
+		return result
+	}
+	result.FilterChain = fc.Name
+	if parts := strings.Split(fc.Name, "/"); len(parts) == 3 {
+		result.sectionName = parts[2]
+	}
+
+	for _, filter := range fc.Filters {
+		switch filter.Name {
+		case wellknown.HTTPConnectionManager:
+			hcm := &hcmv3.HttpConnectionManager{}
+			if err := filter.GetTypedConfig().UnmarshalTo(hcm); err != nil {
+				result.NoMatch = fmt.Sprintf("failed to unmarshal the HTTP connection manager: %v", err)
+				return result
+			}
+			c.traceHTTP(hcm, req, &result)
+			return result
+		case wellknown.TCPProxy:
+			tcpProxy := &tcpv3.TcpProxy{}
+			if err := filter.GetTypedConfig().UnmarshalTo(tcpProxy); err != nil {
+				result.NoMatch = fmt.Sprintf("failed to unmarshal the TCP proxy: %v", err)
+				return result
+			}
+			c.traceTCP(tcpProxy, &result)
+			return result
+		}
+	}
+	result.NoMatch = fmt.Sprintf("filter chain %s has no HTTP connection manager or TCP proxy", fc.Name)
+	return result
+}
+
+func (c *traceConfig) traceTCP(tcpProxy *tcpv3.TcpProxy, result *traceResult) {
+	switch spec := tcpProxy.ClusterSpecifier.(type) {
+	case *tcpv3.TcpProxy_Cluster:
+		result.Action = "proxy the connection to cluster " + spec.Cluster
+		result.Clusters = []traceCluster{c.cluster(spec.Cluster, 0)}
+	case *tcpv3.TcpProxy_WeightedClusters:
+		result.Action = "proxy the connection to weighted clusters"
+		for _, wc := range spec.WeightedClusters.Clusters {
+			result.Clusters = append(result.Clusters, c.cluster(wc.Name, wc.Weight))
+		}
+	}
+}
+
+func (c *traceConfig) traceHTTP(hcm *hcmv3.HttpConnectionManager, req *traceRequest, result *traceResult) {
+	rc := hcm.GetRouteConfig()
+	if name := hcm.GetRds().GetRouteConfigName(); name != "" {
+		if rc = c.routeConfigs[name]; rc == nil {
+			result.NoMatch = fmt.Sprintf("route configuration %s not found", name)
+			return
+		}
+	}
+	if rc == nil {
+		result.NoMatch = "the HTTP connection manager has no route configuration"
+		return
+	}
+	result.RouteConfig = rc.Name
+
+	vh := selectVirtualHost(rc.VirtualHosts, req.Host)
+	if vh == nil {
+		result.NoMatch = fmt.Sprintf("no virtual host matches host %q", req.Host)
+		return
+	}
+	result.VirtualHost = vh.Name
+
+	var route *routev3.Route
+	for _, r := range vh.Routes {
+		if reason := matchRoute(r.Match, req); reason != "" {
+			result.Unmatched = append(result.Unmatched, traceMismatch{Route: r.Name, Reason: reason})
+			continue
+		}
+		route = r
+		break
+	}
+	if route == nil {
+		result.NoMatch = fmt.Sprintf("no route of virtual host %s matches the request", vh.Name)
+		return
+	}
+	result.Route = route.Name
+	if source := routeSource(route.Metadata); source != nil {
+		result.source = source
+		result.RouteSource = source.String()
+	}
+	c.routeAction(route, result)
+	result.Filters = appliedHTTPFilters(hcm.HttpFilters, vh, route)
+}
+
+func (c *traceConfig) routeAction(route *routev3.Route, result *traceResult) {
+	switch action := route.Action.(type) {
+	case *routev3.Route_Route:
+		switch spec := action.Route.ClusterSpecifier.(type) {
+		case *routev3.RouteAction_Cluster:
+			result.Action = "forward to cluster " + spec.Cluster
+			result.Clusters = []traceCluster{c.cluster(spec.Cluster, 0)}
+		case *routev3.RouteAction_WeightedClusters:
+			result.Action = "forward to weighted clusters"
+			for _, wc := range spec.WeightedClusters.Clusters {
+				result.Clusters = append(result.Clusters, c.cluster(wc.Name, wc.GetWeight().GetValue()))
+			}
+		case *routev3.RouteAction_ClusterHeader:
+			result.Action = fmt.Sprintf("forward to the cluster named by header %q", spec.ClusterHeader)
+		default:
+			result.Action = "forward"
+		}
+	case *routev3.Route_Redirect:
+		result.Action = "redirect"
+		if host := action.Redirect.HostRedirect; host != "" {
+			result.Action += " to host " + host
+		}
+		if path := action.Redirect.GetPathRedirect(); path != "" {
+			result.Action += " to path " + path
+		}
+	case *routev3.Route_DirectResponse:
+		result.Action = fmt.Sprintf("direct response with status %d", action.DirectResponse.Status)
+	default:
+		result.Action = "no action"
+	}
+}
+
+// cluster returns the type and endpoints of a cluster.
+func (c *traceConfig) cluster(name string, weight uint32) traceCluster {
+	tc := traceCluster{Name: name, Weight: weight}
+	cluster := c.clusters[name]
+	if cluster == nil {
+		return tc
+	}
+	tc.Type = cluster.GetType().String()
+
+	cla := cluster.LoadAssignment
+	if cla == nil {
+		cla = c.endpoints[name]
+	}
+	for _, locality := range cla.GetEndpoints() {
+		for _, lbEndpoint := range locality.LbEndpoints {
+			if addr := lbEndpoint.GetEndpoint().GetAddress().GetSocketAddress(); addr != nil {
+				tc.Endpoints = append(tc.Endpoints, net.JoinHostPort(addr.Address, strconv.Itoa(int(addr.GetPortValue()))))
+			}
+		}
+	}
+	return tc
+}
+
+// routeSource returns the resource a route is translated from, from its metadata.
+func routeSource(metadata *corev3.Metadata) *traceResource {
+	resources := metadata.GetFilterMetadata()[envoyGatewayMetadataNamespace].GetFields()["resources"].GetListValue().GetValues()
+	if len(resources) == 0 {
+		return nil
+	}
+	fields := resources[0].GetStructValue().GetFields()
+	return &traceResource{
+		Kind:        fields["kind"].GetStringValue(),
+		Namespace:   fields["namespace"].GetStringValue(),
+		Name:        fields["name"].GetStringValue(),
+		SectionName: fields["sectionName"].GetStringValue(),
+	}
+}
+
+// appliedHTTPFilters returns the HTTP filters applied to the requests of a route. The filters
+// disabled in the HTTP connection manager can be enabled by the virtual host or the route, and
+// the other filters can be disabled by them.
+func appliedHTTPFilters(filters []*hcmv3.HttpFilter, vh *routev3.VirtualHost, route *routev3.Route) []string {
+	var applied []string
+	for _, filter := range filters {
+		enabled := !filter.Disabled
+		for _, perFilterConfigs := range []map[string]*anypb.Any{vh.TypedPerFilterConfig, route.TypedPerFilterConfig} {
+			if cfg, ok := perFilterConfigs[filter.Name]; ok {
+				enabled = !filterConfigDisabled(cfg)
+			}
+		}
+		if enabled {
+			applied = append(applied, filter.Name)
+		}
+	}
+	return applied
+}
+
+// filterConfigDisabled returns whether a per-filter config disables its filter.
+func filterConfigDisabled(cfg *anypb.Any) bool {
+	filterConfig := &routev3.FilterConfig{}
+	if !cfg.MessageIs(filterConfig) {
+		return false
+	}
+	return cfg.UnmarshalTo(filterConfig) == nil && filterConfig.Disabled
+}
+
+// selectFilterChain selects the filter chain of a listener like Envoy does: the chains are
+// narrowed down by the most specific match of each criterion in turn, and the default
+// filter chain is selected if none is left.
+func selectFilterChain(l *listenerv3.Listener, req *traceRequest) *listenerv3.FilterChain {
+	transportProtocol := "raw_buffer"
+	if req.SNI != "" {
+		transportProtocol = "tls"
+	}
+
+	candidates := l.FilterChains
+	candidates = narrowFilterChains(candidates, func(m *listenerv3.FilterChainMatch) (int, bool) {
+		return matchServerNames(m.GetServerNames(), req.SNI)
+	})
+	candidates = narrowFilterChains(candidates, func(m *listenerv3.FilterChainMatch) (int, bool) {
+		if m.GetTransportProtocol() == "" {
+			return -1, true
+		}
+		return 0, m.GetTransportProtocol() == transportProtocol
+	})
+	candidates = narrowFilterChains(candidates, func(m *listenerv3.FilterChainMatch) (int, bool) {
+		return matchPrefixRanges(m.GetDirectSourcePrefixRanges(), req.SourceIP)
+	})
+	candidates = narrowFilterChains(candidates, func(m *listenerv3.FilterChainMatch) (int, bool) {
+		return matchPrefixRanges(m.GetSourcePrefixRanges(), req.SourceIP)
+	})
+	if len(candidates) > 0 {
+		return candidates[0]
+	}
+	return l.DefaultFilterChain
+}
+
+// narrowFilterChains keeps the filter chains with the most specific match of a criterion, or
+// the chains which don't specify it if none matches. match returns the specificity of the
+// match, which is negative if the criterion isn't specified, and whether it matches.
+func narrowFilterChains(chains []*listenerv3.FilterChain, match func(*listenerv3.FilterChainMatch) (int, bool)) []*listenerv3.FilterChain {
+	var specific, unspecified []*listenerv3.FilterChain
+	best := -1
+	for _, fc := range chains {
+		score, ok := match(fc.FilterChainMatch)
+		switch {
+		case !ok:
+		case score < 0:
+			unspecified = append(unspecified, fc)
+		case score > best:
+			best = score
+			specific = []*listenerv3.FilterChain{fc}
+		case score == best:
+			specific = append(specific, fc)
+		}
+	}
+	if len(specific) > 0 {
+		return specific
+	}
+	return unspecified
+}
+
+func matchServerNames(names []string, sni string) (int, bool) {
+	if len(names) == 0 {
+		return -1, true
+	}
+	best, matched := 0, false
+	for _, name := range names {
+		switch {
+		case strings.EqualFold(name, sni):
+			// An exact match is more specific than any wildcard.
+			return 1 << 16, true
+		case strings.HasPrefix(name, "*") && len(sni) > len(name)-1 &&
+			strings.HasSuffix(strings.ToLower(sni), strings.ToLower(name[1:])):
+			if len(name) > best {
+				best = len(name)
+			}
+			matched = true
+		}
+	}
+	return best, matched
+}
+
+func matchPrefixRanges(ranges []*corev3.CidrRange, ip netip.Addr) (int, bool) {
+	if len(ranges) == 0 {
+		return -1, true
+	}
+	best, matched := 0, false
+	for _, r := range ranges {
+		addr, err := netip.ParseAddr(r.AddressPrefix)
+		if err != nil || !ip.IsValid() {
+			continue
+		}
+		bits := int(r.GetPrefixLen().GetValue())
+		prefix, err := addr.Prefix(bits)
+		if err != nil || !prefix.Contains(ip) {
+			continue
+		}
+		if bits >= best {
+			best, matched = bits, true
+		}
+	}
+	return best, matched
+}
+
+// selectVirtualHost selects the virtual host of a host like Envoy does: an exact domain is
+// preferred over the longest suffix wildcard, then the longest prefix wildcard, then "*".
+func selectVirtualHost(vhs []*routev3.VirtualHost, host string) *routev3.VirtualHost {
+	host = strings.ToLower(host)
+	if vh := selectVirtualHostForHost(vhs, host); vh != nil {
+		return vh
+	}
+	// Retry without the port of the host.
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return selectVirtualHostForHost(vhs, h)
+	}
+	return nil
+}
+
+func selectVirtualHostForHost(vhs []*routev3.VirtualHost, host string) *routev3.VirtualHost {
+	var (
+		suffix, prefix, catchAll   *routev3.VirtualHost
+		suffixLength, prefixLength int
+	)
+	for _, vh := range vhs {
+		for _, domain := range vh.Domains {
+			domain = strings.ToLower(domain)
+			switch {
+			case domain == host:
+				return vh
+			case domain == "*":
+				if catchAll == nil {
+					catchAll = vh
+				}
+			case strings.HasPrefix(domain, "*"):
+				if len(host) > len(domain)-1 && strings.HasSuffix(host, domain[1:]) && len(domain) > suffixLength {
+					suffix, suffixLength = vh, len(domain)
+				}
+			case strings.HasSuffix(domain, "*"):
+				if len(host) > len(domain)-1 && strings.HasPrefix(host, domain[:len(domain)-1]) && len(domain) > prefixLength {
+					prefix, prefixLength = vh, len(domain)
+				}
+			}
+		}
+	}
+	switch {
+	case suffix != nil:
+		return suffix
+	case prefix != nil:
+		return prefix
+	default:
+		return catchAll
+	}
+}
+
+// matchRoute returns why a request doesn't match a route, or "" if it does.
+func matchRoute(m *routev3.RouteMatch, req *traceRequest) string {
+	path, query, _ := strings.Cut(req.Path, "?")
+	caseSensitive := m.GetCaseSensitive() == nil || m.GetCaseSensitive().GetValue()
+	fold := func(s string) string {
+		if caseSensitive {
+			return s
+		}
+		return strings.ToLower(s)
+	}
+
+	switch spec := m.GetPathSpecifier().(type) {
+	case *routev3.RouteMatch_Prefix:
+		if !strings.HasPrefix(fold(path), fold(spec.Prefix)) {
+			return fmt.Sprintf("path %q does not have prefix %q", path, spec.Prefix)
+		}
+	case *routev3.RouteMatch_Path:
+		if fold(path) != fold(spec.Path) {
+			return fmt.Sprintf("path %q is not %q", path, spec.Path)
+		}
+	case *routev3.RouteMatch_PathSeparatedPrefix:
+		prefix := spec.PathSeparatedPrefix
+		if fold(path) != fold(prefix) && !strings.HasPrefix(fold(path), fold(prefix)+"/") {
+			return fmt.Sprintf("path %q does not have path prefix %q", path, prefix)
+		}
+	case *routev3.RouteMatch_SafeRegex:
+		matched, err := fullMatch(spec.SafeRegex.GetRegex(), path)
+		if err != nil {
+			return err.Error()
+		}
+		if !matched {
+			return fmt.Sprintf("path %q does not match regex %q", path, spec.SafeRegex.GetRegex())
+		}
+	case *routev3.RouteMatch_ConnectMatcher_:
+		if req.Method != "CONNECT" {
+			return "the request is not a CONNECT request"
+		}
+	case nil:
+	default:
+		return fmt.Sprintf("unsupported path matcher %T", spec)
+	}
+
+	for _, h := range m.Headers {
+		if reason := matchHeader(h, req); reason != "" {
+			return reason
+		}
+	}
+
+	values, _ := url.ParseQuery(query)
+	for _, q := range m.QueryParameters {
+		if reason := matchQueryParameter(q, values); reason != "" {
+			return reason
+		}
+	}
+
+	if m.Grpc != nil {
+		if contentType, _ := req.header("content-type"); !strings.HasPrefix(contentType, "application/grpc") {
+			return "the request is not a gRPC request"
+		}
+	}
+	return ""
+}
+
+// matchHeader returns why a request doesn't match a header matcher, or "" if it does.
+func matchHeader(h *routev3.HeaderMatcher, req *traceRequest) string {
+	value, present := req.header(h.Name)
+	if !present && h.TreatMissingHeaderAsEmpty {
+		value, present = "", true
+	}
+
+	var matched bool
+	var description string
+	switch spec := h.HeaderMatchSpecifier.(type) {
+	case *routev3.HeaderMatcher_PresentMatch:
+		matched, description = present == spec.PresentMatch, "present"
+		if !spec.PresentMatch {
+			description = "absent"
+		}
+	case *routev3.HeaderMatcher_ExactMatch:
+		matched, description = present && value == spec.ExactMatch, fmt.Sprintf("exact %q", spec.ExactMatch)
+	case *routev3.HeaderMatcher_PrefixMatch:
+		matched, description = present && strings.HasPrefix(value, spec.PrefixMatch), fmt.Sprintf("prefix %q", spec.PrefixMatch)
+	case *routev3.HeaderMatcher_SuffixMatch:
+		matched, description = present && strings.HasSuffix(value, spec.SuffixMatch), fmt.Sprintf("suffix %q", spec.SuffixMatch)
+	case *routev3.HeaderMatcher_ContainsMatch:
+		matched, description = present && strings.Contains(value, spec.ContainsMatch), fmt.Sprintf("contains %q", spec.ContainsMatch)
+	case *routev3.HeaderMatcher_SafeRegexMatch:
+		ok, err := fullMatch(spec.SafeRegexMatch.GetRegex(), value)
+		if err != nil {
+			return err.Error()
+		}
+		matched, description = present && ok, fmt.Sprintf("regex %q", spec.SafeRegexMatch.GetRegex())
+	case *routev3.HeaderMatcher_StringMatch:
+		ok, err := matchString(spec.StringMatch, value)
+		if err != nil {
+			return err.Error()
+		}
+		matched, description = present && ok, describeStringMatcher(spec.StringMatch)
+	case *routev3.HeaderMatcher_RangeMatch:
+		n, err := strconv.ParseInt(value, 10, 64)
+		matched = present && err == nil && n >= spec.RangeMatch.Start && n < spec.RangeMatch.End
+		description = fmt.Sprintf("range [%d, %d)", spec.RangeMatch.Start, spec.RangeMatch.End)
+	default:
+		matched, description = present, "present"
+	}
+
+	if h.InvertMatch {
+		if matched {
+			return fmt.Sprintf("header %q matches %s, and the match is inverted", h.Name, description)
+		}
+		return ""
+	}
+	if matched {
+		return ""
+	}
+	if !present {
+		return fmt.Sprintf("header %q is missing, expected %s", h.Name, description)
+	}
+	return fmt.Sprintf("header %q value %q does not match %s", h.Name, value, description)
+}
+
+// matchQueryParameter returns why a query doesn't match a query parameter matcher, or "" if it does.
+func matchQueryParameter(q *routev3.QueryParameterMatcher, values url.Values) string {
+	value, present := "", values.Has(q.Name)
+	if present {
+		value = values.Get(q.Name)
+	}
+	switch spec := q.QueryParameterMatchSpecifier.(type) {
+	case *routev3.QueryParameterMatcher_StringMatch:
+		ok, err := matchString(spec.StringMatch, value)
+		if err != nil {
+			return err.Error()
+		}
+		if !present || !ok {
+			return fmt.Sprintf("query parameter %q value %q does not match %s", q.Name, value, describeStringMatcher(spec.StringMatch))
+		}
+	default:
+		if !present {
+			return fmt.Sprintf("query parameter %q is missing", q.Name)
+		}
+	}
+	return ""
+}
+
+func matchString(m *matcherv3.StringMatcher, value string) (bool, error) {
+	fold := func(s string) string {
+		if m.IgnoreCase {
+			return strings.ToLower(s)
+		}
+		return s
+	}
+	switch spec := m.MatchPattern.(type) {
+	case *matcherv3.StringMatcher_Exact:
+		return fold(value) == fold(spec.Exact), nil
+	case *matcherv3.StringMatcher_Prefix:
+		return strings.HasPrefix(fold(value), fold(spec.Prefix)), nil
+	case *matcherv3.StringMatcher_Suffix:
+		return strings.HasSuffix(fold(value), fold(spec.Suffix)), nil
+	case *matcherv3.StringMatcher_Contains:
+		return strings.Contains(fold(value), fold(spec.Contains)), nil
+	case *matcherv3.StringMatcher_SafeRegex:
+		return fullMatch(spec.SafeRegex.GetRegex(), value)
+	default:
+		return false, fmt.Errorf("unsupported string matcher %T", spec)
+	}
+}
+
+func describeStringMatcher(m *matcherv3.StringMatcher) string {
+	var description string
+	switch spec := m.MatchPattern.(type) {
+	case *matcherv3.StringMatcher_Exact:
+		description = fmt.Sprintf("exact %q", spec.Exact)
+	case *matcherv3.StringMatcher_Prefix:
+		description = fmt.Sprintf("prefix %q", spec.Prefix)
+	case *matcherv3.StringMatcher_Suffix:
+		description = fmt.Sprintf("suffix %q", spec.Suffix)
+	case *matcherv3.StringMatcher_Contains:
+		description = fmt.Sprintf("contains %q", spec.Contains)
+	case *matcherv3.StringMatcher_SafeRegex:
+		description = fmt.Sprintf("regex %q", spec.SafeRegex.GetRegex())
+	}
+	if m.IgnoreCase {
+		description += " ignoring case"
+	}
+	return description
+}
+
+// fullMatch returns whether a regex matches the whole value, like the RE2 matchers of Envoy.
+func fullMatch(expr, value string) (bool, error) {
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return false, fmt.Errorf("invalid regex %q: %w", expr, err)
+	}
+	return re.MatchString(value), nil
+}
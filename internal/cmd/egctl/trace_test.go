@@ -0,0 +1,204 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"net/netip"
+	"os"
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+)
+
+const traceTestFile = "testdata/trace/routes.yaml"
+
+func TestTraceGatewayAPI(t *testing.T) {
+	testCases := []struct {
+		name        string
+		request     traceRequest
+		headers     []string
+		routeSource string
+		features    []string
+		policies    []string
+		unmatched   []string
+		noMatch     string
+	}{
+		{
+			name:        "header match",
+			request:     traceRequest{Port: 80, Host: "api.example.com", Method: "GET", Path: "/api/v2/users?page=2"},
+			headers:     []string{"X-Version: 2"},
+			routeSource: "HTTPRoute default/api-v2",
+			features:    []string{"traffic.retry", "security.cors"},
+			policies:    []string{"SecurityPolicy default/cors", "BackendTrafficPolicy default/retries"},
+		},
+		{
+			name:        "missing header",
+			request:     traceRequest{Port: 80, Host: "api.example.com:80", Method: "GET", Path: "/api/v2/users"},
+			routeSource: "HTTPRoute default/api-v1",
+			features:    []string{"traffic.retry"},
+			policies:    []string{"BackendTrafficPolicy default/retries"},
+			unmatched:   []string{`header "x-version" is missing, expected exact "2"`},
+		},
+		{
+			name:    "path not matched",
+			request: traceRequest{Port: 80, Host: "api.example.com", Method: "GET", Path: "/apis"},
+			unmatched: []string{
+				`path "/apis" does not have path prefix "/api/v2"`,
+				`path "/apis" does not have path prefix "/api"`,
+			},
+			noMatch: "no route of virtual host default/eg/http/api_example_com matches the request",
+		},
+		{
+			name:    "host not matched",
+			request: traceRequest{Port: 80, Host: "www.example.com", Method: "GET", Path: "/api"},
+			noMatch: `no virtual host matches host "www.example.com"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := &traceOptions{file: traceTestFile, request: tc.request, headers: tc.headers}
+			require.NoError(t, opts.complete())
+			results, err := traceGatewayAPI(opts)
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+
+			result := results[0]
+			require.Equal(t, "default/eg", result.IRKey)
+			require.Equal(t, "default/eg/http", result.Listener)
+			require.Equal(t, tc.routeSource, result.RouteSource)
+			require.Equal(t, tc.features, result.Features)
+			require.Equal(t, tc.noMatch, result.NoMatch)
+
+			var policies []string
+			for _, p := range result.Policies {
+				policies = append(policies, p.Kind+" "+p.Namespace+"/"+p.Name)
+				require.Equal(t, []string{"Accepted=True"}, p.Conditions)
+			}
+			require.Equal(t, tc.policies, policies)
+
+			var unmatched []string
+			for _, m := range result.Unmatched {
+				unmatched = append(unmatched, m.Reason)
+			}
+			require.Equal(t, tc.unmatched, unmatched)
+		})
+	}
+}
+
+func TestTraceConfigDump(t *testing.T) {
+	inBytes, err := os.ReadFile(traceTestFile)
+	require.NoError(t, err)
+	resources, err := resource.LoadResourcesFromYAMLBytes(inBytes, false)
+	require.NoError(t, err)
+	_, configDumps, err := translateGatewayAPIToConfigDumps(config.DefaultNamespace, config.DefaultDNSDomain, false, resources)
+	require.NoError(t, err)
+	dump, err := protojson.Marshal(configDumps["default/eg"])
+	require.NoError(t, err)
+
+	results, err := traceConfigDumpBytes(dump, &traceRequest{
+		Port:    80,
+		Host:    "api.example.com",
+		Method:  "GET",
+		Path:    "/api/v2",
+		Headers: map[string]string{"x-version": "2"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "HTTPRoute default/api-v2", results[0].RouteSource)
+	require.Equal(t, []traceCluster{{
+		Name:      "httproute/default/api-v2/rule/0",
+		Type:      "EDS",
+		Endpoints: []string{"10.0.0.2:8080"},
+	}}, results[0].Clusters)
+	require.Equal(t, []string{"envoy.filters.http.cors", "envoy.filters.http.router"}, results[0].Filters)
+
+	var out bytes.Buffer
+	require.NoError(t, writeTraceResults(&out, results, ""))
+	require.Contains(t, out.String(), "Route:        httproute/default/api-v2/rule/0/match/0/api_example_com (HTTPRoute default/api-v2)\n")
+}
+
+func TestSelectVirtualHost(t *testing.T) {
+	vhs := []*routev3.VirtualHost{
+		{Name: "catch-all", Domains: []string{"*"}},
+		{Name: "prefix", Domains: []string{"api.*"}},
+		{Name: "suffix", Domains: []string{"*.example.com"}},
+		{Name: "longer-suffix", Domains: []string{"*.api.example.com"}},
+		{Name: "exact", Domains: []string{"api.example.com"}},
+	}
+	testCases := map[string]string{
+		"api.example.com":    "exact",
+		"API.example.com":    "exact",
+		"v1.api.example.com": "longer-suffix",
+		"www.example.com":    "suffix",
+		"api.example.org":    "prefix",
+		"example.org":        "catch-all",
+	}
+	for host, expected := range testCases {
+		t.Run(host, func(t *testing.T) {
+			require.Equal(t, expected, selectVirtualHost(vhs, host).GetName())
+		})
+	}
+}
+
+func TestSelectFilterChain(t *testing.T) {
+	l := &listenerv3.Listener{
+		FilterChains: []*listenerv3.FilterChain{
+			{Name: "wildcard", FilterChainMatch: &listenerv3.FilterChainMatch{ServerNames: []string{"*.example.com"}, TransportProtocol: "tls"}},
+			{Name: "exact", FilterChainMatch: &listenerv3.FilterChainMatch{ServerNames: []string{"api.example.com"}, TransportProtocol: "tls"}},
+			{Name: "internal", FilterChainMatch: &listenerv3.FilterChainMatch{
+				ServerNames:        []string{"internal.example.com"},
+				SourcePrefixRanges: []*corev3.CidrRange{{AddressPrefix: "10.0.0.0", PrefixLen: wrapperspb.UInt32(8)}},
+			}},
+			{Name: "plaintext", FilterChainMatch: &listenerv3.FilterChainMatch{TransportProtocol: "raw_buffer"}},
+		},
+		DefaultFilterChain: &listenerv3.FilterChain{Name: "default"},
+	}
+	testCases := []struct {
+		sni      string
+		sourceIP string
+		expected string
+	}{
+		{sni: "api.example.com", expected: "exact"},
+		{sni: "www.example.com", expected: "wildcard"},
+		{sni: "internal.example.com", sourceIP: "10.1.2.3", expected: "internal"},
+		{sni: "internal.example.com", sourceIP: "192.168.1.1", expected: "default"},
+		{sni: "www.example.org", expected: "default"},
+		{expected: "plaintext"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.sni+tc.sourceIP, func(t *testing.T) {
+			req := &traceRequest{SNI: tc.sni}
+			if tc.sourceIP != "" {
+				req.SourceIP = netip.MustParseAddr(tc.sourceIP)
+			}
+			require.Equal(t, tc.expected, selectFilterChain(l, req).GetName())
+		})
+	}
+}
+
+func TestTraceOptionsComplete(t *testing.T) {
+	require.Error(t, (&traceOptions{}).complete())
+	require.Error(t, (&traceOptions{file: traceTestFile, configDump: "dump.json"}).complete())
+	require.Error(t, (&traceOptions{configDump: "dump.json", gateway: "default/eg"}).complete())
+	require.Error(t, (&traceOptions{file: traceTestFile, headers: []string{"x-version"}}).complete())
+	require.Error(t, (&traceOptions{file: traceTestFile, sourceIP: "foo"}).complete())
+
+	opts := &traceOptions{file: traceTestFile, headers: []string{"X-Version: 2"}, request: traceRequest{Method: "post", Path: "api"}}
+	require.NoError(t, opts.complete())
+	require.Equal(t, "POST", opts.request.Method)
+	require.Equal(t, "/api", opts.request.Path)
+	require.Equal(t, map[string]string{"x-version": "2"}, opts.request.Headers)
+}
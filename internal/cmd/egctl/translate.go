@@ -7,6 +7,7 @@ package egctl
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -24,6 +25,8 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/envoyproxy/gateway/api/v1alpha1/validation"
+	"github.com/envoyproxy/gateway/internal/cmd/options"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
@@ -49,6 +52,7 @@ type TranslationResult struct {
 func newTranslateCommand() *cobra.Command {
 	var (
 		inFile, inType, output, resourceType string
+		gatewayClass, bundleFile             string
 		addMissingResources                  bool
 		outTypes                             []string
 		dnsDomain                            string
@@ -91,23 +95,27 @@ func newTranslateCommand() *cobra.Command {
 
   # Translate Gateway API Resources into IR in YAML output,
   egctl experimental translate --from gateway-api --to ir --output yaml --file <input file>
+
+  # Translate the Gateway API Resources of a GatewayClass in the cluster into All xDS Resources,
+  # and save them with their Secrets redacted to a bundle, which can be translated again with --file.
+  egctl experimental translate --gateway-class <gateway class> --context <kubeconfig context> --to xds --bundle <bundle file>
 	`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return translate(cmd.OutOrStdout(), inFile, inType, outTypes, output, resourceType, addMissingResources, namespace, dnsDomain)
+			return translate(cmd.Context(), cmd.OutOrStdout(), inFile, gatewayClass, bundleFile, inType, outTypes, output, resourceType, addMissingResources, namespace, dnsDomain)
 		},
 	}
 
+	options.AddKubeConfigFlags(translateCommand.PersistentFlags())
 	translateCommand.PersistentFlags().StringVarP(&inFile, "file", "f", "", "Location of input file.")
-	if err := translateCommand.MarkPersistentFlagRequired("file"); err != nil {
-		return nil
-	}
+	translateCommand.PersistentFlags().StringVarP(&gatewayClass, "gateway-class", "", "", "Name of the GatewayClass whose resources are translated from the cluster, with their Secrets redacted, if --file is not set.")
+	translateCommand.PersistentFlags().StringVarP(&bundleFile, "bundle", "", "", "Location of a file to save the input resources to, which can be translated again with --file.")
 	translateCommand.PersistentFlags().StringVarP(&inType, "from", "", gatewayAPIType, getValidInputTypesStr())
 	translateCommand.PersistentFlags().StringSliceVarP(&outTypes, "to", "", []string{gatewayAPIType, xdsType}, getValidOutputTypesStr())
 	translateCommand.PersistentFlags().StringVarP(&output, "output", "o", yamlOutput, "One of 'yaml' or 'json'")
 	translateCommand.PersistentFlags().StringVarP(&resourceType, "type", "t", string(AllEnvoyConfigType), getValidResourceTypesStr())
 	translateCommand.PersistentFlags().BoolVarP(&addMissingResources, "add-missing-resources", "", false, "Provides dummy resources if missed")
-	translateCommand.PersistentFlags().StringVarP(&dnsDomain, "dns-domain", "", "cluster.local", "DNS domain used by k8s services, default is cluster.local")
-	translateCommand.PersistentFlags().StringVarP(&namespace, "namespace", "n", "envoy-gateway-system", "Namespace where envoy gateway is installed.")
+	translateCommand.PersistentFlags().StringVarP(&dnsDomain, "dns-domain", "", config.DefaultDNSDomain, "DNS domain used by k8s services, default is cluster.local")
+	translateCommand.PersistentFlags().StringVarP(&namespace, "namespace", "n", config.DefaultNamespace, "Namespace where envoy gateway is installed.")
 
 	return translateCommand
 }
@@ -194,7 +202,7 @@ func getInputBytes(inFile string) ([]byte, error) {
 	return os.ReadFile(inFile)
 }
 
-func validate(inFile, inType string, outTypes []string, resourceType string) error {
+func validate(inFile, gatewayClass, inType string, outTypes []string, resourceType string) error {
 	if !isValidInputType(inType) {
 		return fmt.Errorf("%s is not a valid input type. %s", inType, getValidInputTypesStr())
 	}
@@ -205,48 +213,48 @@ func validate(inFile, inType string, outTypes []string, resourceType string) err
 	if !isValidResourceType(envoyConfigType(resourceType)) {
 		return fmt.Errorf("%s is not a valid output type. %s", resourceType, getValidResourceTypesStr())
 	}
-	if inFile == "" {
-		return fmt.Errorf("--file must be specified")
+	if (inFile == "") == (gatewayClass == "") {
+		return fmt.Errorf("exactly one of --file and --gateway-class must be specified")
 	}
 
 	return nil
 }
 
-func translate(w io.Writer, inFile, inType string, outTypes []string, output, resourceType string, addMissingResources bool, namespace, dnsDomain string) error {
-	if err := validate(inFile, inType, outTypes, resourceType); err != nil {
+func translate(ctx context.Context, w io.Writer, inFile, gatewayClass, bundleFile, inType string, outTypes []string, output, resourceType string, addMissingResources bool, namespace, dnsDomain string) error {
+	if err := validate(inFile, gatewayClass, inType, outTypes, resourceType); err != nil {
 		return err
 	}
 
-	inBytes, err := getInputBytes(inFile)
-	if err != nil {
-		return fmt.Errorf("unable to read input file: %w", err)
-	}
-
 	if inType == gatewayAPIType {
-		// Unmarshal input
-		resources, err := resource.LoadResourcesFromYAMLBytes(inBytes, addMissingResources)
+		resources, endpointRouting, err := loadTranslateResources(ctx, inFile, gatewayClass, addMissingResources)
 		if err != nil {
-			return fmt.Errorf("unable to unmarshal input: %w", err)
+			return err
+		}
+		// The bundle is saved before the translation, which updates the resources.
+		if bundleFile != "" {
+			if err := writeBundleFile(bundleFile, resources); err != nil {
+				return err
+			}
 		}
 
 		var result TranslationResult
 		for _, outType := range outTypes {
 			// Translate
 			if outType == gatewayAPIType {
-				result.Resources, err = translateGatewayAPIToGatewayAPI(resources)
+				result.Resources, err = translateGatewayAPIToGatewayAPI(namespace, endpointRouting, resources)
 				if err != nil {
 					return err
 				}
 			}
 			if outType == xdsType {
-				res, err := TranslateGatewayAPIToXds(namespace, dnsDomain, resourceType, resources)
+				res, err := translateGatewayAPIToXds(namespace, dnsDomain, resourceType, endpointRouting, resources)
 				if err != nil {
 					return err
 				}
 				result.Xds = res
 			}
 			if outType == irType {
-				res, err := translateGatewayAPIToIR(resources)
+				res, err := translateGatewayAPIToIR(namespace, endpointRouting, resources)
 				if err != nil {
 					return err
 				}
@@ -265,18 +273,40 @@ func translate(w io.Writer, inFile, inType string, outTypes []string, output, re
 	return fmt.Errorf("unable to find translate from input type %s to output type %s", inType, outTypes)
 }
 
-func translateGatewayAPIToIR(resources *resource.Resources) (*gatewayapi.TranslateResult, error) {
-	if resources.GatewayClass == nil {
-		return nil, fmt.Errorf("the GatewayClass resource is required")
+// loadTranslateResources loads the resources to translate from a file, or from the cluster with
+// their Secrets redacted. It returns whether the backends are routed to as the EnvoyProxy sets,
+// which is the case for the resources of the cluster and of a bundle, since they have the
+// EndpointSlices of the Services.
+func loadTranslateResources(ctx context.Context, inFile, gatewayClass string, addMissingResources bool) (*resource.Resources, bool, error) {
+	if inFile == "" {
+		cli, err := newK8sClient()
+		if err != nil {
+			return nil, false, err
+		}
+		resources, err := loadClusterResources(ctx, cli, gatewayClass)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := redactSecrets(resources); err != nil {
+			return nil, false, err
+		}
+		return resources, true, nil
 	}
 
-	t := &gatewayapi.Translator{
-		GatewayControllerName:   string(resources.GatewayClass.Spec.ControllerName),
-		GatewayClassName:        gwapiv1.ObjectName(resources.GatewayClass.Name),
-		GlobalRateLimitEnabled:  true,
-		EndpointRoutingDisabled: true,
-		EnvoyPatchPolicyEnabled: true,
-		BackendEnabled:          true,
+	inBytes, err := getInputBytes(inFile)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to read input file: %w", err)
+	}
+	resources, err := resource.LoadResourcesFromYAMLBytes(inBytes, addMissingResources)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to unmarshal input: %w", err)
+	}
+	return resources, isBundle(inBytes), nil
+}
+
+func translateGatewayAPIToIR(namespace string, endpointRouting bool, resources *resource.Resources) (*gatewayapi.TranslateResult, error) {
+	if resources.GatewayClass == nil {
+		return nil, fmt.Errorf("the GatewayClass resource is required")
 	}
 
 	// Fix the services in the resources section so that they have an IP address - this prevents nasty
@@ -287,26 +317,18 @@ func translateGatewayAPIToIR(resources *resource.Resources) (*gatewayapi.Transla
 		}
 	}
 
-	result, _ := t.Translate(resources)
+	result, _ := newGatewayAPITranslator(namespace, endpointRouting, resources).Translate(resources)
 
 	return result, nil
 }
 
-func translateGatewayAPIToGatewayAPI(resources *resource.Resources) (resource.Resources, error) {
+func translateGatewayAPIToGatewayAPI(namespace string, endpointRouting bool, resources *resource.Resources) (resource.Resources, error) {
 	if resources.GatewayClass == nil {
 		return resource.Resources{}, fmt.Errorf("the GatewayClass resource is required")
 	}
 
 	// Translate from Gateway API to Xds IR
-	gTranslator := &gatewayapi.Translator{
-		GatewayControllerName:   string(resources.GatewayClass.Spec.ControllerName),
-		GatewayClassName:        gwapiv1.ObjectName(resources.GatewayClass.Name),
-		GlobalRateLimitEnabled:  true,
-		EndpointRoutingDisabled: true,
-		EnvoyPatchPolicyEnabled: true,
-		BackendEnabled:          true,
-	}
-	gRes, _ := gTranslator.Translate(resources)
+	gRes, _ := newGatewayAPITranslator(namespace, endpointRouting, resources).Translate(resources)
 	// Update the status of the GatewayClass based on EnvoyProxy validation
 	epInvalid := false
 	if resources.EnvoyProxyForGatewayClass != nil {
@@ -332,51 +354,19 @@ func translateGatewayAPIToGatewayAPI(resources *resource.Resources) (resource.Re
 }
 
 func TranslateGatewayAPIToXds(namespace, dnsDomain, resourceType string, resources *resource.Resources) (map[string]any, error) {
-	if resources.GatewayClass == nil {
-		return nil, fmt.Errorf("the GatewayClass resource is required")
-	}
-
-	// Translate from Gateway API to Xds IR
-	gTranslator := &gatewayapi.Translator{
-		GatewayControllerName:   string(resources.GatewayClass.Spec.ControllerName),
-		GatewayClassName:        gwapiv1.ObjectName(resources.GatewayClass.Name),
-		GlobalRateLimitEnabled:  true,
-		EndpointRoutingDisabled: true,
-		EnvoyPatchPolicyEnabled: true,
-		BackendEnabled:          true,
-	}
-	gRes, _ := gTranslator.Translate(resources)
+	return translateGatewayAPIToXds(namespace, dnsDomain, resourceType, false, resources)
+}
 
-	keys := []string{}
-	for key := range gRes.XdsIR {
-		keys = append(keys, key)
+// translateGatewayAPIToXds translates the Gateway API resources into the xDS resources of a type,
+// routing to the endpoints of the backends if endpointRouting is set.
+func translateGatewayAPIToXds(namespace, dnsDomain, resourceType string, endpointRouting bool, resources *resource.Resources) (map[string]any, error) {
+	_, configDumps, err := translateGatewayAPIToConfigDumps(namespace, dnsDomain, endpointRouting, resources)
+	if err != nil {
+		return nil, err
 	}
-	// Make output stable since XdsIR is a map
-	sort.Strings(keys)
 
-	// Translate from Xds IR to Xds
 	result := make(map[string]interface{})
-	for _, key := range keys {
-		val := gRes.XdsIR[key]
-		xTranslator := &translator.Translator{
-			// Set some default settings for translation
-			GlobalRateLimit: &translator.GlobalRateLimitSettings{
-				ServiceURL: ratelimit.GetServiceURL(namespace, dnsDomain),
-			},
-		}
-		if resources.EnvoyProxyForGatewayClass != nil {
-			xTranslator.FilterOrder = resources.EnvoyProxyForGatewayClass.Spec.FilterOrder
-		}
-		xRes, err := xTranslator.Translate(val)
-		if err != nil {
-			return nil, fmt.Errorf("failed to translate xds ir for key %s value %+v, error:%w", key, val, err)
-		}
-
-		globalConfigs, err := constructConfigDump(resources, xRes)
-		if err != nil {
-			return nil, err
-		}
-
+	for key, globalConfigs := range configDumps {
 		wrapper := map[string]any{}
 		var data protoreflect.ProtoMessage
 		rType := envoyConfigType(resourceType)
@@ -405,6 +395,73 @@ func TranslateGatewayAPIToXds(namespace, dnsDomain, resourceType string, resourc
 	return result, nil
 }
 
+// translateGatewayAPIToConfigDumps translates the Gateway API resources into the IR, and into
+// the config dump of the proxies of each IR key.
+func translateGatewayAPIToConfigDumps(namespace, dnsDomain string, endpointRouting bool, resources *resource.Resources) (*gatewayapi.TranslateResult, map[string]*adminv3.ConfigDump, error) {
+	if resources.GatewayClass == nil {
+		return nil, nil, fmt.Errorf("the GatewayClass resource is required")
+	}
+
+	// Translate from Gateway API to Xds IR
+	gRes, _ := newGatewayAPITranslator(namespace, endpointRouting, resources).Translate(resources)
+
+	keys := []string{}
+	for key := range gRes.XdsIR {
+		keys = append(keys, key)
+	}
+	// Make output stable since XdsIR is a map
+	sort.Strings(keys)
+
+	// Translate from Xds IR to Xds
+	configDumps := make(map[string]*adminv3.ConfigDump, len(keys))
+	for _, key := range keys {
+		val := gRes.XdsIR[key]
+		xRes, err := newXdsTranslator(namespace, dnsDomain, resources).Translate(val)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to translate xds ir for key %s value %+v, error:%w", key, val, err)
+		}
+
+		globalConfigs, err := constructConfigDump(resources, xRes)
+		if err != nil {
+			return nil, nil, err
+		}
+		configDumps[key] = globalConfigs
+	}
+
+	return gRes, configDumps, nil
+}
+
+// newGatewayAPITranslator returns the translator of the Gateway API resources to the IR, with
+// all the features enabled, for an Envoy Gateway installed in the namespace. The backends are
+// routed to as the routingType of the EnvoyProxy sets if endpointRouting is set, such as for the
+// resources of a cluster, which have their EndpointSlices, and to the Cluster IP of their Services
+// otherwise.
+func newGatewayAPITranslator(namespace string, endpointRouting bool, resources *resource.Resources) *gatewayapi.Translator {
+	return &gatewayapi.Translator{
+		GatewayControllerName:   string(resources.GatewayClass.Spec.ControllerName),
+		GatewayClassName:        gwapiv1.ObjectName(resources.GatewayClass.Name),
+		Namespace:               namespace,
+		GlobalRateLimitEnabled:  true,
+		EndpointRoutingDisabled: !endpointRouting,
+		EnvoyPatchPolicyEnabled: true,
+		BackendEnabled:          true,
+	}
+}
+
+// newXdsTranslator returns the translator of the xDS IR to xDS.
+func newXdsTranslator(namespace, dnsDomain string, resources *resource.Resources) *translator.Translator {
+	xTranslator := &translator.Translator{
+		// Set some default settings for translation
+		GlobalRateLimit: &translator.GlobalRateLimitSettings{
+			ServiceURL: ratelimit.GetServiceURL(namespace, dnsDomain),
+		},
+	}
+	if resources.EnvoyProxyForGatewayClass != nil {
+		xTranslator.FilterOrder = resources.EnvoyProxyForGatewayClass.Spec.FilterOrder
+	}
+	return xTranslator
+}
+
 // printOutput prints the echo-backed gateway API and xDS output
 func printOutput(w io.Writer, result TranslationResult, output string) error {
 	var (
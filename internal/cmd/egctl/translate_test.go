@@ -294,6 +294,13 @@ func TestTranslate(t *testing.T) {
 			to:     "gateway-api",
 			expect: true,
 		},
+		{
+			name:      "oidc-namespace",
+			from:      "gateway-api",
+			to:        "gateway-api",
+			expect:    true,
+			extraArgs: []string{"--namespace", "custom-system"},
+		},
 	}
 
 	flag.Parse()
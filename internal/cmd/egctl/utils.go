@@ -11,8 +11,8 @@ import (
 	adminv3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
+	"github.com/envoyproxy/gateway/internal/cmd/options"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 )
 
@@ -72,7 +72,11 @@ func findXDSResourceFromConfigDump(resourceType envoyConfigType, globalConfigs *
 func newK8sClient() (client.Client, error) {
 	scheme := envoygateway.GetScheme()
 
-	cli, err := client.New(config.GetConfigOrDie(), client.Options{Scheme: scheme})
+	restConfig, err := options.DefaultConfigFlags.ToRESTConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubeconfig: %w", err)
+	}
+	cli, err := client.New(restConfig, client.Options{Scheme: scheme})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize Kubernetes client: %w", err)
 	}
@@ -7,33 +7,116 @@ package egctl
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/yaml"
 
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 )
 
+var (
+	// errValidationFailed is returned once the errors of an invalid set of resources are written,
+	// so that egctl exits with a non-zero code.
+	errValidationFailed = errors.New("validation failed")
+	// errValidationSkipped is returned once the reason the translation of the resources was
+	// skipped is written, if a GatewayClass is required to translate them.
+	errValidationSkipped = errors.New("validation incomplete")
+)
+
+// invalidConditionStatuses are the types of the conditions set by the translator that are
+// validated, with the status that makes a resource invalid.
+var invalidConditionStatuses = map[string]metav1.ConditionStatus{
+	string(gwapiv1.RouteConditionAccepted):      metav1.ConditionFalse,
+	string(gwapiv1.RouteConditionResolvedRefs):  metav1.ConditionFalse,
+	string(gwapiv1.ListenerConditionConflicted): metav1.ConditionTrue,
+}
+
+// validationResult is the result of the validation of a set of resources.
+type validationResult struct {
+	Valid bool `json:"valid"`
+	// Errors are the errors of the resources that failed to load, in which case the resources
+	// are not translated.
+	Errors []validationError `json:"errors,omitempty"`
+	// Conditions are the conditions that the translation set on the resources and that make
+	// them invalid.
+	Conditions []validationCondition `json:"conditions,omitempty"`
+	// TranslationError is the error of the translation of the xDS IR to xDS.
+	TranslationError string `json:"translationError,omitempty"`
+	// Skipped is the reason the translation was skipped, in which case the resources were only
+	// validated against their schemas.
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// validationError is the error of a resource that failed to load.
+type validationError struct {
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Error     string `json:"error"`
+
+	// document is the YAML document of the resource.
+	document []byte
+}
+
+// validationCondition is a condition of the status of a resource that makes it invalid.
+type validationCondition struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Parent is the parent Gateway or listener of a route, the Gateway listener, or the
+	// ancestor of a policy the condition is set for.
+	Parent  string                 `json:"parent,omitempty"`
+	Type    string                 `json:"type"`
+	Status  metav1.ConditionStatus `json:"status"`
+	Reason  string                 `json:"reason"`
+	Message string                 `json:"message,omitempty"`
+}
+
 func newValidateCommand() *cobra.Command {
-	var inFile string
+	var (
+		inFile, output      string
+		requireGatewayClass bool
+	)
 
 	validateCommand := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate Gateway API Resources from the given file, return all the errors if got any.",
+		Long: `Validate the Gateway API resources of a file against their schemas, then translate them and report the
+Accepted, ResolvedRefs and Conflicted conditions that Envoy Gateway would set and that make them invalid, such as a
+route referencing a missing Service or a policy targeting a missing route. The command fails if any resource is
+invalid. The resources without a GatewayClass are only validated against their schemas, unless
+--require-gateway-class is set, in which case the command fails.`,
 		Example: `  # Validate Gateway API Resources
   egctl x validate -f <input file>
+
+  # Validate Gateway API Resources in CI, with a JSON report
+  egctl x validate -f <input file> -o json
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(inFile) == 0 {
 				return fmt.Errorf("-f/--file must be specified")
 			}
 
-			return runValidate(cmd.OutOrStdout(), inFile)
+			return runValidate(cmd.OutOrStdout(), inFile, output, requireGatewayClass)
 		},
 	}
 
 	validateCommand.PersistentFlags().StringVarP(&inFile, "file", "f", "", "Location of input file.")
+	validateCommand.PersistentFlags().StringVarP(&output, "output", "o", textOutput, "One of 'text', 'yaml' or 'json'")
+	validateCommand.PersistentFlags().BoolVarP(&requireGatewayClass, "require-gateway-class", "", false,
+		"Fail if the resources have no GatewayClass, since they are only validated against their schemas then.")
 	if err := validateCommand.MarkPersistentFlagRequired("file"); err != nil {
 		return nil
 	}
@@ -41,31 +124,265 @@ func newValidateCommand() *cobra.Command {
 	return validateCommand
 }
 
-func runValidate(w io.Writer, inFile string) error {
+func runValidate(w io.Writer, inFile, output string, requireGatewayClass bool) error {
+	switch output {
+	case textOutput, yamlOutput, jsonOutput:
+	default:
+		return fmt.Errorf("invalid output %q, must be text, yaml or json", output)
+	}
+
 	inBytes, err := getInputBytes(inFile)
 	if err != nil {
 		return fmt.Errorf("unable to read input file: %w", err)
 	}
 
-	noErr := true
+	result, err := validateResources(inBytes)
+	if err != nil {
+		return err
+	}
+	if requireGatewayClass && result.Skipped != "" {
+		result.Valid = false
+	}
+	if err := writeValidationResult(w, result, output); err != nil {
+		return err
+	}
+	switch {
+	case requireGatewayClass && result.Skipped != "":
+		return errValidationSkipped
+	case !result.Valid:
+		return errValidationFailed
+	}
+	return nil
+}
+
+// validateResources validates each resource against its schema and, if they are all valid,
+// translates them and collects the conditions that make them invalid.
+func validateResources(inBytes []byte) (*validationResult, error) {
+	result := &validationResult{}
 	_ = resource.IterYAMLBytes(inBytes, func(yamlByte []byte) error {
 		// Passing each resource as YAML string and get all their errors from local validator.
-		_, err = resource.LoadResourcesFromYAMLBytes(yamlByte, false)
-		if err != nil {
-			noErr = false
-			yamlRows := bytes.Split(yamlByte, []byte("\n"))
-			if len(yamlRows) > 6 {
-				yamlRows = append(yamlRows[:6], []byte("..."))
+		if _, err := resource.LoadResourcesFromYAMLBytes(yamlByte, false); err != nil {
+			vErr := validationError{Error: err.Error(), document: yamlByte}
+			obj := metav1.PartialObjectMetadata{}
+			if yaml.Unmarshal(yamlByte, &obj) == nil {
+				vErr.Kind, vErr.Namespace, vErr.Name = obj.Kind, obj.Namespace, obj.Name
 			}
-			_, err = fmt.Fprintf(w, "%s\n%s\n\n",
-				bytes.Join(yamlRows, []byte("\n")), err.Error())
+			result.Errors = append(result.Errors, vErr)
 		}
 		return nil
 	})
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	resources, err := resource.LoadResourcesFromYAMLBytes(inBytes, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load resources: %w", err)
+	}
+	if resources.GatewayClass == nil {
+		result.Valid = true
+		result.Skipped = "the resources have no GatewayClass"
+		return result, nil
+	}
+	// The HMAC secret of OIDC is generated by Envoy Gateway, so it is not expected among the resources.
+	addOIDCHMACSecret(resources)
+
+	gRes, _, err := translateGatewayAPIToConfigDumps(config.DefaultNamespace, config.DefaultDNSDomain, false, resources)
+	if err != nil {
+		result.TranslationError = err.Error()
+		return result, nil
+	}
+	result.Conditions = invalidConditions(resources, gRes)
+	result.Valid = len(result.Conditions) == 0
+	return result, nil
+}
+
+// addOIDCHMACSecret adds the Secret of the HMAC key of the OIDC authentication to the resources
+// if they don't have it, since Envoy Gateway generates it in its namespace when it is installed.
+func addOIDCHMACSecret(resources *resource.Resources) {
+	if resources.GetSecret(config.DefaultNamespace, gatewayapi.OIDCHMACSecretName) != nil {
+		return
+	}
+	resources.Secrets = append(resources.Secrets, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: config.DefaultNamespace,
+			Name:      gatewayapi.OIDCHMACSecretName,
+		},
+		Data: map[string][]byte{gatewayapi.OIDCHMACSecretKey: []byte("egctl")},
+	})
+}
+
+// invalidConditions returns the conditions of the translated resources that make them invalid.
+// The routes that are not attached to any Gateway of the GatewayClass, and the policies that are
+// not attached to any of its Gateways or routes, have no status after the translation, so they
+// are reported with the condition their missing parents or targets would have caused.
+func invalidConditions(resources *resource.Resources, gRes *gatewayapi.TranslateResult) []validationCondition {
+	gatewayClass := resources.GatewayClass.Name
+	var conditions []validationCondition
+	add := func(kind string, obj metav1.Object, parent string, cs []metav1.Condition) {
+		for _, c := range cs {
+			if status, ok := invalidConditionStatuses[c.Type]; ok && c.Status == status {
+				conditions = append(conditions, validationCondition{
+					Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Parent: parent,
+					Type: c.Type, Status: c.Status, Reason: c.Reason, Message: c.Message,
+				})
+			}
+		}
+	}
+	// The routes that are not attached to any Gateway are left out of the translation result.
+	translatedRoutes := sets.New[string]()
+	addUnattachedRoute := func(kind string, obj metav1.Object) {
+		if translatedRoutes.Has(kind + "/" + obj.GetNamespace() + "/" + obj.GetName()) {
+			return
+		}
+		add(kind, obj, "", []metav1.Condition{{
+			Type:    string(gwapiv1.RouteConditionAccepted),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(gwapiv1.RouteReasonNoMatchingParent),
+			Message: fmt.Sprintf("None of the parentRefs is a Gateway of GatewayClass %s.", gatewayClass),
+		}})
+	}
+	addRoute := func(kind string, obj metav1.Object, parents []gwapiv1.RouteParentStatus) {
+		if len(parents) > 0 {
+			translatedRoutes.Insert(kind + "/" + obj.GetNamespace() + "/" + obj.GetName())
+		}
+		for _, parent := range parents {
+			add(kind, obj, parentRefString(parent.ParentRef, obj.GetNamespace()), parent.Conditions)
+		}
+	}
+	addPolicy := func(kind string, obj metav1.Object, ancestors []gwapiv1a2.PolicyAncestorStatus) {
+		if len(ancestors) == 0 {
+			add(kind, obj, "", []metav1.Condition{{
+				Type:    string(gwapiv1a2.PolicyConditionAccepted),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(gwapiv1a2.PolicyReasonTargetNotFound),
+				Message: fmt.Sprintf("None of the targets is a Gateway of GatewayClass %s or a route attached to it.", gatewayClass),
+			}})
+		}
+		for _, ancestor := range ancestors {
+			add(kind, obj, parentRefString(ancestor.AncestorRef, obj.GetNamespace()), ancestor.Conditions)
+		}
+	}
 
-	if noErr {
-		_, err = fmt.Fprintln(w, "\033[32mOK\033[0m")
+	for _, gw := range gRes.Gateways {
+		add(resource.KindGateway, gw, "", gw.Status.Conditions)
+		for _, listener := range gw.Status.Listeners {
+			add(resource.KindGateway, gw, "listener "+string(listener.Name), listener.Conditions)
+		}
+	}
+	for _, r := range gRes.HTTPRoutes {
+		addRoute(resource.KindHTTPRoute, r, r.Status.Parents)
+	}
+	for _, r := range gRes.GRPCRoutes {
+		addRoute(resource.KindGRPCRoute, r, r.Status.Parents)
+	}
+	for _, r := range gRes.TLSRoutes {
+		addRoute(resource.KindTLSRoute, r, r.Status.Parents)
+	}
+	for _, r := range gRes.TCPRoutes {
+		addRoute(resource.KindTCPRoute, r, r.Status.Parents)
+	}
+	for _, r := range gRes.UDPRoutes {
+		addRoute(resource.KindUDPRoute, r, r.Status.Parents)
+	}
+	for _, r := range resources.HTTPRoutes {
+		addUnattachedRoute(resource.KindHTTPRoute, r)
+	}
+	for _, r := range resources.GRPCRoutes {
+		addUnattachedRoute(resource.KindGRPCRoute, r)
+	}
+	for _, r := range resources.TLSRoutes {
+		addUnattachedRoute(resource.KindTLSRoute, r)
+	}
+	for _, r := range resources.TCPRoutes {
+		addUnattachedRoute(resource.KindTCPRoute, r)
+	}
+	for _, r := range resources.UDPRoutes {
+		addUnattachedRoute(resource.KindUDPRoute, r)
+	}
+	for _, p := range gRes.ClientTrafficPolicies {
+		addPolicy(resource.KindClientTrafficPolicy, p, p.Status.Ancestors)
+	}
+	for _, p := range gRes.BackendTrafficPolicies {
+		addPolicy(resource.KindBackendTrafficPolicy, p, p.Status.Ancestors)
+	}
+	for _, p := range gRes.SecurityPolicies {
+		addPolicy(resource.KindSecurityPolicy, p, p.Status.Ancestors)
+	}
+	for _, p := range gRes.EnvoyPatchPolicies {
+		addPolicy(resource.KindEnvoyPatchPolicy, p, p.Status.Ancestors)
+	}
+	for _, p := range gRes.EnvoyExtensionPolicies {
+		addPolicy(resource.KindEnvoyExtensionPolicy, p, p.Status.Ancestors)
+	}
+	for _, p := range gRes.BackendTLSPolicies {
+		addPolicy(resource.KindBackendTLSPolicy, p, p.Status.Ancestors)
 	}
+	for _, b := range gRes.Backends {
+		add(resource.KindBackend, b, "", b.Status.Conditions)
+	}
+	return conditions
+}
 
+// parentRefString returns the kind, namespace, name and section of a parent reference.
+func parentRefString(ref gwapiv1.ParentReference, namespace string) string {
+	kind := resource.KindGateway
+	if ref.Kind != nil {
+		kind = string(*ref.Kind)
+	}
+	if ref.Namespace != nil {
+		namespace = string(*ref.Namespace)
+	}
+	s := fmt.Sprintf("%s %s/%s", kind, namespace, ref.Name)
+	if ref.SectionName != nil {
+		s += " section " + string(*ref.SectionName)
+	}
+	return s
+}
+
+func writeValidationResult(w io.Writer, result *validationResult, output string) error {
+	if output != textOutput {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		if output == yamlOutput {
+			if data, err = yaml.JSONToYAML(data); err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	var sb strings.Builder
+	for _, vErr := range result.Errors {
+		yamlRows := bytes.Split(vErr.document, []byte("\n"))
+		if len(yamlRows) > 6 {
+			yamlRows = append(yamlRows[:6], []byte("..."))
+		}
+		fmt.Fprintf(&sb, "%s\n%s\n\n", bytes.Join(yamlRows, []byte("\n")), vErr.Error)
+	}
+	for _, c := range result.Conditions {
+		fmt.Fprintf(&sb, "%s %s/%s", c.Kind, c.Namespace, c.Name)
+		if c.Parent != "" {
+			fmt.Fprintf(&sb, " (%s)", c.Parent)
+		}
+		fmt.Fprintf(&sb, ": %s=%s %s", c.Type, c.Status, c.Reason)
+		if c.Message != "" {
+			fmt.Fprintf(&sb, ": %s", c.Message)
+		}
+		sb.WriteString("\n")
+	}
+	if result.TranslationError != "" {
+		fmt.Fprintf(&sb, "translation error: %s\n", result.TranslationError)
+	}
+	if result.Skipped != "" {
+		fmt.Fprintf(&sb, "The translation was skipped since %s.\n", result.Skipped)
+	}
+	if result.Valid {
+		sb.WriteString("\033[32mOK\033[0m\n")
+	}
+	_, err := io.WriteString(w, sb.String())
 	return err
 }
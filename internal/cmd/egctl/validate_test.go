@@ -8,20 +8,28 @@ package egctl
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"path"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 )
 
 func TestRunValidate(t *testing.T) {
 	testCases := []struct {
-		name   string
-		output string
+		name    string
+		file    string
+		args    []string
+		output  string
+		wantErr error
 	}{
 		{
-			name: "invalid-resources",
+			name:    "invalid-resources",
+			wantErr: errValidationFailed,
 			output: `apiVersion: gateway.networking.k8s.io/v1
 kind: Gateway
 metadata:
@@ -69,22 +77,61 @@ local validation error: Backend.gateway.envoyproxy.io "backend-2" is invalid: sp
 
 `,
 		},
+		{
+			name:    "invalid-conditions",
+			wantErr: errValidationFailed,
+			output: `HTTPRoute default/backend (Gateway default/eg): ResolvedRefs=False BackendNotFound: Service default/missing not found
+HTTPRoute default/orphan: Accepted=False NoMatchingParent: None of the parentRefs is a Gateway of GatewayClass eg.
+SecurityPolicy default/cors: Accepted=False TargetNotFound: None of the targets is a Gateway of GatewayClass eg or a route attached to it.
+`,
+		},
+		{
+			name:   "valid-resources",
+			output: "\033[32mOK\033[0m\n",
+		},
+		{
+			// The HMAC secret of OIDC is generated by Envoy Gateway, so it is not expected among the resources.
+			name:   "valid-oidc",
+			output: "\033[32mOK\033[0m\n",
+		},
+		{
+			name:   "no-gatewayclass",
+			output: "The translation was skipped since the resources have no GatewayClass.\n\033[32mOK\033[0m\n",
+		},
+		{
+			name:    "no-gatewayclass-required",
+			file:    "no-gatewayclass",
+			args:    []string{"--require-gateway-class"},
+			wantErr: errValidationSkipped,
+			output:  "The translation was skipped since the resources have no GatewayClass.\n",
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			b := bytes.NewBufferString("")
 			root := newValidateCommand()
+			// As egctl does, only output the result of the validation when it fails.
+			root.SilenceUsage = true
 			root.SetOut(b)
-			root.SetErr(b)
+			root.SetErr(io.Discard)
+			file := tc.file
+			if file == "" {
+				file = tc.name
+			}
 			args := []string{
 				"--file",
-				path.Join("testdata", "validate", tc.name+".yaml"),
+				path.Join("testdata", "validate", file+".yaml"),
 			}
+			args = append(args, tc.args...)
 
 			root.SetArgs(args)
 			err := root.ExecuteContext(context.Background())
-			require.NoError(t, err)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
 
 			out, err := io.ReadAll(b)
 			require.NoError(t, err)
@@ -92,3 +139,50 @@ local validation error: Backend.gateway.envoyproxy.io "backend-2" is invalid: sp
 		})
 	}
 }
+
+func TestRunValidateJSON(t *testing.T) {
+	b := bytes.NewBufferString("")
+	err := runValidate(b, path.Join("testdata", "validate", "invalid-conditions.yaml"), jsonOutput, false)
+	require.ErrorIs(t, err, errValidationFailed)
+
+	result := validationResult{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+	require.False(t, result.Valid)
+	require.Empty(t, result.Errors)
+	require.Len(t, result.Conditions, 3)
+	require.Equal(t, validationCondition{
+		Kind:      resource.KindHTTPRoute,
+		Namespace: "default",
+		Name:      "backend",
+		Parent:    "Gateway default/eg",
+		Type:      "ResolvedRefs",
+		Status:    metav1.ConditionFalse,
+		Reason:    "BackendNotFound",
+		Message:   "Service default/missing not found",
+	}, result.Conditions[0])
+
+	b.Reset()
+	err = runValidate(b, path.Join("testdata", "validate", "invalid-resources.yaml"), jsonOutput, false)
+	require.ErrorIs(t, err, errValidationFailed)
+	result = validationResult{}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+	require.Len(t, result.Errors, 5)
+	require.Equal(t, "Gateway", result.Errors[0].Kind)
+	require.Equal(t, "eg1", result.Errors[0].Name)
+
+	// The resources without a GatewayClass are only validated against their schemas, and are
+	// not valid if a GatewayClass is required.
+	for _, requireGatewayClass := range []bool{false, true} {
+		b.Reset()
+		err = runValidate(b, path.Join("testdata", "validate", "no-gatewayclass.yaml"), jsonOutput, requireGatewayClass)
+		if requireGatewayClass {
+			require.ErrorIs(t, err, errValidationSkipped)
+		} else {
+			require.NoError(t, err)
+		}
+		result = validationResult{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &result))
+		require.Equal(t, !requireGatewayClass, result.Valid)
+		require.Equal(t, "the resources have no GatewayClass", result.Skipped)
+	}
+}
@@ -22,6 +22,7 @@ func GetEnvoyCommand() *cobra.Command {
 
 	cmd.AddCommand(getShutdownCommand())
 	cmd.AddCommand(getShutdownManagerCommand())
+	cmd.AddCommand(getRunCommand())
 
 	return cmd
 }
@@ -69,3 +70,23 @@ func getShutdownManagerCommand() *cobra.Command {
 
 	return cmd
 }
+
+// getRunCommand returns the run cobra command to be executed, the arguments after "--" are passed to Envoy.
+func getRunCommand() *cobra.Command {
+	var homeDir string
+
+	cmd := &cobra.Command{
+		Use:    "run [flags] -- [envoy args]",
+		Short:  "Run Envoy via func-e, used by the host infrastructure provider to capture the output of Envoy.",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return envoy.Run(homeDir, args)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&homeDir, "home-dir", "",
+		"Home directory of func-e, where Envoy is installed.")
+	_ = cmd.MarkPersistentFlagRequired("home-dir")
+
+	return cmd
+}
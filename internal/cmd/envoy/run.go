@@ -0,0 +1,31 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package envoy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	funcE "github.com/tetratelabs/func-e/api"
+)
+
+// Run runs Envoy via func-e with the given arguments, installing it under the home directory
+// if needed. Both func-e and Envoy write to the stdout and stderr of the current process, which
+// are captured by the host infrastructure provider. It blocks until Envoy exits, or shuts it down
+// when the current process is interrupted or terminated.
+func Run(homeDir string, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := funcE.Run(ctx, args, funcE.HomeDir(homeDir), funcE.Out(os.Stdout))
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
@@ -15,3 +15,9 @@ func TestGetEnvoyCommand(t *testing.T) {
 	got := GetEnvoyCommand()
 	assert.Equal(t, "envoy", got.Use)
 }
+
+func TestGetRunCommand(t *testing.T) {
+	got := getRunCommand()
+	assert.True(t, got.Hidden)
+	assert.NotNil(t, got.PersistentFlags().Lookup("home-dir"))
+}
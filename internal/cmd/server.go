@@ -8,22 +8,27 @@ package cmd
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/spf13/cobra"
 
-	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/admin"
+	adminhistory "github.com/envoyproxy/gateway/internal/admin/history"
+	adminintrospection "github.com/envoyproxy/gateway/internal/admin/introspection"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config/loader"
 	extensionregistry "github.com/envoyproxy/gateway/internal/extension/registry"
 	"github.com/envoyproxy/gateway/internal/extension/types"
 	gatewayapirunner "github.com/envoyproxy/gateway/internal/gatewayapi/runner"
 	ratelimitrunner "github.com/envoyproxy/gateway/internal/globalratelimit/runner"
+	"github.com/envoyproxy/gateway/internal/infrastructure/host"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/ratelimit"
 	infrarunner "github.com/envoyproxy/gateway/internal/infrastructure/runner"
 	"github.com/envoyproxy/gateway/internal/logging"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/metrics"
 	providerrunner "github.com/envoyproxy/gateway/internal/provider/runner"
+	"github.com/envoyproxy/gateway/internal/tracing"
 	xdsserverrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
 	xdstranslatorrunner "github.com/envoyproxy/gateway/internal/xds/translator/runner"
 )
@@ -39,6 +44,10 @@ type Runner interface {
 // cfgPath is the path to the EnvoyGateway configuration file.
 var cfgPath string
 
+// tracingShutdownTimeout is the timeout of exporting the remaining spans of the translation
+// pipeline when the runners are closed.
+const tracingShutdownTimeout = 5 * time.Second
+
 // GetServerCommand returns the server cobra command to be executed.
 func GetServerCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -159,12 +168,24 @@ func startRunners(ctx context.Context, cfg *config.Server) (err error) {
 	// It will be closed once the leader is elected in the controller manager.
 	cfg.Elected = make(chan struct{})
 
+	// Init eg tracing of the translation pipeline, it's initialized again along with the runners
+	// when the configuration is reloaded.
+	shutdownTracing, err := tracing.Init(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			cfg.Logger.Error(err, "failed to shut down tracing")
+		}
+	}()
+
 	// Setup the Extension Manager
 	var extMgr types.Manager
-	if cfg.EnvoyGateway.Provider.Type == egv1a1.ProviderTypeKubernetes {
-		if extMgr, err = extensionregistry.NewManager(cfg); err != nil {
-			return err
-		}
+	if extMgr, err = extensionregistry.NewManager(cfg); err != nil {
+		return err
 	}
 
 	runners := []struct {
@@ -198,11 +219,12 @@ func startRunners(ctx context.Context, cfg *config.Server) (err error) {
 			// It subscribes to the xdsIR, translates it into xds Resources and publishes it.
 			// It also computes the EnvoyPatchPolicy statuses and publishes it.
 			runner: xdstranslatorrunner.New(&xdstranslatorrunner.Config{
-				Server:            *cfg,
-				XdsIR:             channels.xdsIR,
-				Xds:               channels.xds,
-				ExtensionManager:  extMgr,
-				ProviderResources: channels.pResources,
+				Server:              *cfg,
+				XdsIR:               channels.xdsIR,
+				Xds:                 channels.xds,
+				ExtensionManager:    extMgr,
+				ProviderResources:   channels.pResources,
+				RateLimitServiceURL: rateLimitServiceURL(cfg),
 			}),
 		},
 		{
@@ -218,11 +240,34 @@ func startRunners(ctx context.Context, cfg *config.Server) (err error) {
 			// Start the xDS Server
 			// It subscribes to the xds Resources and configures the remote Envoy Proxy
 			// via the xDS Protocol.
+			// It also publishes the xDS updates rejected by the proxies.
 			runner: xdsserverrunner.New(&xdsserverrunner.Config{
+				Server:            *cfg,
+				Xds:               channels.xds,
+				ProviderResources: channels.pResources,
+			}),
+		},
+		{
+			// Start the Admin History Runner
+			// It subscribes to the xdsIR and the xds Resources, and retains their
+			// last revisions, which are served by the admin server.
+			runner: adminhistory.New(&adminhistory.Config{
 				Server: *cfg,
+				XdsIR:  channels.xdsIR,
 				Xds:    channels.xds,
 			}),
 		},
+		{
+			// Start the Admin Introspection Runner
+			// It serves the current provider resources, xdsIR, infraIR and statuses
+			// on the admin server.
+			runner: adminintrospection.New(&adminintrospection.Config{
+				Server:            *cfg,
+				ProviderResources: channels.pResources,
+				XdsIR:             channels.xdsIR,
+				InfraIR:           channels.infraIR,
+			}),
+		},
 	}
 
 	// Start all runners
@@ -283,3 +328,16 @@ func startRunner(ctx context.Context, cfg *config.Server, runner Runner) error {
 	}
 	return nil
 }
+
+// rateLimitServiceURL returns the URL of the global rate limit service deployed by the
+// infrastructure provider, or an empty string if global rate limiting is disabled.
+func rateLimitServiceURL(cfg *config.Server) string {
+	if cfg.EnvoyGateway.RateLimit == nil {
+		return ""
+	}
+	// The rate limit service is run next to the proxies on the host.
+	if cfg.EnvoyGateway.Provider != nil && cfg.EnvoyGateway.Provider.IsRunningOnHost() {
+		return host.GetRateLimitServiceURL(cfg.EnvoyGateway.Provider.GetHostInfrastructureProvider())
+	}
+	return ratelimit.GetServiceURL(cfg.Namespace, cfg.DNSDomain)
+}
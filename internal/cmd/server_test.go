@@ -11,6 +11,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 )
 
 var (
@@ -71,3 +74,49 @@ func TestGetConfigValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRateLimitServiceURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		provider  *egv1a1.EnvoyGatewayProvider
+		rateLimit *egv1a1.RateLimit
+		expected  string
+	}{
+		{
+			name:     "rate limit disabled",
+			provider: egv1a1.DefaultEnvoyGatewayProvider(),
+			expected: "",
+		},
+		{
+			name:      "kubernetes provider",
+			provider:  egv1a1.DefaultEnvoyGatewayProvider(),
+			rateLimit: &egv1a1.RateLimit{},
+			expected:  "grpc://envoy-ratelimit.envoy-gateway-system.svc.cluster.local:8081",
+		},
+		{
+			name: "host infrastructure provider",
+			provider: &egv1a1.EnvoyGatewayProvider{
+				Type: egv1a1.ProviderTypeCustom,
+				Custom: &egv1a1.EnvoyGatewayCustomProvider{
+					Resource: egv1a1.EnvoyGatewayResourceProvider{Type: egv1a1.ResourceProviderTypeFile},
+					Infrastructure: &egv1a1.EnvoyGatewayInfrastructureProvider{
+						Type: egv1a1.InfrastructureProviderTypeHost,
+						Host: &egv1a1.EnvoyGatewayHostInfrastructureProvider{},
+					},
+				},
+			},
+			rateLimit: &egv1a1.RateLimit{},
+			expected:  "grpc://127.0.0.1:8081",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := config.New(os.Stdout)
+			require.NoError(t, err)
+			cfg.EnvoyGateway.Provider = test.provider
+			cfg.EnvoyGateway.RateLimit = test.rateLimit
+
+			require.Equal(t, test.expected, rateLimitServiceURL(cfg))
+		})
+	}
+}
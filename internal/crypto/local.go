@@ -0,0 +1,59 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package crypto
+
+import (
+	"path/filepath"
+)
+
+// The layout of the certificates generated locally, each component has its own
+// sub-directory holding the CA certificate, the certificate and the private key.
+const (
+	// LocalEnvoyGatewayDir is the sub-directory of the Envoy Gateway certificates.
+	LocalEnvoyGatewayDir = "envoy-gateway"
+	// LocalEnvoyDir is the sub-directory of the Envoy certificates.
+	LocalEnvoyDir = "envoy"
+	// LocalEnvoyRateLimitDir is the sub-directory of the rate limit service certificates.
+	LocalEnvoyRateLimitDir = "envoy-rate-limit"
+	// LocalOIDCHMACDir is the sub-directory of the OIDC HMAC secret.
+	LocalOIDCHMACDir = "envoy-oidc-hmac"
+
+	// LocalCACertFilename is the name of the CA certificate file.
+	LocalCACertFilename = "ca.crt"
+	// LocalTLSCertFilename is the name of the certificate file.
+	LocalTLSCertFilename = "tls.crt"
+	// LocalTLSKeyFilename is the name of the private key file.
+	LocalTLSKeyFilename = "tls.key"
+	// LocalOIDCHMACSecretFilename is the name of the OIDC HMAC secret file.
+	LocalOIDCHMACSecretFilename = "hmac-secret" // nolint: gosec
+)
+
+// LocalCertPaths holds the paths of the certificate files of a component.
+type LocalCertPaths struct {
+	// TLSCert is the path of the certificate.
+	TLSCert string
+	// TLSKey is the path of the private key.
+	TLSKey string
+	// CACert is the path of the CA certificate.
+	CACert string
+}
+
+// GetLocalCertPaths returns the paths of the certificate files of the component
+// under the given certificates directory.
+func GetLocalCertPaths(certsDir, component string) LocalCertPaths {
+	dir := filepath.Join(certsDir, component)
+	return LocalCertPaths{
+		TLSCert: filepath.Join(dir, LocalTLSCertFilename),
+		TLSKey:  filepath.Join(dir, LocalTLSKeyFilename),
+		CACert:  filepath.Join(dir, LocalCACertFilename),
+	}
+}
+
+// GetLocalOIDCHMACSecretPath returns the path of the OIDC HMAC secret under
+// the given certificates directory.
+func GetLocalOIDCHMACSecretPath(certsDir string) string {
+	return filepath.Join(certsDir, LocalOIDCHMACDir, LocalOIDCHMACSecretFilename)
+}
@@ -7,11 +7,14 @@ package registry
 
 import (
 	"context"
+	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"fmt"
 	"math"
 	"net"
+	"os"
+	"slices"
 	"strconv"
 
 	"google.golang.org/grpc"
@@ -19,6 +22,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/test/bufconn"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8scli "sigs.k8s.io/controller-runtime/pkg/client"
 	k8sclicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -47,33 +51,41 @@ const grpcServiceConfig = `{
 var _ extTypes.Manager = (*Manager)(nil)
 
 type Manager struct {
-	k8sClient          k8scli.Client
-	namespace          string
-	extension          egv1a1.ExtensionManager
+	k8sClient k8scli.Client
+	namespace string
+	// extensions are the registered extensions, in the order their hooks are invoked.
+	extensions []*registeredExtension
+}
+
+// registeredExtension is an extension registered to the Manager.
+type registeredExtension struct {
+	config             egv1a1.ExtensionManager
 	extensionConnCache *grpc.ClientConn
 }
 
 // NewManager returns a new Manager
 func NewManager(cfg *config.Server) (extTypes.Manager, error) {
-	cli, err := k8scli.New(k8sclicfg.GetConfigOrDie(), k8scli.Options{Scheme: envoygateway.GetScheme()})
-	if err != nil {
-		return nil, err
-	}
-
-	var extension *egv1a1.ExtensionManager
-	if cfg.EnvoyGateway != nil {
-		extension = cfg.EnvoyGateway.ExtensionManager
+	// The Kubernetes client is only used to resolve the CA certificate Secret, so it's
+	// not created for the other providers which load the certificates from files.
+	var cli k8scli.Client
+	if cfg.EnvoyGateway != nil && cfg.EnvoyGateway.Provider != nil &&
+		cfg.EnvoyGateway.Provider.Type == egv1a1.ProviderTypeKubernetes {
+		var err error
+		if cli, err = k8scli.New(k8sclicfg.GetConfigOrDie(), k8scli.Options{Scheme: envoygateway.GetScheme()}); err != nil {
+			return nil, err
+		}
 	}
 
-	// Setup an empty default in the case that no config was provided
-	if extension == nil {
-		extension = &egv1a1.ExtensionManager{}
+	// The Manager has no extensions in the case that no config was provided
+	var extensions []*registeredExtension
+	for _, ext := range cfg.EnvoyGateway.GetExtensionManagers() {
+		extensions = append(extensions, &registeredExtension{config: *ext})
 	}
 
 	return &Manager{
-		k8sClient: cli,
-		namespace: cfg.Namespace,
-		extension: *extension,
+		k8sClient:  cli,
+		namespace:  cfg.Namespace,
+		extensions: extensions,
 	}, nil
 }
 
@@ -82,6 +94,21 @@ func NewInMemoryManager(cfg egv1a1.ExtensionManager, server extension.EnvoyGatew
 		return nil, nil, fmt.Errorf("in-memory manager must be passed a server")
 	}
 
+	conn, c, err := newInMemoryConn(server)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &Manager{
+		extensions: []*registeredExtension{{
+			config:             cfg,
+			extensionConnCache: conn,
+		}},
+	}, c, nil
+}
+
+// newInMemoryConn serves the extension server in memory, and returns a connection to it.
+func newInMemoryConn(server extension.EnvoyGatewayExtensionServer) (*grpc.ClientConn, func(), error) {
 	buffer := 10 * 1024 * 1024
 	lis := bufconn.Listen(buffer)
 
@@ -101,26 +128,31 @@ func NewInMemoryManager(cfg egv1a1.ExtensionManager, server extension.EnvoyGatew
 		lis.Close()
 		baseServer.Stop()
 	}
-
-	return &Manager{
-		extensionConnCache: conn,
-		extension:          cfg,
-	}, c, nil
+	return conn, c, nil
 }
 
-// FailOpen returns true if the extension manager is configured to fail open, and false otherwise.
+// FailOpen returns true if all the registered extensions are configured to fail open, and false otherwise.
 func (m *Manager) FailOpen() bool {
-	return m.extension.FailOpen
+	if len(m.extensions) == 0 {
+		return false
+	}
+	for _, ext := range m.extensions {
+		if !ext.config.FailOpen {
+			return false
+		}
+	}
+	return true
 }
 
 // HasExtension checks to see whether a given Group and Kind has an
 // associated extension registered for it.
 func (m *Manager) HasExtension(g gwapiv1.Group, k gwapiv1.Kind) bool {
-	extension := m.extension
-	// TODO: not currently checking the version since extensionRef only supports group and kind.
-	for _, gvk := range extension.Resources {
-		if g == gwapiv1.Group(gvk.Group) && k == gwapiv1.Kind(gvk.Kind) {
-			return true
+	for _, ext := range m.extensions {
+		// TODO: not currently checking the version since extensionRef only supports group and kind.
+		for _, gvk := range ext.config.Resources {
+			if g == gwapiv1.Group(gvk.Group) && k == gwapiv1.Kind(gvk.Kind) {
+				return true
+			}
 		}
 	}
 	return false
@@ -141,85 +173,72 @@ func getExtensionServerAddress(service *egv1a1.ExtensionService) string {
 	return serverAddr
 }
 
-// GetPreXDSHookClient checks if the registered extension makes use of a particular hook type that modifies inputs
+// GetPreXDSHookClient checks if the registered extensions make use of a particular hook type that modifies inputs
 // that are used to generate an xDS resource.
-// If the extension makes use of the hook then the XDS Hook Client is returned. If it does not support
-// the hook type then nil is returned
+// If any extension makes use of the hook then the XDS Hook Client is returned, which invokes the hook of the
+// extensions in order. If none of them supports the hook type then nil is returned
 func (m *Manager) GetPreXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) (extTypes.XDSHookClient, error) {
-	ctx := context.Background()
-	ext := m.extension
-
-	if ext.Hooks == nil {
-		return nil, nil
-	}
-	if ext.Hooks.XDSTranslator == nil {
-		return nil, nil
-	}
+	return m.getXDSHookClient(xdsHookType, func(hooks *egv1a1.XDSTranslatorHooks) []egv1a1.XDSTranslatorHook {
+		return hooks.Pre
+	})
+}
 
-	hookUsed := false
-	for _, hook := range ext.Hooks.XDSTranslator.Pre {
-		if xdsHookType == hook {
-			hookUsed = true
-			break
-		}
-	}
-	if !hookUsed {
-		return nil, nil
-	}
+// GetPostXDSHookClient checks if the registered extensions make use of a particular hook type that modifies
+// xDS resources after they are generated by Envoy Gateway.
+// If any extension makes use of the hook then the XDS Hook Client is returned, which invokes the hook of the
+// extensions in order. If none of them supports the hook type then nil is returned
+func (m *Manager) GetPostXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) (extTypes.XDSHookClient, error) {
+	return m.getXDSHookClient(xdsHookType, func(hooks *egv1a1.XDSTranslatorHooks) []egv1a1.XDSTranslatorHook {
+		return hooks.Post
+	})
+}
 
-	if m.extensionConnCache == nil {
-		serverAddr := getExtensionServerAddress(ext.Service)
+func (m *Manager) getXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook,
+	hooksOf func(*egv1a1.XDSTranslatorHooks) []egv1a1.XDSTranslatorHook,
+) (extTypes.XDSHookClient, error) {
+	ctx := context.Background()
+	chain := &XDSHookChain{}
 
-		opts, err := setupGRPCOpts(ctx, m.k8sClient, &ext, m.namespace)
-		if err != nil {
-			return nil, err
+	for _, ext := range m.extensions {
+		if ext.config.Hooks == nil || ext.config.Hooks.XDSTranslator == nil {
+			continue
+		}
+		if !slices.Contains(hooksOf(ext.config.Hooks.XDSTranslator), xdsHookType) {
+			continue
 		}
 
-		conn, err := grpc.Dial(serverAddr, opts...)
+		conn, err := m.getConn(ctx, ext)
 		if err != nil {
-			return nil, err
+			err = ext.wrapError(err)
+			if !ext.config.FailOpen {
+				return nil, err
+			}
+			// The extensions configured to fail open are skipped if they can't be set up.
+			chain.setupErr = errors.Join(chain.setupErr, err)
+			continue
 		}
 
-		m.extensionConnCache = conn
+		chain.hooks = append(chain.hooks, &chainedXDSHook{
+			XDSHook:   &XDSHook{grpcClient: extension.NewEnvoyGatewayExtensionClient(conn)},
+			extension: ext,
+		})
 	}
 
-	client := extension.NewEnvoyGatewayExtensionClient(m.extensionConnCache)
-	xdsHookClient := &XDSHook{
-		grpcClient: client,
-	}
-	return xdsHookClient, nil
-}
-
-// GetPostXDSHookClient checks if the registered extension makes use of a particular hook type that modifies
-// xDS resources after they are generated by Envoy Gateway.
-// If the extension makes use of the hook then the XDS Hook Client is returned. If it does not support
-// the hook type then nil is returned
-func (m *Manager) GetPostXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) (extTypes.XDSHookClient, error) {
-	ctx := context.Background()
-	ext := m.extension
-
-	if ext.Hooks == nil {
-		return nil, nil
-	}
-	if ext.Hooks.XDSTranslator == nil {
-		return nil, nil
-	}
-
-	hookUsed := false
-	for _, hook := range ext.Hooks.XDSTranslator.Post {
-		if xdsHookType == hook {
-			hookUsed = true
-			break
+	if len(chain.hooks) == 0 {
+		if chain.setupErr != nil {
+			return nil, &extTypes.FailOpenError{Err: chain.setupErr}
 		}
-	}
-	if !hookUsed {
 		return nil, nil
 	}
+	return chain, nil
+}
 
-	if m.extensionConnCache == nil {
-		serverAddr := getExtensionServerAddress(ext.Service)
+// getConn returns the connection to the extension server, which is created on the first use.
+func (m *Manager) getConn(ctx context.Context, ext *registeredExtension) (*grpc.ClientConn, error) {
+	if ext.extensionConnCache == nil {
+		serverAddr := getExtensionServerAddress(ext.config.Service)
 
-		opts, err := setupGRPCOpts(ctx, m.k8sClient, &ext, m.namespace)
+		opts, err := setupGRPCOpts(ctx, m.k8sClient, &ext.config, m.namespace)
 		if err != nil {
 			return nil, err
 		}
@@ -229,20 +248,43 @@ func (m *Manager) GetPostXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) (ex
 			return nil, err
 		}
 
-		m.extensionConnCache = conn
+		ext.extensionConnCache = conn
 	}
+	return ext.extensionConnCache, nil
+}
 
-	client := extension.NewEnvoyGatewayExtensionClient(m.extensionConnCache)
-	xdsHookClient := &XDSHook{
-		grpcClient: client,
+func (m *Manager) CleanupHookConns() {
+	for _, ext := range m.extensions {
+		if ext.extensionConnCache != nil {
+			ext.extensionConnCache.Close()
+		}
 	}
-	return xdsHookClient, nil
 }
 
-func (m *Manager) CleanupHookConns() {
-	if m.extensionConnCache != nil {
-		m.extensionConnCache.Close()
+// wrapError adds the name of the extension to the error, if it's named.
+func (e *registeredExtension) wrapError(err error) error {
+	if e.config.Name == "" {
+		return err
+	}
+	return fmt.Errorf("extension %s: %w", e.config.Name, err)
+}
+
+// ownedResources returns the resources handled by the extension, either as route filters or policies.
+func (e *registeredExtension) ownedResources(resources []*unstructured.Unstructured) []*unstructured.Unstructured {
+	var ret []*unstructured.Unstructured
+	for _, res := range resources {
+		if res == nil {
+			continue
+		}
+		gvk := res.GroupVersionKind()
+		owned := func(r egv1a1.GroupVersionKind) bool {
+			return r.Group == gvk.Group && r.Kind == gvk.Kind
+		}
+		if slices.ContainsFunc(e.config.Resources, owned) || slices.ContainsFunc(e.config.PolicyResources, owned) {
+			ret = append(ret, res)
+		}
 	}
+	return ret
 }
 
 func parseCA(caSecret *corev1.Secret) (*x509.CertPool, error) {
@@ -250,6 +292,10 @@ func parseCA(caSecret *corev1.Secret) (*x509.CertPool, error) {
 	if !ok {
 		return nil, errors.New("no cert found in CA secret")
 	}
+	return parseCAPEM(caCertPEMBytes)
+}
+
+func parseCAPEM(caCertPEMBytes []byte) (*x509.CertPool, error) {
 	cp := x509.NewCertPool()
 	if ok := cp.AppendCertsFromPEM(caCertPEMBytes); !ok {
 		return nil, errors.New("failed to append certificates")
@@ -257,6 +303,38 @@ func parseCA(caSecret *corev1.Secret) (*x509.CertPool, error) {
 	return cp, nil
 }
 
+// loadCA loads the CA certificate used to verify the extension server, either from
+// the configured file or from the referenced Kubernetes Secret.
+func loadCA(ctx context.Context, client k8scli.Client, tlsConfig *egv1a1.ExtensionTLS, namespace string) (*x509.CertPool, error) {
+	if tlsConfig.CACertFile != nil {
+		caCertPEMBytes, err := os.ReadFile(*tlsConfig.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate file %s: %w", *tlsConfig.CACertFile, err)
+		}
+		cp, err := parseCAPEM(caCertPEMBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing cert in file %s", *tlsConfig.CACertFile)
+		}
+		return cp, nil
+	}
+
+	if client == nil {
+		return nil, errors.New("the extension server CA certificate can only be referenced as a Secret on Kubernetes, use caCertFile instead")
+	}
+
+	certRef := tlsConfig.CertificateRef
+	secret, secretNamespace, err := kubernetes.ValidateSecretObjectReference(ctx, client, &certRef, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	cp, err := parseCA(secret)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cert in Secret %s in namespace %s", string(certRef.Name), secretNamespace)
+	}
+	return cp, nil
+}
+
 func setupGRPCOpts(ctx context.Context, client k8scli.Client, ext *egv1a1.ExtensionManager, namespace string) ([]grpc.DialOption, error) {
 	// These two errors shouldn't happen since we check these conditions when loading the extension
 	if ext == nil {
@@ -269,18 +347,24 @@ func setupGRPCOpts(ctx context.Context, client k8scli.Client, ext *egv1a1.Extens
 	var opts []grpc.DialOption
 	var creds credentials.TransportCredentials
 	if ext.Service.TLS != nil {
-		certRef := ext.Service.TLS.CertificateRef
-		secret, secretNamespace, err := kubernetes.ValidateSecretObjectReference(ctx, client, &certRef, namespace)
+		cp, err := loadCA(ctx, client, ext.Service.TLS, namespace)
 		if err != nil {
 			return nil, err
 		}
 
-		cp, err := parseCA(secret)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing cert in Secret %s in namespace %s", string(certRef.Name), secretNamespace)
+		tlsConfig := &tls.Config{
+			RootCAs:    cp,
+			MinVersion: tls.VersionTLS12,
+		}
+		if ext.Service.TLS.ClientCertFile != nil && ext.Service.TLS.ClientKeyFile != nil {
+			cert, err := tls.LoadX509KeyPair(*ext.Service.TLS.ClientCertFile, *ext.Service.TLS.ClientKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
 		}
 
-		creds = credentials.NewClientTLSFromCert(cp, "")
+		creds = credentials.NewTLS(tlsConfig)
 		opts = append(opts, grpc.WithTransportCredentials(creds))
 	} else {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
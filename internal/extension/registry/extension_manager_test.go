@@ -7,18 +7,36 @@ package registry
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"math"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/ptr"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/proto/extension"
 )
 
 func TestGetExtensionServerAddress(t *testing.T) {
@@ -150,3 +168,178 @@ func Test_setupGRPCOpts(t *testing.T) {
 		})
 	}
 }
+
+// writeTestCert writes a certificate and its key signed by the given parent into dir,
+// a self-signed CA certificate is written if parent is nil.
+func writeTestCert(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".crt"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".key"),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return cert, key
+}
+
+func TestManagerWithCustomProvider(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := writeTestCert(t, dir, "ca", nil, nil)
+	writeTestCert(t, dir, "server", ca, caKey)
+	writeTestCert(t, dir, "client", ca, caKey)
+
+	// Start an extension server which requires client certificates signed by the CA.
+	serverCert, err := tls.LoadX509KeyPair(filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key"))
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})))
+	extension.RegisterEnvoyGatewayExtensionServer(server, &extension.UnimplementedEnvoyGatewayExtensionServer{})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.Stop()
+
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+	cfg.EnvoyGateway.Provider = &egv1a1.EnvoyGatewayProvider{
+		Type: egv1a1.ProviderTypeCustom,
+		Custom: &egv1a1.EnvoyGatewayCustomProvider{
+			Resource: egv1a1.EnvoyGatewayResourceProvider{
+				Type: egv1a1.ResourceProviderTypeFile,
+			},
+		},
+	}
+	cfg.EnvoyGateway.ExtensionManager = &egv1a1.ExtensionManager{
+		Hooks: &egv1a1.ExtensionHooks{
+			XDSTranslator: &egv1a1.XDSTranslatorHooks{
+				Post: []egv1a1.XDSTranslatorHook{egv1a1.XDSRoute},
+			},
+		},
+		Service: &egv1a1.ExtensionService{
+			BackendEndpoint: egv1a1.BackendEndpoint{
+				IP: &egv1a1.IPEndpoint{
+					Address: "127.0.0.1",
+					Port:    int32(lis.Addr().(*net.TCPAddr).Port), // nolint: gosec
+				},
+			},
+			TLS: &egv1a1.ExtensionTLS{
+				CACertFile:     ptr.To(filepath.Join(dir, "ca.crt")),
+				ClientCertFile: ptr.To(filepath.Join(dir, "client.crt")),
+				ClientKeyFile:  ptr.To(filepath.Join(dir, "client.key")),
+			},
+		},
+	}
+
+	mgr, err := NewManager(cfg)
+	require.NoError(t, err)
+	defer mgr.(*Manager).CleanupHookConns()
+
+	client, err := mgr.GetPostXDSHookClient(egv1a1.XDSRoute)
+	require.NoError(t, err)
+	require.NotNil(t, client)
+
+	// The extension server is reached over mTLS, and it doesn't implement the hook.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = extension.NewEnvoyGatewayExtensionClient(mgr.(*Manager).extensions[0].extensionConnCache).
+		PostRouteModify(ctx, &extension.PostRouteModifyRequest{Route: &routev3.Route{Name: "foo"}})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestSetupGRPCOptsFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := writeTestCert(t, dir, "ca", nil, nil)
+	writeTestCert(t, dir, "client", ca, caKey)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.crt"), []byte("invalid"), 0o600))
+
+	tests := []struct {
+		name    string
+		tls     *egv1a1.ExtensionTLS
+		wantErr string
+	}{
+		{
+			name: "CA certificate file",
+			tls:  &egv1a1.ExtensionTLS{CACertFile: ptr.To(filepath.Join(dir, "ca.crt"))},
+		},
+		{
+			name: "CA and client certificate files",
+			tls: &egv1a1.ExtensionTLS{
+				CACertFile:     ptr.To(filepath.Join(dir, "ca.crt")),
+				ClientCertFile: ptr.To(filepath.Join(dir, "client.crt")),
+				ClientKeyFile:  ptr.To(filepath.Join(dir, "client.key")),
+			},
+		},
+		{
+			name:    "missing CA certificate file",
+			tls:     &egv1a1.ExtensionTLS{CACertFile: ptr.To(filepath.Join(dir, "missing.crt"))},
+			wantErr: "failed to read CA certificate file",
+		},
+		{
+			name:    "invalid CA certificate file",
+			tls:     &egv1a1.ExtensionTLS{CACertFile: ptr.To(filepath.Join(dir, "invalid.crt"))},
+			wantErr: "error parsing cert in file",
+		},
+		{
+			name: "invalid client certificate file",
+			tls: &egv1a1.ExtensionTLS{
+				CACertFile:     ptr.To(filepath.Join(dir, "ca.crt")),
+				ClientCertFile: ptr.To(filepath.Join(dir, "invalid.crt")),
+				ClientKeyFile:  ptr.To(filepath.Join(dir, "client.key")),
+			},
+			wantErr: "failed to load client certificate",
+		},
+		{
+			name:    "certificateRef without Kubernetes",
+			tls:     &egv1a1.ExtensionTLS{},
+			wantErr: "use caCertFile instead",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ext := &egv1a1.ExtensionManager{
+				Service: &egv1a1.ExtensionService{
+					BackendEndpoint: egv1a1.BackendEndpoint{
+						IP: &egv1a1.IPEndpoint{Address: "127.0.0.1", Port: 5005},
+					},
+					TLS: tc.tls,
+				},
+			}
+			_, err := setupGRPCOpts(context.TODO(), nil, ext, "envoy-gateway-system")
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
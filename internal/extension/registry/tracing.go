@@ -0,0 +1,114 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package registry
+
+import (
+	"context"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/extension/types"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/tracing"
+)
+
+var (
+	_ types.Manager       = (*tracingManager)(nil)
+	_ types.XDSHookClient = (*tracingXDSHook)(nil)
+)
+
+// tracingManager is a Manager whose hook clients trace the hooks invoked during an xDS translation
+// as children of the span of the translation.
+type tracingManager struct {
+	types.Manager
+	ctx context.Context
+}
+
+// NewTracingManager returns a Manager whose hook clients trace the hooks they invoke as children of
+// the span of ctx, or m if the span of ctx isn't recorded.
+func NewTracingManager(ctx context.Context, m types.Manager) types.Manager {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return m
+	}
+	return &tracingManager{Manager: m, ctx: ctx}
+}
+
+func (m *tracingManager) GetPreXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) (types.XDSHookClient, error) {
+	hook, err := m.Manager.GetPreXDSHookClient(xdsHookType)
+	if hook == nil {
+		return hook, err
+	}
+	return &tracingXDSHook{hook: hook, ctx: m.ctx}, err
+}
+
+func (m *tracingManager) GetPostXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) (types.XDSHookClient, error) {
+	hook, err := m.Manager.GetPostXDSHookClient(xdsHookType)
+	if hook == nil {
+		return hook, err
+	}
+	return &tracingXDSHook{hook: hook, ctx: m.ctx}, err
+}
+
+// tracingXDSHook traces the hooks invoked by a hook client.
+type tracingXDSHook struct {
+	hook types.XDSHookClient
+	ctx  context.Context
+}
+
+func (h *tracingXDSHook) start(hook string) trace.Span {
+	_, span := tracing.Start(h.ctx, "extension-hook", tracing.HookKey.String(hook))
+	return span
+}
+
+func (h *tracingXDSHook) PostRouteModifyHook(route *route.Route, routeHostnames []string, extensionResources []*unstructured.Unstructured) (*route.Route, error) {
+	span := h.start("PostRouteModify")
+	route, err := h.hook.PostRouteModifyHook(route, routeHostnames, extensionResources)
+	tracing.End(span, err)
+	return route, err
+}
+
+func (h *tracingXDSHook) PostVirtualHostModifyHook(vh *route.VirtualHost) (*route.VirtualHost, error) {
+	span := h.start("PostVirtualHostModify")
+	vh, err := h.hook.PostVirtualHostModifyHook(vh)
+	tracing.End(span, err)
+	return vh, err
+}
+
+func (h *tracingXDSHook) PostHTTPListenerModifyHook(l *listener.Listener, extensionResources []*unstructured.Unstructured) (*listener.Listener, error) {
+	span := h.start("PostHTTPListenerModify")
+	l, err := h.hook.PostHTTPListenerModifyHook(l, extensionResources)
+	tracing.End(span, err)
+	return l, err
+}
+
+func (h *tracingXDSHook) PostTranslateModifyHook(clusters []*cluster.Cluster, secrets []*tls.Secret) ([]*cluster.Cluster, []*tls.Secret, error) {
+	span := h.start("PostTranslateModify")
+	clusters, secrets, err := h.hook.PostTranslateModifyHook(clusters, secrets)
+	tracing.End(span, err)
+	return clusters, secrets, err
+}
+
+func (h *tracingXDSHook) PostClusterModifyHook(c *cluster.Cluster, route *ir.ResourceMetadata, backends []*ir.ResourceMetadata,
+	backendTrafficPolicy *ir.ResourceMetadata,
+) (*cluster.Cluster, error) {
+	span := h.start("PostClusterModify")
+	c, err := h.hook.PostClusterModifyHook(c, route, backends, backendTrafficPolicy)
+	tracing.End(span, err)
+	return c, err
+}
+
+func (h *tracingXDSHook) PreXDSTranslateHook(name string, xdsIR *ir.Xds) (*ir.Xds, error) {
+	span := h.start("PreXDSTranslate")
+	xdsIR, err := h.hook.PreXDSTranslateHook(name, xdsIR)
+	tracing.End(span, err)
+	return xdsIR, err
+}
@@ -0,0 +1,61 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/tracing"
+)
+
+func TestTracingManager(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	foo := &chainTestServer{name: "foo"}
+	m := newChainTestManager(t, foo)
+
+	// The manager isn't wrapped if the translation isn't traced.
+	require.Same(t, m, NewTracingManager(context.Background(), m))
+
+	ctx, span := tracing.Start(context.Background(), "xds-translate")
+	tm := NewTracingManager(ctx, m)
+
+	client, err := tm.GetPostXDSHookClient(egv1a1.XDSVirtualHost)
+	require.NoError(t, err)
+	vh, err := client.PostVirtualHostModifyHook(&routev3.VirtualHost{Name: "vh"})
+	require.NoError(t, err)
+	require.Equal(t, "vh-foo", vh.Name)
+
+	foo.fail = true
+	_, err = client.PostVirtualHostModifyHook(&routev3.VirtualHost{Name: "vh"})
+	require.Error(t, err)
+
+	// The hooks the extension doesn't use have no client.
+	client, err = tm.GetPostXDSHookClient(egv1a1.XDSHTTPListener)
+	require.NoError(t, err)
+	require.Nil(t, client)
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	for _, hookSpan := range spans[:2] {
+		require.Equal(t, "extension-hook", hookSpan.Name())
+		require.Equal(t, span.SpanContext().SpanID(), hookSpan.Parent().SpanID())
+		require.Contains(t, hookSpan.Attributes(), tracing.HookKey.String("PostVirtualHostModify"))
+	}
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+}
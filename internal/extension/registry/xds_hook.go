@@ -7,6 +7,9 @@ package registry
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 
 	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
@@ -15,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/envoyproxy/gateway/internal/extension/types"
+	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/proto/extension"
 )
 
@@ -120,3 +124,253 @@ func (h *XDSHook) PostTranslateModifyHook(clusters []*cluster.Cluster, secrets [
 
 	return resp.Clusters, resp.Secrets, nil
 }
+
+// translateResourceMetadata converts the metadata of a resource in the IR to its protobuf representation.
+func translateResourceMetadata(metadata *ir.ResourceMetadata) *extension.ResourceMetadata {
+	if metadata == nil {
+		return nil
+	}
+	return &extension.ResourceMetadata{
+		Kind:        metadata.Kind,
+		Name:        metadata.Name,
+		Namespace:   metadata.Namespace,
+		Annotations: metadata.Annotations,
+		SectionName: metadata.SectionName,
+	}
+}
+
+func (h *XDSHook) PostClusterModifyHook(c *cluster.Cluster, route *ir.ResourceMetadata, backends []*ir.ResourceMetadata,
+	backendTrafficPolicy *ir.ResourceMetadata,
+) (*cluster.Cluster, error) {
+	backendsMetadata := make([]*extension.ResourceMetadata, 0, len(backends))
+	for _, backend := range backends {
+		if backend != nil {
+			backendsMetadata = append(backendsMetadata, translateResourceMetadata(backend))
+		}
+	}
+
+	// Make the request to the extension server
+	ctx := context.Background()
+	resp, err := h.grpcClient.PostClusterModify(ctx,
+		&extension.PostClusterModifyRequest{
+			Cluster: c,
+			PostClusterContext: &extension.PostClusterExtensionContext{
+				Route:                translateResourceMetadata(route),
+				Backends:             backendsMetadata,
+				BackendTrafficPolicy: translateResourceMetadata(backendTrafficPolicy),
+			},
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Cluster, nil
+}
+
+func (h *XDSHook) PreXDSTranslateHook(name string, xdsIR *ir.Xds) (*ir.Xds, error) {
+	// The IR is sent as JSON, which redacts its secrets
+	irBytes, err := json.Marshal(xdsIR)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal IR: %w", err)
+	}
+
+	// Make the request to the extension server
+	ctx := context.Background()
+	resp, err := h.grpcClient.PreXDSTranslate(ctx,
+		&extension.PreXDSTranslateRequest{
+			PreXdsTranslateContext: &extension.PreXDSTranslateExtensionContext{
+				Name: name,
+			},
+			Ir:        irBytes,
+			IrVersion: ir.XdsSchemaVersion,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Ir) == 0 {
+		return nil, nil
+	}
+	if resp.IrVersion != ir.XdsSchemaVersion {
+		return nil, fmt.Errorf("unsupported IR version %q returned by the extension, expected %q", resp.IrVersion, ir.XdsSchemaVersion)
+	}
+	modifiedIR := &ir.Xds{}
+	if err := json.Unmarshal(resp.Ir, modifiedIR); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal IR: %w", err)
+	}
+	modifiedIR.RestoreRedacted(xdsIR)
+	return modifiedIR, nil
+}
+
+var _ types.XDSHookClient = (*XDSHookChain)(nil)
+
+// XDSHookChain invokes the same hook of multiple extensions in order, where each hook
+// receives the output of the hook of the previous extension.
+//
+// The first error of an extension configured to fail close is returned directly, while the
+// errors of the extensions configured to fail open are returned as a types.FailOpenError
+// along with the output of the other extensions.
+type XDSHookChain struct {
+	hooks []*chainedXDSHook
+	// setupErr holds the errors of the extensions configured to fail open which couldn't be set up.
+	setupErr error
+}
+
+type chainedXDSHook struct {
+	*XDSHook
+	extension *registeredExtension
+}
+
+// invoke calls the given function with the hook of each extension in order.
+func (c *XDSHookChain) invoke(call func(h *chainedXDSHook) error) error {
+	errs := c.setupErr
+	for _, h := range c.hooks {
+		if err := call(h); err != nil {
+			err = h.extension.wrapError(err)
+			if !h.extension.config.FailOpen {
+				return err
+			}
+			errs = errors.Join(errs, err)
+		}
+	}
+	if errs != nil {
+		return &types.FailOpenError{Err: errs}
+	}
+	return nil
+}
+
+// PostRouteModifyHook invokes the hook of the extensions whose resources are referenced by the route,
+// each of them only receives its own resources.
+func (c *XDSHookChain) PostRouteModifyHook(r *route.Route, routeHostnames []string, extensionResources []*unstructured.Unstructured) (*route.Route, error) {
+	var modifiedRoute *route.Route
+	err := c.invoke(func(h *chainedXDSHook) error {
+		resources := h.extension.ownedResources(extensionResources)
+		if len(resources) == 0 {
+			return nil
+		}
+		in := r
+		if modifiedRoute != nil {
+			in = modifiedRoute
+		}
+		out, err := h.XDSHook.PostRouteModifyHook(in, routeHostnames, resources)
+		if err != nil {
+			return err
+		}
+		if out != nil {
+			modifiedRoute = out
+		}
+		return nil
+	})
+	if err != nil && !types.IsFailOpenError(err) {
+		return nil, err
+	}
+	return modifiedRoute, err
+}
+
+func (c *XDSHookChain) PostVirtualHostModifyHook(vh *route.VirtualHost) (*route.VirtualHost, error) {
+	var modifiedVH *route.VirtualHost
+	err := c.invoke(func(h *chainedXDSHook) error {
+		in := vh
+		if modifiedVH != nil {
+			in = modifiedVH
+		}
+		out, err := h.XDSHook.PostVirtualHostModifyHook(in)
+		if err != nil {
+			return err
+		}
+		if out != nil {
+			modifiedVH = out
+		}
+		return nil
+	})
+	if err != nil && !types.IsFailOpenError(err) {
+		return nil, err
+	}
+	return modifiedVH, err
+}
+
+// PostHTTPListenerModifyHook invokes the hook of all the extensions, each of them only receives
+// its own resources.
+func (c *XDSHookChain) PostHTTPListenerModifyHook(l *listener.Listener, extensionResources []*unstructured.Unstructured) (*listener.Listener, error) {
+	var modifiedListener *listener.Listener
+	err := c.invoke(func(h *chainedXDSHook) error {
+		in := l
+		if modifiedListener != nil {
+			in = modifiedListener
+		}
+		out, err := h.XDSHook.PostHTTPListenerModifyHook(in, h.extension.ownedResources(extensionResources))
+		if err != nil {
+			return err
+		}
+		if out != nil {
+			modifiedListener = out
+		}
+		return nil
+	})
+	if err != nil && !types.IsFailOpenError(err) {
+		return nil, err
+	}
+	return modifiedListener, err
+}
+
+func (c *XDSHookChain) PostTranslateModifyHook(clusters []*cluster.Cluster, secrets []*tls.Secret) ([]*cluster.Cluster, []*tls.Secret, error) {
+	modified := false
+	err := c.invoke(func(h *chainedXDSHook) error {
+		newClusters, newSecrets, err := h.XDSHook.PostTranslateModifyHook(clusters, secrets)
+		if err != nil {
+			return err
+		}
+		clusters, secrets, modified = newClusters, newSecrets, true
+		return nil
+	})
+	if (err != nil && !types.IsFailOpenError(err)) || !modified {
+		return nil, nil, err
+	}
+	return clusters, secrets, err
+}
+
+func (c *XDSHookChain) PostClusterModifyHook(cl *cluster.Cluster, route *ir.ResourceMetadata, backends []*ir.ResourceMetadata,
+	backendTrafficPolicy *ir.ResourceMetadata,
+) (*cluster.Cluster, error) {
+	var modifiedCluster *cluster.Cluster
+	err := c.invoke(func(h *chainedXDSHook) error {
+		in := cl
+		if modifiedCluster != nil {
+			in = modifiedCluster
+		}
+		out, err := h.XDSHook.PostClusterModifyHook(in, route, backends, backendTrafficPolicy)
+		if err != nil {
+			return err
+		}
+		if out != nil {
+			modifiedCluster = out
+		}
+		return nil
+	})
+	if err != nil && !types.IsFailOpenError(err) {
+		return nil, err
+	}
+	return modifiedCluster, err
+}
+
+func (c *XDSHookChain) PreXDSTranslateHook(name string, xdsIR *ir.Xds) (*ir.Xds, error) {
+	var modifiedIR *ir.Xds
+	err := c.invoke(func(h *chainedXDSHook) error {
+		in := xdsIR
+		if modifiedIR != nil {
+			in = modifiedIR
+		}
+		out, err := h.XDSHook.PreXDSTranslateHook(name, in)
+		if err != nil {
+			return err
+		}
+		if out != nil {
+			modifiedIR = out
+		}
+		return nil
+	})
+	if err != nil && !types.IsFailOpenError(err) {
+		return nil, err
+	}
+	return modifiedIR, err
+}
@@ -0,0 +1,258 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	extTypes "github.com/envoyproxy/gateway/internal/extension/types"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/proto/extension"
+)
+
+// chainTestServer appends its name to the name of the resources it receives, and records
+// the kinds of the extension resources it receives.
+type chainTestServer struct {
+	extension.UnimplementedEnvoyGatewayExtensionServer
+	name  string
+	fail  bool
+	kinds []string
+	// irVersion overrides the version of the IR returned by PreXDSTranslate.
+	irVersion string
+}
+
+func (s *chainTestServer) PostRouteModify(_ context.Context, req *extension.PostRouteModifyRequest) (*extension.PostRouteModifyResponse, error) {
+	if s.fail {
+		return nil, errors.New(s.name + " failed")
+	}
+	for _, res := range req.PostRouteContext.ExtensionResources {
+		u := unstructured.Unstructured{}
+		if err := u.UnmarshalJSON(res.UnstructuredBytes); err != nil {
+			return nil, err
+		}
+		s.kinds = append(s.kinds, u.GetKind())
+	}
+	route := proto.Clone(req.Route).(*routev3.Route)
+	route.Name += "-" + s.name
+	return &extension.PostRouteModifyResponse{Route: route}, nil
+}
+
+func (s *chainTestServer) PostVirtualHostModify(_ context.Context, req *extension.PostVirtualHostModifyRequest) (*extension.PostVirtualHostModifyResponse, error) {
+	if s.fail {
+		return nil, errors.New(s.name + " failed")
+	}
+	vh := proto.Clone(req.VirtualHost).(*routev3.VirtualHost)
+	vh.Name += "-" + s.name
+	return &extension.PostVirtualHostModifyResponse{VirtualHost: vh}, nil
+}
+
+func (s *chainTestServer) PostTranslateModify(_ context.Context, req *extension.PostTranslateModifyRequest) (*extension.PostTranslateModifyResponse, error) {
+	if s.fail {
+		return nil, errors.New(s.name + " failed")
+	}
+	clusters := append(req.Clusters, &clusterv3.Cluster{Name: s.name})
+	return &extension.PostTranslateModifyResponse{Clusters: clusters, Secrets: req.Secrets}, nil
+}
+
+func (s *chainTestServer) PostClusterModify(_ context.Context, req *extension.PostClusterModifyRequest) (*extension.PostClusterModifyResponse, error) {
+	if s.fail {
+		return nil, errors.New(s.name + " failed")
+	}
+	ctx := req.PostClusterContext
+	cluster := proto.Clone(req.Cluster).(*clusterv3.Cluster)
+	cluster.Name += "-" + s.name + "-" + ctx.Route.Name + "-" + ctx.Backends[0].Name + "-" + ctx.BackendTrafficPolicy.Name
+	return &extension.PostClusterModifyResponse{Cluster: cluster}, nil
+}
+
+func (s *chainTestServer) PreXDSTranslate(_ context.Context, req *extension.PreXDSTranslateRequest) (*extension.PreXDSTranslateResponse, error) {
+	if s.fail {
+		return nil, errors.New(s.name + " failed")
+	}
+	xdsIR := &ir.Xds{}
+	if err := json.Unmarshal(req.Ir, xdsIR); err != nil {
+		return nil, err
+	}
+	xdsIR.HTTP = append(xdsIR.HTTP, &ir.HTTPListener{
+		CoreListenerDetails: ir.CoreListenerDetails{Name: req.PreXdsTranslateContext.Name + "-" + s.name},
+	})
+	irBytes, err := json.Marshal(xdsIR)
+	if err != nil {
+		return nil, err
+	}
+	irVersion := req.IrVersion
+	if s.irVersion != "" {
+		irVersion = s.irVersion
+	}
+	return &extension.PreXDSTranslateResponse{Ir: irBytes, IrVersion: irVersion}, nil
+}
+
+func newChainTestManager(t *testing.T, servers ...*chainTestServer) *Manager {
+	t.Helper()
+	m := &Manager{}
+	for _, server := range servers {
+		conn, closeFunc, err := newInMemoryConn(server)
+		require.NoError(t, err)
+		t.Cleanup(closeFunc)
+		m.extensions = append(m.extensions, &registeredExtension{
+			config: egv1a1.ExtensionManager{
+				Name: server.name,
+				Resources: []egv1a1.GroupVersionKind{
+					{Group: server.name + ".example.io", Version: "v1alpha1", Kind: "Filter"},
+				},
+				Hooks: &egv1a1.ExtensionHooks{
+					XDSTranslator: &egv1a1.XDSTranslatorHooks{
+						Pre:  []egv1a1.XDSTranslatorHook{egv1a1.XDSIR},
+						Post: []egv1a1.XDSTranslatorHook{egv1a1.XDSRoute, egv1a1.XDSVirtualHost, egv1a1.XDSTranslation, egv1a1.XDSCluster},
+					},
+				},
+			},
+			extensionConnCache: conn,
+		})
+	}
+	return m
+}
+
+func newExtensionResource(group string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(group + "/v1alpha1")
+	u.SetKind("Filter")
+	u.SetName(group)
+	return u
+}
+
+func TestXDSHookChain(t *testing.T) {
+	foo := &chainTestServer{name: "foo"}
+	bar := &chainTestServer{name: "bar"}
+	m := newChainTestManager(t, foo, bar)
+	require.True(t, m.HasExtension("foo.example.io", "Filter"))
+	require.True(t, m.HasExtension("bar.example.io", "Filter"))
+	require.False(t, m.HasExtension("baz.example.io", "Filter"))
+
+	client, err := m.GetPostXDSHookClient(egv1a1.XDSRoute)
+	require.NoError(t, err)
+
+	// Each extension receives the output of the previous one, and only its own resources.
+	route, err := client.PostRouteModifyHook(&routev3.Route{Name: "route"}, nil, []*unstructured.Unstructured{
+		newExtensionResource("bar.example.io"),
+		newExtensionResource("foo.example.io"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "route-foo-bar", route.Name)
+	require.Equal(t, []string{"Filter"}, foo.kinds)
+	require.Equal(t, []string{"Filter"}, bar.kinds)
+
+	// The route hook is only invoked for the extensions whose resources are referenced.
+	route, err = client.PostRouteModifyHook(&routev3.Route{Name: "route"}, nil, []*unstructured.Unstructured{
+		newExtensionResource("bar.example.io"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "route-bar", route.Name)
+
+	client, err = m.GetPostXDSHookClient(egv1a1.XDSVirtualHost)
+	require.NoError(t, err)
+	vh, err := client.PostVirtualHostModifyHook(&routev3.VirtualHost{Name: "vh"})
+	require.NoError(t, err)
+	require.Equal(t, "vh-foo-bar", vh.Name)
+
+	client, err = m.GetPostXDSHookClient(egv1a1.XDSTranslation)
+	require.NoError(t, err)
+	clusters, _, err := client.PostTranslateModifyHook(nil, nil)
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+	require.Equal(t, "foo", clusters[0].Name)
+	require.Equal(t, "bar", clusters[1].Name)
+
+	client, err = m.GetPostXDSHookClient(egv1a1.XDSCluster)
+	require.NoError(t, err)
+	cluster, err := client.PostClusterModifyHook(&clusterv3.Cluster{Name: "cluster"},
+		&ir.ResourceMetadata{Kind: "HTTPRoute", Name: "route"},
+		[]*ir.ResourceMetadata{{Kind: "Service", Name: "svc"}},
+		&ir.ResourceMetadata{Kind: egv1a1.KindBackendTrafficPolicy, Name: "btp"})
+	require.NoError(t, err)
+	require.Equal(t, "cluster-foo-route-svc-btp-bar-route-svc-btp", cluster.Name)
+
+	client, err = m.GetPreXDSHookClient(egv1a1.XDSIR)
+	require.NoError(t, err)
+	original := &ir.Xds{
+		HTTP: []*ir.HTTPListener{
+			{
+				CoreListenerDetails: ir.CoreListenerDetails{Name: "listener"},
+				TLS: &ir.TLSConfig{
+					Certificates: []ir.TLSCertificate{{Name: "cert", PrivateKey: ir.PrivateBytes("key")}},
+				},
+			},
+		},
+	}
+	xdsIR, err := client.PreXDSTranslateHook("gateway", original)
+	require.NoError(t, err)
+	require.Len(t, xdsIR.HTTP, 3)
+	require.Equal(t, "gateway-foo", xdsIR.HTTP[1].Name)
+	require.Equal(t, "gateway-bar", xdsIR.HTTP[2].Name)
+	// The secrets redacted when the IR is sent to the extensions are restored.
+	require.Equal(t, original.HTTP[0], xdsIR.HTTP[0])
+
+	// No client is returned if none of the extensions use the hook.
+	client, err = m.GetPostXDSHookClient(egv1a1.XDSHTTPListener)
+	require.NoError(t, err)
+	require.Nil(t, client)
+}
+
+func TestXDSHookChainErrors(t *testing.T) {
+	foo := &chainTestServer{name: "foo", fail: true}
+	bar := &chainTestServer{name: "bar"}
+	m := newChainTestManager(t, foo, bar)
+
+	// The chain stops at the first error of an extension configured to fail close.
+	client, err := m.GetPostXDSHookClient(egv1a1.XDSVirtualHost)
+	require.NoError(t, err)
+	vh, err := client.PostVirtualHostModifyHook(&routev3.VirtualHost{Name: "vh"})
+	require.ErrorContains(t, err, "extension foo: ")
+	require.False(t, extTypes.IsFailOpenError(err))
+	require.Nil(t, vh)
+	require.False(t, m.FailOpen())
+
+	// The extensions configured to fail open are skipped.
+	m.extensions[0].config.FailOpen = true
+	vh, err = client.PostVirtualHostModifyHook(&routev3.VirtualHost{Name: "vh"})
+	require.ErrorContains(t, err, "extension foo: ")
+	require.True(t, extTypes.IsFailOpenError(err))
+	require.Equal(t, "vh-bar", vh.Name)
+	require.False(t, m.FailOpen())
+
+	// No resources are returned if all the extensions failed.
+	bar.fail = true
+	m.extensions[1].config.FailOpen = true
+	require.True(t, m.FailOpen())
+	client, err = m.GetPostXDSHookClient(egv1a1.XDSTranslation)
+	require.NoError(t, err)
+	clusters, secrets, err := client.PostTranslateModifyHook([]*clusterv3.Cluster{{Name: "cluster"}}, nil)
+	require.True(t, extTypes.IsFailOpenError(err))
+	require.ErrorContains(t, err, "extension bar: ")
+	require.Nil(t, clusters)
+	require.Nil(t, secrets)
+}
+
+func TestPreXDSTranslateHookVersion(t *testing.T) {
+	foo := &chainTestServer{name: "foo", irVersion: "v0"}
+	m := newChainTestManager(t, foo)
+
+	// The IR returned by an extension is rejected if its version isn't the one of the request.
+	client, err := m.GetPreXDSHookClient(egv1a1.XDSIR)
+	require.NoError(t, err)
+	xdsIR, err := client.PreXDSTranslateHook("gateway", &ir.Xds{})
+	require.ErrorContains(t, err, `unsupported IR version "v0" returned by the extension, expected "v1"`)
+	require.Nil(t, xdsIR)
+}
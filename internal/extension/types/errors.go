@@ -0,0 +1,29 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package types
+
+import "errors"
+
+// FailOpenError wraps the errors of the extensions configured to fail open.
+// The resources returned by a hook along with a FailOpenError are still valid,
+// they include the changes of the extensions which didn't return an error.
+type FailOpenError struct {
+	Err error
+}
+
+func (e *FailOpenError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FailOpenError) Unwrap() error {
+	return e.Err
+}
+
+// IsFailOpenError returns true if the error is only caused by extensions configured to fail open.
+func IsFailOpenError(err error) bool {
+	var failOpenErr *FailOpenError
+	return errors.As(err, &failOpenErr)
+}
@@ -11,6 +11,8 @@ import (
 	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/envoyproxy/gateway/internal/ir"
 )
 
 type XDSHookClient interface {
@@ -42,4 +44,23 @@ type XDSHookClient interface {
 	// The list of clusters and secrets returned by the extension are used as the final list of all clusters and secrets
 	// PostTranslateModifyHook is always executed when an extension is loaded
 	PostTranslateModifyHook([]*cluster.Cluster, []*tls.Secret) ([]*cluster.Cluster, []*tls.Secret, error)
+
+	// PostClusterModifyHook allows an extension to make changes to a cluster generated by Envoy Gateway before it is
+	// finalized. It also passes the metadata of the route, the backends and the BackendTrafficPolicy the cluster was
+	// generated from, the BackendTrafficPolicy metadata is nil if no policy is attached to the route. The route metadata
+	// is nil for the clusters which are not generated for the backends of an HTTPRoute or GRPCRoute, and the backends are
+	// empty as well for the other clusters, e.g. the clusters of the tracing and access log services.
+	// PostClusterModifyHook is executed for each cluster when an extension is loaded with the Cluster post hook.
+	// An extension may return nil in order to not make any changes to it.
+	PostClusterModifyHook(cluster *cluster.Cluster, route *ir.ResourceMetadata, backends []*ir.ResourceMetadata,
+		backendTrafficPolicy *ir.ResourceMetadata) (*cluster.Cluster, error)
+
+	// PreXDSTranslateHook allows an extension to modify the IR of a Gateway before it is translated into xDS resources.
+	// Operating on the IR allows extensions to inject routes or tweak traffic features without depending on the
+	// Envoy protos generated by Envoy Gateway. The secrets of the IR are redacted before it is sent to the extension,
+	// and restored in the returned IR. The IR is sent as JSON along with ir.XdsSchemaVersion, and the returned IR is
+	// rejected if the extension doesn't return it with the same version.
+	// PreXDSTranslateHook is always executed when an extension is loaded with the IR pre hook. An extension may return
+	// nil in order to not make any changes to it.
+	PreXDSTranslateHook(name string, xdsIR *ir.Xds) (*ir.Xds, error)
 }
@@ -33,6 +33,7 @@ type Manager interface {
 	// the hook type then nil is returned
 	GetPostXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) (XDSHookClient, error)
 
-	// FailOpen returns true if the extension manager is configured to fail open, and false otherwise.
+	// FailOpen returns true if all the registered extensions are configured to fail open, and false otherwise.
+	// The errors of the extensions configured to fail open are returned as FailOpenError by the hook clients.
 	FailOpen() bool
 }
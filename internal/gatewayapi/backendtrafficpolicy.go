@@ -429,6 +429,7 @@ func (t *Translator) translateBackendTrafficPolicyForRoute(
 					}
 
 					r.Traffic.Name = irTrafficName(policy)
+					r.Traffic.Metadata = buildBackendTrafficPolicyMetadata(policy)
 
 					// Update the Host field in HealthCheck, now that we have access to the Route Hostname.
 					r.Traffic.HealthCheck.SetHTTPHostIfAbsent(r.Hostname)
@@ -599,6 +600,7 @@ func (t *Translator) translateBackendTrafficPolicyForGateway(
 			}
 
 			r.Traffic.Name = irTrafficName(policy)
+			r.Traffic.Metadata = buildBackendTrafficPolicyMetadata(policy)
 
 			// Update the Host field in HealthCheck, now that we have access to the Route Hostname.
 			r.Traffic.HealthCheck.SetHTTPHostIfAbsent(r.Hostname)
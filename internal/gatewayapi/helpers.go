@@ -373,6 +373,15 @@ func irStringKey(gatewayNs, gatewayName string) string {
 	return fmt.Sprintf("%s/%s", gatewayNs, gatewayName)
 }
 
+// GetIRKey returns the key of the IR a Gateway is translated into, which is the
+// name of its GatewayClass when the Gateways of the class are merged.
+func GetIRKey(gateway *gwapiv1.Gateway, mergeGateways bool) string {
+	if mergeGateways {
+		return string(gateway.Spec.GatewayClassName)
+	}
+	return irStringKey(gateway.Namespace, gateway.Name)
+}
+
 func irListenerName(listener *ListenerContext) string {
 	return fmt.Sprintf("%s/%s/%s", listener.gateway.Namespace, listener.gateway.Name, listener.Name)
 }
@@ -454,6 +463,15 @@ func irTrafficName(policy *egv1a1.BackendTrafficPolicy) string {
 	return fmt.Sprintf("%s/%s", policy.Namespace, policy.Name)
 }
 
+func buildBackendTrafficPolicyMetadata(policy *egv1a1.BackendTrafficPolicy) *ir.ResourceMetadata {
+	return &ir.ResourceMetadata{
+		Kind:        egv1a1.KindBackendTrafficPolicy,
+		Name:        policy.Name,
+		Namespace:   policy.Namespace,
+		Annotations: filterEGPrefix(policy.Annotations),
+	}
+}
+
 func IsMergeGatewaysEnabled(resources *resource.Resources) bool {
 	return resources.EnvoyProxyForGatewayClass != nil && resources.EnvoyProxyForGatewayClass.Spec.MergeGateways != nil && *resources.EnvoyProxyForGatewayClass.Spec.MergeGateways
 }
@@ -15,6 +15,7 @@ import (
 	"sort"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -22,6 +23,8 @@ import (
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	mcsapiv1a1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 	"sigs.k8s.io/yaml"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
@@ -47,10 +50,10 @@ func LoadResourcesFromYAMLBytes(yamlBytes []byte, addMissingResources bool) (*Re
 // loadKubernetesYAMLToResources converts a Kubernetes YAML string into GatewayAPI Resources.
 // TODO: add support for kind:
 //   - BackendLPPolicy (gateway.networking.k8s.io/v1alpha2)
-//   - ReferenceGrant (gateway.networking.k8s.io/v1alpha2)
 func loadKubernetesYAMLToResources(input []byte, addMissingResources bool) (*Resources, error) {
 	resources := NewResources()
 	var useDefaultNamespace bool
+	var envoyProxies []*egv1a1.EnvoyProxy
 	providedNamespaceMap := sets.New[string]()
 	requiredNamespaceMap := sets.New[string]()
 	combinedScheme := envoygateway.GetScheme()
@@ -114,8 +117,7 @@ func loadKubernetesYAMLToResources(input []byte, addMissingResources bool) (*Res
 				},
 				Spec: typedSpec.(egv1a1.EnvoyProxySpec),
 			}
-			// TODO: only support loading one envoyproxy for now.
-			resources.EnvoyProxyForGatewayClass = envoyProxy
+			envoyProxies = append(envoyProxies, envoyProxy)
 		case KindGatewayClass:
 			typedSpec := spec.Interface()
 			gatewayClass := &gwapiv1.GatewayClass{
@@ -214,7 +216,8 @@ func loadKubernetesYAMLToResources(input []byte, addMissingResources bool) (*Res
 		case KindNamespace:
 			namespace := &corev1.Namespace{
 				ObjectMeta: metav1.ObjectMeta{
-					Name: name,
+					Name:   name,
+					Labels: un.GetLabels(),
 				},
 			}
 			resources.Namespaces = append(resources.Namespaces, namespace)
@@ -233,6 +236,49 @@ func loadKubernetesYAMLToResources(input []byte, addMissingResources bool) (*Res
 				service.Spec.ClusterIP = dummyClusterIP
 			}
 			resources.Services = append(resources.Services, service)
+		case KindServiceImport:
+			typedSpec := spec.Interface()
+			serviceImport := &mcsapiv1a1.ServiceImport{
+				TypeMeta: metav1.TypeMeta{
+					Kind: KindServiceImport,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: typedSpec.(mcsapiv1a1.ServiceImportSpec),
+			}
+			resources.ServiceImports = append(resources.ServiceImports, serviceImport)
+		case KindEndpointSlice:
+			typedEndpointSlice := kobj.(*discoveryv1.EndpointSlice)
+			endpointSlice := &discoveryv1.EndpointSlice{
+				TypeMeta: metav1.TypeMeta{
+					Kind: KindEndpointSlice,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+					// The labels associate the EndpointSlice to its Service or ServiceImport.
+					Labels: un.GetLabels(),
+				},
+				AddressType: typedEndpointSlice.AddressType,
+				Endpoints:   typedEndpointSlice.Endpoints,
+				Ports:       typedEndpointSlice.Ports,
+			}
+			resources.EndpointSlices = append(resources.EndpointSlices, endpointSlice)
+		case KindReferenceGrant:
+			typedSpec := spec.Interface()
+			referenceGrant := &gwapiv1b1.ReferenceGrant{
+				TypeMeta: metav1.TypeMeta{
+					Kind: KindReferenceGrant,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: typedSpec.(gwapiv1b1.ReferenceGrantSpec),
+			}
+			resources.ReferenceGrants = append(resources.ReferenceGrants, referenceGrant)
 		case KindEnvoyPatchPolicy:
 			typedSpec := spec.Interface()
 			envoyPatchPolicy := &egv1a1.EnvoyPatchPolicy{
@@ -376,6 +422,8 @@ func loadKubernetesYAMLToResources(input []byte, addMissingResources bool) (*Res
 		return nil, err
 	}
 
+	assignEnvoyProxies(resources, envoyProxies)
+
 	if useDefaultNamespace {
 		if !providedNamespaceMap.Has(config.DefaultNamespace) {
 			namespace := &corev1.Namespace{
@@ -467,6 +515,32 @@ func loadKubernetesYAMLToResources(input []byte, addMissingResources bool) (*Res
 	return resources, nil
 }
 
+// assignEnvoyProxies assigns the EnvoyProxies referenced by the infrastructure of the Gateways to
+// them, and the last of the others to the GatewayClass.
+func assignEnvoyProxies(resources *Resources, envoyProxies []*egv1a1.EnvoyProxy) {
+	gatewayProxies := sets.New[string]()
+	for _, gateway := range resources.Gateways {
+		if infra := gateway.Spec.Infrastructure; infra != nil && infra.ParametersRef != nil && infra.ParametersRef.Kind == KindEnvoyProxy {
+			gatewayProxies.Insert(gateway.Namespace + "/" + infra.ParametersRef.Name)
+		}
+	}
+	var classProxy *gwapiv1.ParametersReference
+	if resources.GatewayClass != nil {
+		classProxy = resources.GatewayClass.Spec.ParametersRef
+	}
+
+	for _, envoyProxy := range envoyProxies {
+		isClassProxy := classProxy != nil && classProxy.Kind == KindEnvoyProxy && classProxy.Name == envoyProxy.Name &&
+			classProxy.Namespace != nil && string(*classProxy.Namespace) == envoyProxy.Namespace
+		if !isClassProxy && gatewayProxies.Has(envoyProxy.Namespace+"/"+envoyProxy.Name) {
+			resources.EnvoyProxiesForGateways = append(resources.EnvoyProxiesForGateways, envoyProxy)
+			continue
+		}
+		// TODO: only support loading one envoyproxy for the GatewayClass for now.
+		resources.EnvoyProxyForGatewayClass = envoyProxy
+	}
+}
+
 func addMissingServices(requiredServices map[string]*corev1.Service, obj interface{}) {
 	var objNamespace string
 	protocol := ir.TCPProtocolType
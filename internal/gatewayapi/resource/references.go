@@ -0,0 +1,123 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package resource
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	mcsapiv1a1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+// References holds the namespaced names of the objects referenced by a set of resources,
+// by kind.
+type References struct {
+	Secrets          sets.Set[types.NamespacedName]
+	ConfigMaps       sets.Set[types.NamespacedName]
+	Services         sets.Set[types.NamespacedName]
+	ServiceImports   sets.Set[types.NamespacedName]
+	Backends         sets.Set[types.NamespacedName]
+	HTTPRouteFilters sets.Set[types.NamespacedName]
+}
+
+// NewReferences returns empty References.
+func NewReferences() *References {
+	return &References{
+		Secrets:          sets.New[types.NamespacedName](),
+		ConfigMaps:       sets.New[types.NamespacedName](),
+		Services:         sets.New[types.NamespacedName](),
+		ServiceImports:   sets.New[types.NamespacedName](),
+		Backends:         sets.New[types.NamespacedName](),
+		HTTPRouteFilters: sets.New[types.NamespacedName](),
+	}
+}
+
+var (
+	secretObjectReferenceType  = reflect.TypeOf(gwapiv1.SecretObjectReference{})
+	backendObjectReferenceType = reflect.TypeOf(gwapiv1.BackendObjectReference{})
+	localObjectReferenceType   = reflect.TypeOf(gwapiv1.LocalObjectReference{})
+	objectReferenceType        = reflect.TypeOf(gwapiv1.ObjectReference{})
+)
+
+// Add adds the objects referenced by the spec of an object in a namespace. The references are
+// found by walking the spec for the object reference types of the Gateway API, whose group and
+// kind default to those of a Secret for a SecretObjectReference, and of a Service for a
+// BackendObjectReference, and whose namespace defaults to the one of the object.
+func (r *References) Add(namespace string, spec any) {
+	r.walk(namespace, reflect.ValueOf(spec))
+}
+
+func (r *References) walk(namespace string, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			r.walk(namespace, v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			r.walk(namespace, v.Index(i))
+		}
+	case reflect.Struct:
+		switch v.Type() {
+		case secretObjectReferenceType:
+			ref := v.Interface().(gwapiv1.SecretObjectReference)
+			r.insert(namespace, ref.Group, ref.Kind, ref.Namespace, ref.Name, "", KindSecret)
+			return
+		case backendObjectReferenceType:
+			ref := v.Interface().(gwapiv1.BackendObjectReference)
+			r.insert(namespace, ref.Group, ref.Kind, ref.Namespace, ref.Name, "", KindService)
+			return
+		case localObjectReferenceType:
+			ref := v.Interface().(gwapiv1.LocalObjectReference)
+			r.insert(namespace, &ref.Group, &ref.Kind, nil, ref.Name, "", "")
+			return
+		case objectReferenceType:
+			ref := v.Interface().(gwapiv1.ObjectReference)
+			r.insert(namespace, &ref.Group, &ref.Kind, ref.Namespace, ref.Name, "", "")
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				r.walk(namespace, v.Field(i))
+			}
+		}
+	}
+}
+
+func (r *References) insert(namespace string, group *gwapiv1.Group, kind *gwapiv1.Kind, refNamespace *gwapiv1.Namespace,
+	name gwapiv1.ObjectName, defaultGroup, defaultKind string,
+) {
+	g, k := defaultGroup, defaultKind
+	if group != nil {
+		g = string(*group)
+	}
+	if kind != nil {
+		k = string(*kind)
+	}
+	if refNamespace != nil {
+		namespace = string(*refNamespace)
+	}
+	nn := types.NamespacedName{Namespace: namespace, Name: string(name)}
+
+	switch {
+	case g == "" && k == KindSecret:
+		r.Secrets.Insert(nn)
+	case g == "" && k == KindConfigMap:
+		r.ConfigMaps.Insert(nn)
+	case g == "" && k == KindService:
+		r.Services.Insert(nn)
+	case g == mcsapiv1a1.GroupName && k == KindServiceImport:
+		r.ServiceImports.Insert(nn)
+	case g == egv1a1.GroupName && k == KindBackend:
+		r.Backends.Insert(nn)
+	case g == egv1a1.GroupName && k == KindHTTPRouteFilter:
+		r.HTTPRouteFilters.Insert(nn)
+	}
+}
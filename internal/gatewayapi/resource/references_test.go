@@ -0,0 +1,83 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package resource
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+func TestReferences(t *testing.T) {
+	refs := NewReferences()
+
+	refs.Add("default", gwapiv1.GatewaySpec{
+		Listeners: []gwapiv1.Listener{{
+			TLS: &gwapiv1.GatewayTLSConfig{
+				CertificateRefs: []gwapiv1.SecretObjectReference{
+					{Name: "tls"},
+					{Name: "other-tls", Namespace: ptr.To(gwapiv1.Namespace("certs"))},
+				},
+			},
+		}},
+	})
+	refs.Add("apps", gwapiv1.HTTPRouteSpec{
+		Rules: []gwapiv1.HTTPRouteRule{{
+			Filters: []gwapiv1.HTTPRouteFilter{{
+				Type: gwapiv1.HTTPRouteFilterExtensionRef,
+				ExtensionRef: &gwapiv1.LocalObjectReference{
+					Group: gwapiv1.Group(egv1a1.GroupName),
+					Kind:  KindHTTPRouteFilter,
+					Name:  "filter",
+				},
+			}},
+			BackendRefs: []gwapiv1.HTTPBackendRef{
+				{BackendRef: gwapiv1.BackendRef{BackendObjectReference: gwapiv1.BackendObjectReference{Name: "service"}}},
+				{BackendRef: gwapiv1.BackendRef{BackendObjectReference: gwapiv1.BackendObjectReference{
+					Group: ptr.To(gwapiv1.Group(egv1a1.GroupName)),
+					Kind:  ptr.To(gwapiv1.Kind(KindBackend)),
+					Name:  "backend",
+				}}},
+				{BackendRef: gwapiv1.BackendRef{BackendObjectReference: gwapiv1.BackendObjectReference{
+					Group: ptr.To(gwapiv1.Group("multicluster.x-k8s.io")),
+					Kind:  ptr.To(gwapiv1.Kind(KindServiceImport)),
+					Name:  "import",
+				}}},
+			},
+		}},
+	})
+	refs.Add("apps", &egv1a1.SecurityPolicySpec{
+		BasicAuth: &egv1a1.BasicAuth{Users: gwapiv1.SecretObjectReference{Name: "users"}},
+	})
+	refs.Add("apps", egv1a1.BackendTrafficPolicySpec{})
+
+	require.Equal(t, []types.NamespacedName{
+		{Namespace: "apps", Name: "users"},
+		{Namespace: "certs", Name: "other-tls"},
+		{Namespace: "default", Name: "tls"},
+	}, sortedNames(refs.Secrets))
+	require.Equal(t, []types.NamespacedName{{Namespace: "apps", Name: "service"}}, sortedNames(refs.Services))
+	require.Equal(t, []types.NamespacedName{{Namespace: "apps", Name: "import"}}, sortedNames(refs.ServiceImports))
+	require.Equal(t, []types.NamespacedName{{Namespace: "apps", Name: "backend"}}, sortedNames(refs.Backends))
+	require.Equal(t, []types.NamespacedName{{Namespace: "apps", Name: "filter"}}, sortedNames(refs.HTTPRouteFilters))
+	require.Empty(t, refs.ConfigMaps)
+}
+
+func sortedNames(s sets.Set[types.NamespacedName]) []types.NamespacedName {
+	names := s.UnsortedList()
+	slices.SortFunc(names, func(a, b types.NamespacedName) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	return names
+}
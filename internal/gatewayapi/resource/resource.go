@@ -160,6 +160,13 @@ func (r *Resources) GetSecret(namespace, name string) *corev1.Secret {
 	return nil
 }
 
+// RedactSecretMetadata drops the annotations and managed fields of a Secret, whose values are
+// held by the last applied configuration of kubectl, before the values of the Secret are redacted.
+func RedactSecretMetadata(secret *corev1.Secret) {
+	secret.Annotations = nil
+	secret.ManagedFields = nil
+}
+
 func (r *Resources) GetConfigMap(namespace, name string) *corev1.ConfigMap {
 	for _, configMap := range r.ConfigMaps {
 		if configMap.Namespace == namespace && configMap.Name == name {
@@ -24,7 +24,9 @@ const (
 	KindTCPRoute             = "TCPRoute"
 	KindUDPRoute             = "UDPRoute"
 	KindService              = "Service"
+	KindEndpointSlice        = "EndpointSlice"
 	KindServiceImport        = "ServiceImport"
 	KindSecret               = "Secret"
 	KindHTTPRouteFilter      = "HTTPRouteFilter"
+	KindReferenceGrant       = "ReferenceGrant"
 )
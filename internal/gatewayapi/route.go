@@ -831,6 +831,14 @@ func buildRouteMetadata(route RouteContext, sectionName *gwapiv1.SectionName) *i
 	return metadata
 }
 
+func buildBackendMetadata(backendRef gwapiv1.BackendObjectReference, backendNamespace string) *ir.ResourceMetadata {
+	return &ir.ResourceMetadata{
+		Kind:      KindDerefOr(backendRef.Kind, resource.KindService),
+		Name:      string(backendRef.Name),
+		Namespace: backendNamespace,
+	}
+}
+
 func filterEGPrefix(in map[string]string) map[string]string {
 	out := map[string]string{}
 	for k, v := range in {
@@ -1353,6 +1361,8 @@ func (t *Translator) processDestination(name string, backendRefContext BackendRe
 		return nil, err
 	}
 
+	ds.Metadata = buildBackendMetadata(backendRef.BackendObjectReference, backendNamespace)
+
 	if err := validateDestinationSettings(ds, t.IsEnvoyServiceRouting(envoyProxy), backendRef.Kind); err != nil {
 		routeStatus := GetRouteStatus(route)
 		status.SetRouteStatusCondition(routeStatus,
@@ -0,0 +1,461 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/utils"
+)
+
+// The fields of the provider resources, by the role their resources play in the translation.
+var (
+	// globalFields hold the resources that may change the translation of every Gateway.
+	globalFields = sets.New("gatewayClass", "envoyProxyForGatewayClass", "namespaces", "referenceGrants")
+	// routeFields hold the routes, which are attached to Gateways by their parentRefs.
+	routeFields = sets.New("httpRoutes", "grpcRoutes", "tlsRoutes", "tcpRoutes", "udpRoutes")
+	// policyFields hold the policies, which are attached to the resources they target.
+	policyFields = sets.New("envoyPatchPolicies", "clientTrafficPolicies", "backendTrafficPolicies", "securityPolicies",
+		"backendTLSPolicies", "envoyExtensionPolicies", "extensionServerPolicies")
+	// referrerFields hold the resources which aren't attached to Gateways, but whose references are
+	// consumed by the Gateways referencing them.
+	referrerFields = sets.New("envoyProxiesForGateways", "backends", "httpFilters", "extensionRefFilters")
+)
+
+// The labels of the EndpointSlices naming the Service or ServiceImport they belong to.
+var endpointSliceServiceLabels = []string{"kubernetes.io/service-name", "multicluster.kubernetes.io/service-name"}
+
+// objectID identifies a provider resource by the field of the provider resources holding it.
+type objectID struct {
+	field string
+	types.NamespacedName
+}
+
+func (id objectID) String() string {
+	return fmt.Sprintf("%s/%s", id.field, id.NamespacedName)
+}
+
+// indexedObject is a provider resource, as indexed to track the dependencies of the Gateways.
+type indexedObject struct {
+	id objectID
+	// ref is the namespaced name the resource is referenced by. The EndpointSlices are
+	// referenced by the namespaced name of their Service.
+	ref types.NamespacedName
+	// hash is the hash of the resource, without its status.
+	hash uint64
+	// refs are the namespaced names of the resources it references. The kind of the references
+	// is ignored, so a Gateway may depend on unrelated resources of the same name, which only
+	// costs extra translations.
+	refs []types.NamespacedName
+	// parents are the Gateways a route is attached to.
+	parents []types.NamespacedName
+	// targets are the resources a policy targets.
+	targets []types.NamespacedName
+	// selector is true if the policy selects its targets by labels.
+	selector bool
+}
+
+// gatewayDependencies are the resources consumed by the translation of a Gateway.
+type gatewayDependencies struct {
+	// refs are the namespaced names of the resources the Gateway consumes.
+	refs sets.Set[types.NamespacedName]
+	// members are the routes and policies attached to the Gateway, whose statuses depend on
+	// the translation of the Gateway.
+	members sets.Set[objectID]
+}
+
+// gatewayClassIndex tracks the dependencies of the Gateways of a GatewayClass, so a change of the
+// provider resources re-translates only the Gateways consuming the changed resources.
+type gatewayClassIndex struct {
+	objects  map[objectID]*indexedObject
+	gateways map[types.NamespacedName]*gatewayDependencies
+	// gatewaysByMember are the Gateways each route and policy is attached to.
+	gatewaysByMember map[objectID]sets.Set[types.NamespacedName]
+}
+
+// newGatewayClassIndex indexes the provider resources of a GatewayClass.
+func newGatewayClassIndex(res *resource.Resources) (*gatewayClassIndex, error) {
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	idx := &gatewayClassIndex{
+		objects:          map[objectID]*indexedObject{},
+		gateways:         map[types.NamespacedName]*gatewayDependencies{},
+		gatewaysByMember: map[objectID]sets.Set[types.NamespacedName]{},
+	}
+	for field, raw := range fields {
+		var objects []map[string]any
+		if err := json.Unmarshal(raw, &objects); err != nil {
+			// The GatewayClass and its EnvoyProxy are single resources.
+			var object map[string]any
+			if err := json.Unmarshal(raw, &object); err != nil {
+				return nil, fmt.Errorf("failed to index the %s: %w", field, err)
+			}
+			objects = []map[string]any{object}
+		}
+		for _, object := range objects {
+			o, err := newIndexedObject(field, object)
+			if err != nil {
+				return nil, err
+			}
+			idx.objects[o.id] = o
+		}
+	}
+
+	idx.indexGateways()
+	return idx, nil
+}
+
+func newIndexedObject(field string, object map[string]any) (*indexedObject, error) {
+	u := unstructured.Unstructured{Object: object}
+	o := &indexedObject{
+		id: objectID{field: field, NamespacedName: types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}},
+	}
+	o.ref = o.id.NamespacedName
+	if field == "endpointSlices" {
+		for _, label := range endpointSliceServiceLabels {
+			if service := u.GetLabels()[label]; service != "" {
+				o.ref.Name = service
+				break
+			}
+		}
+	}
+
+	// The status of the resources is written by the control plane, so it's ignored.
+	delete(object, "status")
+	unstructured.RemoveNestedField(object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(object, "metadata", "managedFields")
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	o.hash = h.Sum64()
+
+	if field == "gateways" || routeFields.Has(field) || policyFields.Has(field) || referrerFields.Has(field) {
+		spec, _ := object["spec"].(map[string]any)
+		o.refs = collectRefs(spec, u.GetNamespace(), nil)
+	}
+	if routeFields.Has(field) {
+		parentRefs, _, _ := unstructured.NestedSlice(object, "spec", "parentRefs")
+		for _, parentRef := range parentRefs {
+			ref, ok := parentRef.(map[string]any)
+			if !ok {
+				continue
+			}
+			if kind, ok := ref["kind"].(string); ok && kind != "Gateway" {
+				continue
+			}
+			if nn, ok := toNamespacedName(ref, u.GetNamespace()); ok {
+				o.parents = append(o.parents, nn)
+			}
+		}
+	}
+	if policyFields.Has(field) {
+		if targetRef, ok, _ := unstructured.NestedMap(object, "spec", "targetRef"); ok {
+			if nn, ok := toNamespacedName(targetRef, u.GetNamespace()); ok {
+				o.targets = append(o.targets, nn)
+			}
+		}
+		targetRefs, _, _ := unstructured.NestedSlice(object, "spec", "targetRefs")
+		for _, targetRef := range targetRefs {
+			if ref, ok := targetRef.(map[string]any); ok {
+				if nn, ok := toNamespacedName(ref, u.GetNamespace()); ok {
+					o.targets = append(o.targets, nn)
+				}
+			}
+		}
+		selectors, _, _ := unstructured.NestedSlice(object, "spec", "targetSelectors")
+		o.selector = len(selectors) > 0
+	}
+	return o, nil
+}
+
+// collectRefs collects the references of a resource, which are the objects of its spec with a name,
+// and an optional namespace defaulting to the namespace of the resource.
+func collectRefs(value any, namespace string, refs []types.NamespacedName) []types.NamespacedName {
+	switch v := value.(type) {
+	case map[string]any:
+		if nn, ok := toNamespacedName(v, namespace); ok {
+			refs = append(refs, nn)
+		}
+		for _, child := range v {
+			refs = collectRefs(child, namespace, refs)
+		}
+	case []any:
+		for _, child := range v {
+			refs = collectRefs(child, namespace, refs)
+		}
+	}
+	return refs
+}
+
+func toNamespacedName(ref map[string]any, namespace string) (types.NamespacedName, bool) {
+	name, ok := ref["name"].(string)
+	if !ok || name == "" {
+		return types.NamespacedName{}, false
+	}
+	if ns, ok := ref["namespace"].(string); ok && ns != "" {
+		namespace = ns
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, true
+}
+
+// indexGateways computes the dependencies of each Gateway, which are the Gateway, the routes attached
+// to it, the policies targeting any of its dependencies, and the resources they reference.
+func (idx *gatewayClassIndex) indexGateways() {
+	referrers := map[types.NamespacedName][]*indexedObject{}
+	routes := map[types.NamespacedName][]*indexedObject{}
+	var policies []*indexedObject
+	for _, o := range idx.objects {
+		switch {
+		case referrerFields.Has(o.id.field):
+			referrers[o.ref] = append(referrers[o.ref], o)
+		case routeFields.Has(o.id.field):
+			for _, parent := range o.parents {
+				routes[parent] = append(routes[parent], o)
+			}
+		case policyFields.Has(o.id.field):
+			policies = append(policies, o)
+		}
+	}
+
+	for id, gateway := range idx.objects {
+		if id.field != "gateways" {
+			continue
+		}
+		deps := &gatewayDependencies{refs: sets.New[types.NamespacedName](), members: sets.New[objectID]()}
+		visited := sets.New[objectID]()
+		var consume func(o *indexedObject)
+		consume = func(o *indexedObject) {
+			if visited.Has(o.id) {
+				return
+			}
+			visited.Insert(o.id)
+			deps.refs.Insert(o.ref)
+			for _, ref := range o.refs {
+				deps.refs.Insert(ref)
+				for _, referrer := range referrers[ref] {
+					consume(referrer)
+				}
+			}
+		}
+
+		consume(gateway)
+		for _, route := range routes[id.NamespacedName] {
+			deps.members.Insert(route.id)
+			consume(route)
+		}
+		// Attach the policies until no policy targets the new dependencies.
+		for attached := true; attached; {
+			attached = false
+			for _, policy := range policies {
+				if deps.members.Has(policy.id) {
+					continue
+				}
+				// The policies selecting their targets by labels are attached to every Gateway.
+				if policy.selector || slices.ContainsFunc(policy.targets, deps.refs.Has) {
+					deps.members.Insert(policy.id)
+					consume(policy)
+					attached = true
+				}
+			}
+		}
+
+		idx.gateways[id.NamespacedName] = deps
+		for member := range deps.members {
+			if idx.gatewaysByMember[member] == nil {
+				idx.gatewaysByMember[member] = sets.New[types.NamespacedName]()
+			}
+			idx.gatewaysByMember[member].Insert(id.NamespacedName)
+		}
+	}
+}
+
+// changedObjects returns the resources added, deleted or updated since the previous index.
+func (idx *gatewayClassIndex) changedObjects(prev *gatewayClassIndex) []*indexedObject {
+	var changed []*indexedObject
+	for id, o := range idx.objects {
+		if p, ok := prev.objects[id]; !ok || p.hash != o.hash {
+			changed = append(changed, o)
+		}
+	}
+	for id, p := range prev.objects {
+		if _, ok := idx.objects[id]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	return changed
+}
+
+// affectedGateways returns the Gateways whose translation may be changed by the resources changed
+// since the previous index, and the changed routes and policies. The Gateways sharing routes or
+// policies with an affected Gateway are affected too, so the statuses of the shared routes and
+// policies are complete. All the Gateways must be translated if full is true.
+func (idx *gatewayClassIndex) affectedGateways(prev *gatewayClassIndex) (affected sets.Set[types.NamespacedName], members sets.Set[objectID], full bool) {
+	affected = sets.New[types.NamespacedName]()
+	members = sets.New[objectID]()
+	for _, o := range idx.changedObjects(prev) {
+		if globalFields.Has(o.id.field) {
+			return nil, nil, true
+		}
+		if routeFields.Has(o.id.field) || policyFields.Has(o.id.field) {
+			members.Insert(o.id)
+			// A policy which isn't attached to any Gateway may still get a status.
+			if policyFields.Has(o.id.field) && idx.gatewaysByMember[o.id].Len() == 0 && prev.gatewaysByMember[o.id].Len() == 0 {
+				return nil, nil, true
+			}
+		}
+		for _, index := range []*gatewayClassIndex{prev, idx} {
+			for gateway, deps := range index.gateways {
+				if deps.refs.Has(o.ref) {
+					affected.Insert(gateway)
+				}
+			}
+		}
+	}
+
+	for expanded := true; expanded; {
+		expanded = false
+		for gateway := range affected {
+			for _, index := range []*gatewayClassIndex{prev, idx} {
+				deps, ok := index.gateways[gateway]
+				if !ok {
+					continue
+				}
+				for member := range deps.members {
+					for _, other := range []*gatewayClassIndex{prev, idx} {
+						for shared := range other.gatewaysByMember[member] {
+							if !affected.Has(shared) {
+								affected.Insert(shared)
+								expanded = true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return affected, members, false
+}
+
+// incrementalResources returns the provider resources to translate to re-translate the affected
+// Gateways: the affected Gateways, the routes and policies attached to them or changed, and all the
+// other resources, which are only consumed through references.
+func (idx *gatewayClassIndex) incrementalResources(res *resource.Resources, affected sets.Set[types.NamespacedName],
+	changed sets.Set[objectID],
+) *resource.Resources {
+	members := changed.Clone()
+	for gateway := range affected {
+		if deps, ok := idx.gateways[gateway]; ok {
+			members = members.Union(deps.members)
+		}
+	}
+	isMember := func(field string) func(o client.Object) bool {
+		return func(o client.Object) bool {
+			return members.Has(objectID{field: field, NamespacedName: utils.NamespacedName(o)})
+		}
+	}
+
+	sub := *res
+	sub.Gateways = filterObjects(res.Gateways, func(o client.Object) bool { return affected.Has(utils.NamespacedName(o)) })
+	sub.HTTPRoutes = filterObjects(res.HTTPRoutes, isMember("httpRoutes"))
+	sub.GRPCRoutes = filterObjects(res.GRPCRoutes, isMember("grpcRoutes"))
+	sub.TLSRoutes = filterObjects(res.TLSRoutes, isMember("tlsRoutes"))
+	sub.TCPRoutes = filterObjects(res.TCPRoutes, isMember("tcpRoutes"))
+	sub.UDPRoutes = filterObjects(res.UDPRoutes, isMember("udpRoutes"))
+	sub.EnvoyPatchPolicies = filterObjects(res.EnvoyPatchPolicies, isMember("envoyPatchPolicies"))
+	sub.ClientTrafficPolicies = filterObjects(res.ClientTrafficPolicies, isMember("clientTrafficPolicies"))
+	sub.BackendTrafficPolicies = filterObjects(res.BackendTrafficPolicies, isMember("backendTrafficPolicies"))
+	sub.SecurityPolicies = filterObjects(res.SecurityPolicies, isMember("securityPolicies"))
+	sub.BackendTLSPolicies = filterObjects(res.BackendTLSPolicies, isMember("backendTLSPolicies"))
+	sub.EnvoyExtensionPolicies = filterObjects(res.EnvoyExtensionPolicies, isMember("envoyExtensionPolicies"))
+	sub.ExtensionServerPolicies = nil
+	for i := range res.ExtensionServerPolicies {
+		if isMember("extensionServerPolicies")(&res.ExtensionServerPolicies[i]) {
+			sub.ExtensionServerPolicies = append(sub.ExtensionServerPolicies, res.ExtensionServerPolicies[i])
+		}
+	}
+	return &sub
+}
+
+func filterObjects[T client.Object](objects []T, keep func(o client.Object) bool) []T {
+	var kept []T
+	for _, o := range objects {
+		if keep(o) {
+			kept = append(kept, o)
+		}
+	}
+	return kept
+}
+
+// retainStatuses keeps the statuses of the Gateways, routes and policies of the provider resources
+// which aren't re-translated.
+func (s *StatusesToDelete) retainStatuses(res, translated *resource.Resources) {
+	retain(s.GatewayStatusKeys, res.Gateways, translated.Gateways)
+	retain(s.HTTPRouteStatusKeys, res.HTTPRoutes, translated.HTTPRoutes)
+	retain(s.GRPCRouteStatusKeys, res.GRPCRoutes, translated.GRPCRoutes)
+	retain(s.TLSRouteStatusKeys, res.TLSRoutes, translated.TLSRoutes)
+	retain(s.TCPRouteStatusKeys, res.TCPRoutes, translated.TCPRoutes)
+	retain(s.UDPRouteStatusKeys, res.UDPRoutes, translated.UDPRoutes)
+	retain(s.ClientTrafficPolicyStatusKeys, res.ClientTrafficPolicies, translated.ClientTrafficPolicies)
+	retain(s.BackendTrafficPolicyStatusKeys, res.BackendTrafficPolicies, translated.BackendTrafficPolicies)
+	retain(s.SecurityPolicyStatusKeys, res.SecurityPolicies, translated.SecurityPolicies)
+	retain(s.BackendTLSPolicyStatusKeys, res.BackendTLSPolicies, translated.BackendTLSPolicies)
+	retain(s.EnvoyExtensionPolicyStatusKeys, res.EnvoyExtensionPolicies, translated.EnvoyExtensionPolicies)
+	for i := range res.ExtensionServerPolicies {
+		policy := &res.ExtensionServerPolicies[i]
+		if !slices.ContainsFunc(translated.ExtensionServerPolicies, func(u unstructured.Unstructured) bool {
+			return utils.NamespacedName(&u) == utils.NamespacedName(policy) && u.GroupVersionKind() == policy.GroupVersionKind()
+		}) {
+			delete(s.ExtensionServerPolicyStatusKeys, message.NamespacedNameAndGVK{
+				NamespacedName:   utils.NamespacedName(policy),
+				GroupVersionKind: policy.GroupVersionKind(),
+			})
+		}
+	}
+}
+
+func retain[T client.Object](keys map[types.NamespacedName]bool, objects, translated []T) {
+	translatedKeys := sets.New[types.NamespacedName]()
+	for _, o := range translated {
+		translatedKeys.Insert(utils.NamespacedName(o))
+	}
+	for _, o := range objects {
+		if key := utils.NamespacedName(o); !translatedKeys.Has(key) {
+			delete(keys, key)
+		}
+	}
+}
+
+// gatewayIRKeys returns the IR keys of the Gateways which aren't re-translated.
+func gatewayIRKeys(res *resource.Resources, affected sets.Set[types.NamespacedName]) []string {
+	var keys []string
+	for _, gateway := range res.Gateways {
+		if !affected.Has(utils.NamespacedName(gateway)) {
+			keys = append(keys, gatewayapi.GetIRKey(gateway, false))
+		}
+	}
+	return keys
+}
@@ -0,0 +1,300 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+)
+
+const testControllerName = "gateway.envoyproxy.io/gatewayclass-controller"
+
+func newTestGateway(name string) *gwapiv1.Gateway {
+	return &gwapiv1.Gateway{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gwapiv1.GroupVersion.String(), Kind: resource.KindGateway},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: gwapiv1.GatewaySpec{
+			GatewayClassName: "eg",
+			Listeners: []gwapiv1.Listener{{
+				Name:     "http",
+				Protocol: gwapiv1.HTTPProtocolType,
+				Port:     80,
+				AllowedRoutes: &gwapiv1.AllowedRoutes{
+					Namespaces: &gwapiv1.RouteNamespaces{From: ptr.To(gwapiv1.NamespacesFromSame)},
+				},
+			}},
+		},
+	}
+}
+
+func newTestHTTPRoute(name, service string, gateways ...string) *gwapiv1.HTTPRoute {
+	route := &gwapiv1.HTTPRoute{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gwapiv1.GroupVersion.String(), Kind: resource.KindHTTPRoute},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: gwapiv1.HTTPRouteSpec{
+			Rules: []gwapiv1.HTTPRouteRule{{
+				BackendRefs: []gwapiv1.HTTPBackendRef{{
+					BackendRef: gwapiv1.BackendRef{
+						BackendObjectReference: gwapiv1.BackendObjectReference{
+							Name: gwapiv1.ObjectName(service),
+							Port: ptr.To(gwapiv1.PortNumber(8080)),
+						},
+					},
+				}},
+			}},
+		},
+	}
+	for _, gateway := range gateways {
+		route.Spec.ParentRefs = append(route.Spec.ParentRefs, gwapiv1.ParentReference{Name: gwapiv1.ObjectName(gateway)})
+	}
+	return route
+}
+
+func newTestService(name, ip string) (*corev1.Service, *discoveryv1.EndpointSlice) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "10.0.0.1",
+			Ports:     []corev1.ServicePort{{Name: "http", Port: 8080, Protocol: corev1.ProtocolTCP}},
+		},
+	}
+	endpointSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name + "-abcde",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: name},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{ip}}},
+		Ports:       []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To[int32](8080), Protocol: ptr.To(corev1.ProtocolTCP)}},
+	}
+	return service, endpointSlice
+}
+
+// newTestResources returns the resources of two Gateways, each with a route to its own Service.
+func newTestResources() *resource.Resources {
+	res := resource.NewResources()
+	res.GatewayClass = &gwapiv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "eg"},
+		Spec:       gwapiv1.GatewayClassSpec{ControllerName: testControllerName},
+	}
+	res.Namespaces = []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "default"}}}
+	res.Gateways = []*gwapiv1.Gateway{newTestGateway("gateway-1"), newTestGateway("gateway-2")}
+	res.HTTPRoutes = []*gwapiv1.HTTPRoute{
+		newTestHTTPRoute("route-1", "service-1", "gateway-1"),
+		newTestHTTPRoute("route-2", "service-2", "gateway-2"),
+	}
+	for i, name := range []string{"service-1", "service-2"} {
+		service, endpointSlice := newTestService(name, "10.1.0."+string(rune('1'+i)))
+		res.Services = append(res.Services, service)
+		res.EndpointSlices = append(res.EndpointSlices, endpointSlice)
+	}
+	res.Secrets = []*corev1.Secret{{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unused"}}}
+	return res
+}
+
+func TestAffectedGateways(t *testing.T) {
+	gateway1 := types.NamespacedName{Namespace: "default", Name: "gateway-1"}
+	gateway2 := types.NamespacedName{Namespace: "default", Name: "gateway-2"}
+
+	testCases := []struct {
+		name     string
+		update   func(res *resource.Resources)
+		full     bool
+		affected []types.NamespacedName
+	}{
+		{
+			name:   "unreferenced secret",
+			update: func(res *resource.Resources) { res.Secrets[0].Data = map[string][]byte{"key": []byte("value")} },
+		},
+		{
+			name:   "status only",
+			update: func(res *resource.Resources) { res.HTTPRoutes[0].Status.Parents = []gwapiv1.RouteParentStatus{{}} },
+		},
+		{
+			name:     "service",
+			update:   func(res *resource.Resources) { res.Services[0].Spec.Ports[0].Port = 9090 },
+			affected: []types.NamespacedName{gateway1},
+		},
+		{
+			name:     "endpoint slice",
+			update:   func(res *resource.Resources) { res.EndpointSlices[1].Endpoints[0].Addresses = []string{"10.1.0.9"} },
+			affected: []types.NamespacedName{gateway2},
+		},
+		{
+			name: "route moved to another gateway",
+			update: func(res *resource.Resources) {
+				res.HTTPRoutes[0].Spec.ParentRefs[0].Name = "gateway-2"
+			},
+			affected: []types.NamespacedName{gateway1, gateway2},
+		},
+		{
+			name:     "deleted gateway",
+			update:   func(res *resource.Resources) { res.Gateways = res.Gateways[1:] },
+			affected: []types.NamespacedName{gateway1},
+		},
+		{
+			name: "policy targeting a route",
+			update: func(res *resource.Resources) {
+				res.BackendTrafficPolicies = append(res.BackendTrafficPolicies, &egv1a1.BackendTrafficPolicy{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+					Spec: egv1a1.BackendTrafficPolicySpec{
+						PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+							TargetRefs: []gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{{
+								LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+									Group: gwapiv1.GroupName, Kind: "HTTPRoute", Name: "route-2",
+								},
+							}},
+						},
+					},
+				})
+			},
+			affected: []types.NamespacedName{gateway2},
+		},
+		{
+			name: "policy without target",
+			update: func(res *resource.Resources) {
+				res.ClientTrafficPolicies = append(res.ClientTrafficPolicies, &egv1a1.ClientTrafficPolicy{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "policy"},
+					Spec: egv1a1.ClientTrafficPolicySpec{
+						PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+							TargetRefs: []gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{{
+								LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+									Group: gwapiv1.GroupName, Kind: "Gateway", Name: "missing",
+								},
+							}},
+						},
+					},
+				})
+			},
+			full: true,
+		},
+		{
+			name: "namespace",
+			update: func(res *resource.Resources) {
+				res.Namespaces[0].Labels = map[string]string{"team": "a"}
+			},
+			full: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prev, err := newGatewayClassIndex(newTestResources())
+			require.NoError(t, err)
+			res := newTestResources()
+			tc.update(res)
+			idx, err := newGatewayClassIndex(res)
+			require.NoError(t, err)
+
+			affected, _, full := idx.affectedGateways(prev)
+			require.Equal(t, tc.full, full)
+			if !tc.full {
+				require.Equal(t, sets.New(tc.affected...), affected)
+			}
+		})
+	}
+}
+
+func TestAffectedGatewaysSharedRoute(t *testing.T) {
+	newResources := func() *resource.Resources {
+		res := newTestResources()
+		res.HTTPRoutes = append(res.HTTPRoutes, newTestHTTPRoute("route-3", "service-2", "gateway-2", "gateway-3"))
+		res.Gateways = append(res.Gateways, newTestGateway("gateway-3"))
+		return res
+	}
+	prev, err := newGatewayClassIndex(newResources())
+	require.NoError(t, err)
+	res := newResources()
+	res.Services[1].Spec.Ports[0].Port = 9090
+	idx, err := newGatewayClassIndex(res)
+	require.NoError(t, err)
+
+	// Gateway 3 shares route 3 with gateway 2, whose Service changed.
+	affected, _, full := idx.affectedGateways(prev)
+	require.False(t, full)
+	require.Equal(t, sets.New(
+		types.NamespacedName{Namespace: "default", Name: "gateway-2"},
+		types.NamespacedName{Namespace: "default", Name: "gateway-3"},
+	), affected)
+}
+
+func TestIncrementalTranslation(t *testing.T) {
+	translate := func(res *resource.Resources) *gatewayapi.TranslateResult {
+		translator := &gatewayapi.Translator{
+			GatewayControllerName: testControllerName,
+			GatewayClassName:      "eg",
+			Namespace:             "envoy-gateway-system",
+		}
+		result, _ := translator.Translate(res)
+		return result
+	}
+
+	prev, err := newGatewayClassIndex(newTestResources())
+	require.NoError(t, err)
+	res := newTestResources()
+	res.EndpointSlices[0].Endpoints[0].Addresses = []string{"10.1.0.9"}
+	idx, err := newGatewayClassIndex(res)
+	require.NoError(t, err)
+	affected, changed, full := idx.affectedGateways(prev)
+	require.False(t, full)
+
+	// Only the affected Gateway and its route are translated.
+	translated := idx.incrementalResources(res, affected, changed)
+	require.Len(t, translated.Gateways, 1)
+	require.Equal(t, "gateway-1", translated.Gateways[0].Name)
+	require.Len(t, translated.HTTPRoutes, 1)
+	require.Equal(t, "route-1", translated.HTTPRoutes[0].Name)
+	require.Len(t, translated.Services, 2)
+
+	// The IR and statuses of the affected Gateway are the same as with a full translation.
+	// The translation updates the resources, so a copy is translated.
+	all := translate(res.DeepCopy())
+	incremental := translate(translated)
+	require.Len(t, incremental.XdsIR, 1)
+	require.Equal(t, all.XdsIR["default/gateway-1"], incremental.XdsIR["default/gateway-1"])
+	require.Equal(t, all.InfraIR["default/gateway-1"], incremental.InfraIR["default/gateway-1"])
+	require.Len(t, incremental.XdsIR["default/gateway-1"].HTTP[0].Routes, 1)
+	require.Equal(t, conditionReasons(all.HTTPRoutes[0].Status.Parents[0].Conditions),
+		conditionReasons(incremental.HTTPRoutes[0].Status.Parents[0].Conditions))
+
+	// The IR keys and statuses of the other Gateway and its route are kept.
+	require.Equal(t, []string{"default/gateway-2"}, gatewayIRKeys(res, affected))
+	statuses := &StatusesToDelete{
+		GatewayStatusKeys: map[types.NamespacedName]bool{
+			{Namespace: "default", Name: "gateway-1"}: true,
+			{Namespace: "default", Name: "gateway-2"}: true,
+		},
+		HTTPRouteStatusKeys: map[types.NamespacedName]bool{
+			{Namespace: "default", Name: "route-1"}: true,
+			{Namespace: "default", Name: "route-2"}: true,
+		},
+	}
+	statuses.retainStatuses(res, translated)
+	require.Equal(t, map[types.NamespacedName]bool{{Namespace: "default", Name: "gateway-1"}: true}, statuses.GatewayStatusKeys)
+	require.Equal(t, map[types.NamespacedName]bool{{Namespace: "default", Name: "route-1"}: true}, statuses.HTTPRouteStatusKeys)
+}
+
+func conditionReasons(conditions []metav1.Condition) map[string]string {
+	reasons := map[string]string{}
+	for _, condition := range conditions {
+		reasons[condition.Type] = condition.Reason
+	}
+	return reasons
+}
@@ -0,0 +1,18 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import "github.com/envoyproxy/gateway/internal/metrics"
+
+var (
+	gatewayAPITranslationDurationSeconds = metrics.NewHistogram(
+		"gatewayapi_translation_duration_seconds",
+		"How long in seconds the Gateway API resources of a GatewayClass take to be translated to IR.",
+		[]float64{0.001, 0.01, 0.1, 1, 5, 10, 30},
+	)
+
+	gatewayClassLabel = metrics.NewLabel("gatewayClass")
+)
@@ -13,6 +13,7 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"time"
 
 	"github.com/docker/docker/pkg/fileutils"
 	"github.com/telepresenceio/watchable"
@@ -33,6 +34,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/tracing"
 	"github.com/envoyproxy/gateway/internal/utils"
 	"github.com/envoyproxy/gateway/internal/wasm"
 )
@@ -43,15 +45,8 @@ const (
 	serveTLSKeyFilepath  = "/certs/tls.key"
 	serveTLSCaFilepath   = "/certs/ca.crt"
 
-	// TODO: Make these path configurable.
-	// Default certificates path for envoy-gateway with Host infrastructure provider.
-	localTLSCertFilepath = "/tmp/envoy-gateway/certs/envoy-gateway/tls.crt"
-	localTLSKeyFilepath  = "/tmp/envoy-gateway/certs/envoy-gateway/tls.key"
-	localTLSCaFilepath   = "/tmp/envoy-gateway/certs/envoy-gateway/ca.crt"
-
 	hmacSecretName = "envoy-oidc-hmac" // nolint: gosec
 	hmacSecretKey  = "hmac-secret"
-	hmacSecretPath = "/tmp/envoy-gateway/certs/envoy-oidc-hmac/hmac-secret" // nolint: gosec
 )
 
 type Config struct {
@@ -65,6 +60,9 @@ type Config struct {
 type Runner struct {
 	Config
 	wasmCache wasm.Cache
+	// gatewayClassIndexes track the dependencies of the Gateways of each GatewayClass at their
+	// last translation, so only the Gateways affected by a change are re-translated.
+	gatewayClassIndexes map[string]*gatewayClassIndex
 }
 
 func New(cfg *Config) *Runner {
@@ -128,11 +126,16 @@ func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *re
 		func(update message.Update[string, *resource.ControllerResources], errChan chan error) {
 			r.Logger.Info("received an update")
 			val := update.Value
+			ctx, span := tracing.Start(tracing.Subscribe(context.Background(), tracing.ProviderResourcesMessage, update.Key),
+				"gatewayapi-translate", tracing.ControllerKey.String(update.Key))
+			defer span.End()
+
 			// There is only 1 key which is the controller name
 			// so when a delete is triggered, delete all IR keys
 			if update.Delete || val == nil {
 				r.deleteAllIRKeys()
 				r.deleteAllStatusKeys()
+				r.gatewayClassIndexes = nil
 				return
 			}
 
@@ -149,7 +152,24 @@ func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *re
 			// Remaining keys will be deleted from watchable before we exit this function.
 			statusesToDelete := r.getAllStatuses()
 
+			gatewayClassIndexes := make(map[string]*gatewayClassIndex, len(*val))
 			for _, resources := range *val {
+				gcCtx, gcSpan := tracing.Start(ctx, "gatewayapi-translate-gateway-class",
+					tracing.GatewayClassKey.String(resources.GatewayClass.Name))
+				start := time.Now()
+
+				// Only re-translate the Gateways affected by the changes since the last translation,
+				// and keep the IRs and statuses of the others.
+				index, translated, affected := r.resourcesToTranslate(resources)
+				gatewayClassIndexes[resources.GatewayClass.Name] = index
+				gcSpan.SetAttributes(tracing.GatewaysKey.Int(len(translated.Gateways)))
+				if affected != nil {
+					r.Logger.Info("translating the affected gateways", "gatewayClass", resources.GatewayClass.Name,
+						"affected", len(translated.Gateways), "total", len(resources.Gateways))
+					newIRKeys = append(newIRKeys, gatewayIRKeys(resources, affected)...)
+					statusesToDelete.retainStatuses(resources, translated)
+				}
+
 				// Translate and publish IRs.
 				t := &gatewayapi.Translator{
 					GatewayControllerName:     r.Server.EnvoyGateway.Gateway.ControllerName,
@@ -163,21 +183,24 @@ func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *re
 					ListenerPortShiftDisabled: r.EnvoyGateway.Provider != nil && r.EnvoyGateway.Provider.IsRunningOnHost(),
 				}
 
-				// If an extension is loaded, pass its supported groups/kinds to the translator
-				if r.EnvoyGateway.ExtensionManager != nil {
+				// If extensions are loaded, pass their supported groups/kinds to the translator
+				if extensionManagers := r.EnvoyGateway.GetExtensionManagers(); len(extensionManagers) > 0 {
 					var extGKs []schema.GroupKind
-					for _, gvk := range r.EnvoyGateway.ExtensionManager.Resources {
-						extGKs = append(extGKs, schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
+					for _, extensionManager := range extensionManagers {
+						for _, gvk := range extensionManager.Resources {
+							extGKs = append(extGKs, schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
+						}
 					}
 					t.ExtensionGroupKinds = extGKs
 					r.Logger.Info("extension resources", "GVKs count", len(extGKs))
 				}
 				// Translate to IR
-				result, err := t.Translate(resources)
+				result, err := t.Translate(translated)
 				if err != nil {
 					// Currently all errors that Translate returns should just be logged
 					r.Logger.Error(err, "errors detected during translation")
 				}
+				gatewayAPITranslationDurationSeconds.With(gatewayClassLabel.Value(resources.GatewayClass.Name)).Record(time.Since(start).Seconds())
 
 				// Publish the IRs.
 				// Also validate the ir before sending it.
@@ -192,15 +215,20 @@ func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *re
 					}
 				}
 
+				irKeys := make([]string, 0, len(result.XdsIR))
 				for key, val := range result.XdsIR {
 					r.Logger.V(1).WithValues("xds-ir", key).Info(val.JSONString())
 					if err := val.Validate(); err != nil {
 						r.Logger.Error(err, "unable to validate xds ir, skipped sending it")
 						errChan <- err
 					} else {
+						tracing.Publish(gcCtx, tracing.XdsIRMessage, key)
 						r.XdsIR.Store(key, val)
+						irKeys = append(irKeys, key)
 					}
 				}
+				gcSpan.SetAttributes(tracing.IRKeysKey.StringSlice(irKeys))
+				tracing.End(gcSpan, err)
 
 				// Update Status
 				for _, gateway := range result.Gateways {
@@ -294,12 +322,15 @@ func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *re
 				}
 			}
 
+			r.gatewayClassIndexes = gatewayClassIndexes
+
 			// Delete IR keys
 			// There is a 1:1 mapping between infra and xds IR keys
 			delKeys := getIRKeysToDelete(curIRKeys, newIRKeys)
 			for _, key := range delKeys {
 				r.InfraIR.Delete(key)
 				r.XdsIR.Delete(key)
+				tracing.Forget(tracing.XdsIRMessage, key)
 			}
 
 			// Delete status keys
@@ -323,12 +354,14 @@ func (r *Runner) loadTLSConfig(ctx context.Context) (tlsConfig *tls.Config, salt
 		}
 
 	case r.EnvoyGateway.Provider.IsRunningOnHost():
-		salt, err = os.ReadFile(hmacSecretPath)
+		certsDir := r.EnvoyGateway.Provider.GetHostInfrastructureProvider().GetCertificatesDir()
+		certPaths := crypto.GetLocalCertPaths(certsDir, crypto.LocalEnvoyGatewayDir)
+		salt, err = os.ReadFile(crypto.GetLocalOIDCHMACSecretPath(certsDir))
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get hmac secret: %w", err)
 		}
 
-		tlsConfig, err = crypto.LoadTLSConfig(localTLSCertFilepath, localTLSKeyFilepath, localTLSCaFilepath)
+		tlsConfig, err = crypto.LoadTLSConfig(certPaths.TLSCert, certPaths.TLSKey, certPaths.CACert)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create tls config: %w", err)
 		}
@@ -349,11 +382,39 @@ func unstructuredToPolicyStatus(policyStatus map[string]any) gwapiv1a2.PolicySta
 	return ret
 }
 
+// resourcesToTranslate returns the index of the provider resources of a GatewayClass, and the resources
+// to translate. If the changes since the last translation can be tracked, only the resources of the
+// affected Gateways are translated, and the affected Gateways are returned.
+func (r *Runner) resourcesToTranslate(resources *resource.Resources) (*gatewayClassIndex, *resource.Resources, sets.Set[types.NamespacedName]) {
+	index, err := newGatewayClassIndex(resources)
+	if err != nil {
+		r.Logger.Error(err, "failed to index the resources, translating all the gateways", "gatewayClass", resources.GatewayClass.Name)
+		return nil, resources, nil
+	}
+
+	prev := r.gatewayClassIndexes[resources.GatewayClass.Name]
+	// The merged Gateways are translated into a single IR.
+	if prev == nil || gatewayapi.IsMergeGatewaysEnabled(resources) {
+		return index, resources, nil
+	}
+	affected, changed, full := index.affectedGateways(prev)
+	if full || len(index.gateways) == 0 {
+		return index, resources, nil
+	}
+	for gateway := range index.gateways {
+		if !affected.Has(gateway) {
+			return index, index.incrementalResources(resources, affected, changed), affected
+		}
+	}
+	return index, resources, nil
+}
+
 // deleteAllIRKeys deletes all XdsIR and InfraIR
 func (r *Runner) deleteAllIRKeys() {
 	for key := range r.InfraIR.LoadAll() {
 		r.InfraIR.Delete(key)
 		r.XdsIR.Delete(key)
+		tracing.Forget(tracing.XdsIRMessage, key)
 	}
 }
 
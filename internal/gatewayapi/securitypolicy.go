@@ -45,9 +45,12 @@ const (
 	defaultForwardAccessToken = false
 	defaultRefreshToken       = false
 
+	// OIDCHMACSecretName is the name of the Secret of the HMAC key of the OIDC authentication,
+	// which Envoy Gateway generates in its namespace, and OIDCHMACSecretKey the key of the HMAC
+	// key in the Secret.
 	// nolint: gosec
-	oidcHMACSecretName = "envoy-oidc-hmac"
-	oidcHMACSecretKey  = "hmac-secret"
+	OIDCHMACSecretName = "envoy-oidc-hmac"
+	OIDCHMACSecretKey  = "hmac-secret"
 )
 
 func (t *Translator) ProcessSecurityPolicies(securityPolicies []*egv1a1.SecurityPolicy,
@@ -843,14 +846,14 @@ func (t *Translator) buildOIDC(
 	// HMAC secret is generated by the CertGen job and stored in a secret
 	// We need to rotate the HMAC secret in the future, probably the same
 	// way we rotate the certs generated by the CertGen job.
-	hmacSecret := resources.GetSecret(t.Namespace, oidcHMACSecretName)
+	hmacSecret := resources.GetSecret(t.Namespace, OIDCHMACSecretName)
 	if hmacSecret == nil {
-		return nil, fmt.Errorf("HMAC secret %s/%s not found", t.Namespace, oidcHMACSecretName)
+		return nil, fmt.Errorf("HMAC secret %s/%s not found", t.Namespace, OIDCHMACSecretName)
 	}
-	hmacData, ok := hmacSecret.Data[oidcHMACSecretKey]
+	hmacData, ok := hmacSecret.Data[OIDCHMACSecretKey]
 	if !ok || len(hmacData) == 0 {
 		return nil, fmt.Errorf(
-			"HMAC secret not found in secret %s/%s", t.Namespace, oidcHMACSecretName)
+			"HMAC secret not found in secret %s/%s", t.Namespace, OIDCHMACSecretName)
 	}
 
 	return &ir.OIDC{
@@ -8,6 +8,7 @@ package status
 import (
 	"fmt"
 	"time"
+	"unicode/utf8"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -116,6 +117,24 @@ func UpdateGatewayStatusProgrammedCondition(gw *gwapiv1.Gateway, svc *corev1.Ser
 	updateGatewayProgrammedCondition(gw, envoyObj)
 }
 
+// UpdateGatewayStatusXdsRejected sets the Programmed condition of the Gateway to false
+// because the Envoy proxies rejected its xDS configuration, the message describes the
+// rejected updates.
+func UpdateGatewayStatusXdsRejected(gw *gwapiv1.Gateway, message string) {
+	// The message of a condition is limited to 32768 characters, it is cut on a rune boundary
+	// so that it stays valid UTF-8.
+	if len(message) > maxConditionMessageLength {
+		end := maxConditionMessageLength
+		for end > 0 && !utf8.RuneStart(message[end]) {
+			end--
+		}
+		message = message[:end]
+	}
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions,
+		newCondition(string(gwapiv1.GatewayConditionProgrammed), metav1.ConditionFalse, string(gwapiv1.GatewayReasonInvalid),
+			message, time.Now(), gw.Generation))
+}
+
 func SetGatewayListenerStatusCondition(gateway *gwapiv1.Gateway, listenerStatusIdx int,
 	conditionType gwapiv1.ListenerConditionType, status metav1.ConditionStatus, reason gwapiv1.ListenerConditionReason, message string,
 ) {
@@ -135,6 +154,8 @@ const (
 	messageFmtTooManyAddresses = "Too many addresses (%d) have been assigned to the Gateway, the maximum number of addresses is 16"
 	messageNoResources         = "Envoy replicas unavailable"
 	messageFmtProgrammed       = "Address assigned to the Gateway, %d/%d envoy replicas available"
+
+	maxConditionMessageLength = 32768
 )
 
 // updateGatewayProgrammedCondition computes the Gateway Programmed status condition.
@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -334,3 +336,31 @@ func TestUpdateGatewayProgrammedCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateGatewayStatusXdsRejected(t *testing.T) {
+	gtw := &gwapiv1.Gateway{}
+	gtw.Generation = 2
+	updateGatewayProgrammedCondition(gtw, &appsv1.Deployment{Status: appsv1.DeploymentStatus{AvailableReplicas: 1}})
+	UpdateGatewayStatusXdsRejected(gtw, "rejected")
+
+	expectCondition := []metav1.Condition{
+		{
+			Type:               string(gwapiv1.GatewayConditionProgrammed),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(gwapiv1.GatewayReasonInvalid),
+			Message:            "rejected",
+			ObservedGeneration: 2,
+		},
+	}
+	if d := cmp.Diff(expectCondition, gtw.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); d != "" {
+		t.Errorf("unexpected condition diff: %s", d)
+	}
+
+	UpdateGatewayStatusXdsRejected(gtw, strings.Repeat("a", maxConditionMessageLength+1))
+	assert.Len(t, gtw.Status.Conditions[0].Message, maxConditionMessageLength)
+
+	// A multi-byte rune that crosses the limit is dropped rather than split.
+	UpdateGatewayStatusXdsRejected(gtw, strings.Repeat("a", maxConditionMessageLength-1)+"é")
+	assert.True(t, utf8.ValidString(gtw.Status.Conditions[0].Message))
+	assert.Equal(t, strings.Repeat("a", maxConditionMessageLength-1), gtw.Status.Conditions[0].Message)
+}
@@ -42,12 +42,6 @@ const (
 	rateLimitTLSKeyFilepath = "/certs/tls.key"
 	// rateLimitTLSCACertFilepath is the ratelimit ca cert file.
 	rateLimitTLSCACertFilepath = "/certs/ca.crt"
-
-	// TODO: Make these path configurable.
-	// Default certificates path for envoy-gateway with Host infrastructure provider.
-	localTLSCertFilepath = "/tmp/envoy-gateway/certs/envoy-gateway/tls.crt"
-	localTLSKeyFilepath  = "/tmp/envoy-gateway/certs/envoy-gateway/tls.key"
-	localTLSCaFilepath   = "/tmp/envoy-gateway/certs/envoy-gateway/ca.crt"
 )
 
 type Config struct {
@@ -221,7 +215,9 @@ func (r *Runner) loadTLSConfig() (tlsConfig *tls.Config, err error) {
 		}
 
 	case r.EnvoyGateway.Provider.IsRunningOnHost():
-		tlsConfig, err = crypto.LoadTLSConfig(localTLSCertFilepath, localTLSKeyFilepath, localTLSCaFilepath)
+		certsDir := r.EnvoyGateway.Provider.GetHostInfrastructureProvider().GetCertificatesDir()
+		certPaths := crypto.GetLocalCertPaths(certsDir, crypto.LocalEnvoyGatewayDir)
+		tlsConfig, err = crypto.LoadTLSConfig(certPaths.TLSCert, certPaths.TLSKey, certPaths.CACert)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create tls config: %w", err)
 		}
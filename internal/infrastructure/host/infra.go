@@ -8,10 +8,13 @@ package host
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sync"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/infrastructure/common"
 	"github.com/envoyproxy/gateway/internal/logging"
@@ -19,9 +22,8 @@ import (
 )
 
 const (
-	// TODO: Make these path configurable.
-	defaultHomeDir          = "/tmp/envoy-gateway"
-	defaultLocalCertPathDir = "/tmp/envoy-gateway/certs/envoy"
+	// TODO: Make this path configurable.
+	defaultHomeDir = "/tmp/envoy-gateway"
 
 	// XdsTLSCertFilename is the fully qualified name of the file containing Envoy's
 	// xDS server TLS certificate.
@@ -46,6 +48,24 @@ type Infra struct {
 	// proxyContextMap store the context of each running proxy by its name for lifecycle management.
 	proxyContextMap map[string]*proxyContext
 
+	// restartBackoff defines the delays between the restarts of the managed processes.
+	restartBackoff restartBackoff
+	// envoyRunner runs an Envoy process with the given arguments, func-e is used if it's nil.
+	envoyRunner func(ctx context.Context, out io.Writer, args []string) error
+
+	// proxyPorts allocates the admin and stats ports of each proxy, and the
+	// ports of the rate limit service.
+	proxyPorts *portAllocator
+
+	// rateLimitMu guards rateLimitContext, since the rate limit service is
+	// managed independently of the proxies.
+	rateLimitMu sync.Mutex
+	// rateLimitContext is the context of the running rate limit service, if any.
+	rateLimitContext *rateLimitContext
+
+	// certsDir is the directory of the certificates generated by `certgen --local`.
+	certsDir string
+
 	// TODO: remove this field once it supports the configurable homeDir
 	sdsConfigPath string
 }
@@ -57,12 +77,14 @@ func NewInfra(runnerCtx context.Context, cfg *config.Server, logger logging.Logg
 	}
 
 	// Check local certificates dir exist.
-	if _, err := os.Lstat(defaultLocalCertPathDir); err != nil {
+	certsDir := cfg.EnvoyGateway.Provider.GetHostInfrastructureProvider().GetCertificatesDir()
+	envoyCertsDir := filepath.Join(certsDir, crypto.LocalEnvoyDir)
+	if _, err := os.Lstat(envoyCertsDir); err != nil {
 		return nil, fmt.Errorf("failed to stat dir: %w", err)
 	}
 
 	// Ensure the sds config exist.
-	if err := createSdsConfig(defaultLocalCertPathDir); err != nil {
+	if err := createSdsConfig(envoyCertsDir); err != nil {
 		return nil, fmt.Errorf("failed to create sds config: %w", err)
 	}
 
@@ -71,7 +93,10 @@ func NewInfra(runnerCtx context.Context, cfg *config.Server, logger logging.Logg
 		Logger:          logger,
 		EnvoyGateway:    cfg.EnvoyGateway,
 		proxyContextMap: make(map[string]*proxyContext),
-		sdsConfigPath:   defaultLocalCertPathDir,
+		restartBackoff:  defaultRestartBackoff(),
+		proxyPorts:      newPortAllocator(),
+		certsDir:        certsDir,
+		sdsConfigPath:   envoyCertsDir,
 	}
 	return infra, nil
 }
@@ -0,0 +1,158 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package host
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// proxyLogFilename is the name of the file under the proxy directory holding the
+	// stdout and stderr of the proxy, the rotated files are suffixed with their index e.g. "envoy.log.1".
+	proxyLogFilename = "envoy.log"
+	// proxyLogPathArg redirects the logs of Envoy, which are written to stderr by default, to stdout
+	// so they are interleaved with the rest of its output in the proxy log file.
+	proxyLogPathArg = "--log-path /dev/stdout"
+)
+
+// rotatingFile is an io.WriteCloser writing to a file which is rotated once it reaches
+// maxSize bytes. The rotated files are renamed to "<path>.1", "<path>.2" and so on, from
+// the newest to the oldest, and only maxBackups of them are retained.
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// newRotatingFile opens the file at the given path for appending, creating it and its
+// directory if they don't exist.
+func newRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create dir: %w", err)
+	}
+
+	r := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := r.open(os.O_APPEND); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open(flag int) error {
+	// nolint: gosec
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|flag, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+// Write implements io.Writer. The file is rotated before the write if it would exceed
+// the max size, a single write larger than the max size is never split.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the rotated files by one, removing the oldest one, and re-opens an empty file.
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	r.file = nil
+
+	if err := removeIfExists(r.backupPath(r.maxBackups)); err != nil {
+		return err
+	}
+	for n := r.maxBackups - 1; n >= 1; n-- {
+		if err := renameIfExists(r.backupPath(n), r.backupPath(n+1)); err != nil {
+			return err
+		}
+	}
+	if r.maxBackups > 0 {
+		if err := renameIfExists(r.path, r.backupPath(1)); err != nil {
+			return err
+		}
+	}
+
+	return r.open(os.O_TRUNC)
+}
+
+func (r *rotatingFile) backupPath(n int) string {
+	if n == 0 {
+		return r.path
+	}
+	return fmt.Sprintf("%s.%d", r.path, n)
+}
+
+// Close implements io.Closer.
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove log file: %w", err)
+	}
+	return nil
+}
+
+func renameIfExists(from, to string) error {
+	if err := os.Rename(from, to); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return nil
+}
+
+// openProxyLog opens the log file of the given proxy, rotated as configured in the host
+// infrastructure provider.
+func (i *Infra) openProxyLog(proxyName string) (*rotatingFile, error) {
+	hostProvider := i.EnvoyGateway.Provider.GetHostInfrastructureProvider()
+	maxSize := int64(hostProvider.GetProxyLogsMaxSizeMegabytes()) * 1024 * 1024
+	maxBackups := int(hostProvider.GetProxyLogsMaxBackups())
+	return newRotatingFile(filepath.Join(i.proxyDir(proxyName), proxyLogFilename), maxSize, maxBackups)
+}
@@ -0,0 +1,86 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package host
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "proxy", "envoy.log")
+	r, err := newRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+
+	write := func(s string) {
+		t.Helper()
+		n, err := r.Write([]byte(s))
+		require.NoError(t, err)
+		require.Equal(t, len(s), n)
+	}
+	requireContent := func(path, content string) {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, content, string(data))
+	}
+
+	write("aaaaa")
+	write("bbbbb")
+	requireContent(path, "aaaaabbbbb")
+	require.NoFileExists(t, path+".1")
+
+	// The file is rotated once it would exceed the max size.
+	write("ccccc")
+	requireContent(path, "ccccc")
+	requireContent(path+".1", "aaaaabbbbb")
+
+	// A write larger than the max size is never split.
+	write("dddddddddddd")
+	requireContent(path, "dddddddddddd")
+	requireContent(path+".1", "ccccc")
+	requireContent(path+".2", "aaaaabbbbb")
+
+	// Only max backups of the rotated files are retained.
+	write("eeeee")
+	requireContent(path, "eeeee")
+	requireContent(path+".1", "dddddddddddd")
+	requireContent(path+".2", "ccccc")
+	require.NoFileExists(t, path+".3")
+
+	require.NoError(t, r.Close())
+	_, err = r.Write([]byte("fffff"))
+	require.ErrorIs(t, err, os.ErrClosed)
+
+	// The existing file is appended to when it's re-opened.
+	r, err = newRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	write("fffff")
+	requireContent(path, "eeeeefffff")
+	require.NoError(t, r.Close())
+}
+
+func TestRotatingFileWithoutBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "envoy.log")
+	r, err := newRotatingFile(path, 5, 0)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, r.Close())
+	}()
+
+	_, err = r.Write([]byte("aaaaa"))
+	require.NoError(t, err)
+	_, err = r.Write([]byte("bbbbb"))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "bbbbb", string(data))
+	require.NoFileExists(t, path+".1")
+}
@@ -0,0 +1,18 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package host
+
+import "github.com/envoyproxy/gateway/internal/metrics"
+
+var (
+	processRestartsTotal = metrics.NewCounter(
+		"host_process_restarts_total",
+		"Total number of restarts of the processes managed by the host infrastructure provider.",
+	)
+
+	kindLabel = metrics.NewLabel("kind")
+	nameLabel = metrics.NewLabel("name")
+)
@@ -0,0 +1,181 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package host
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/envoyproxy/gateway/internal/utils/file"
+	netutils "github.com/envoyproxy/gateway/internal/utils/net"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
+)
+
+const (
+	// proxiesDir is the directory under the home directory holding the runtime files of each proxy.
+	proxiesDir = "proxies"
+	// portsFilename is the name of the file which reports the ports allocated to a proxy.
+	portsFilename = "ports.json"
+
+	// maxPortAllocationAttempts is the max number of attempts to find a port that is not
+	// allocated to any other proxy.
+	maxPortAllocationAttempts = 10
+)
+
+// ProxyPorts holds the ports allocated to a host managed Envoy proxy.
+type ProxyPorts struct {
+	// Name is the name of the proxy infra.
+	Name string `json:"name"`
+	// AdminAddress is the listening address of the Envoy admin interface.
+	AdminAddress string `json:"adminAddress"`
+	// AdminPort is the listening port of the Envoy admin interface.
+	AdminPort int32 `json:"adminPort"`
+	// StatsAddress is the listening address of the Envoy Prometheus stats listener.
+	StatsAddress string `json:"statsAddress"`
+	// StatsPort is the listening port of the Envoy Prometheus stats listener.
+	StatsPort int32 `json:"statsPort"`
+}
+
+// portAllocator allocates non-conflicting ports to the managed proxies and the
+// rate limit service. The ports of a proxy are kept until they are released,
+// so the proxy keeps the same ports when it's updated.
+type portAllocator struct {
+	mu sync.Mutex
+	// allocated holds the ports of each proxy by its name.
+	allocated map[string]*ProxyPorts
+	// inUse holds all the ports allocated to the proxies.
+	inUse sets.Set[int32]
+	// freePort returns a port which is currently free on the host.
+	freePort func() (int32, error)
+}
+
+func newPortAllocator() *portAllocator {
+	return &portAllocator{
+		allocated: make(map[string]*ProxyPorts),
+		inUse:     sets.New[int32](),
+		freePort:  freeHostPort,
+	}
+}
+
+// Allocate returns the ports of the given proxy, new ports are allocated if the
+// proxy doesn't have any yet.
+func (p *portAllocator) Allocate(proxyName, infraName string) (*ProxyPorts, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ports, ok := p.allocated[proxyName]; ok {
+		return ports, nil
+	}
+
+	adminPort, err := p.allocatePort()
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate admin port: %w", err)
+	}
+	statsPort, err := p.allocatePort()
+	if err != nil {
+		p.inUse.Delete(adminPort)
+		return nil, fmt.Errorf("failed to allocate stats port: %w", err)
+	}
+
+	ports := &ProxyPorts{
+		Name:         infraName,
+		AdminAddress: bootstrap.EnvoyAdminAddress,
+		AdminPort:    adminPort,
+		StatsAddress: netutils.IPv4ListenerAddress,
+		StatsPort:    statsPort,
+	}
+	p.allocated[proxyName] = ports
+	return ports, nil
+}
+
+// Release releases the ports allocated to the given proxy.
+func (p *portAllocator) Release(proxyName string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ports, ok := p.allocated[proxyName]; ok {
+		p.inUse.Delete(ports.AdminPort, ports.StatsPort)
+		delete(p.allocated, proxyName)
+	}
+}
+
+// AllocatePorts allocates the given number of ports which are not bound to any proxy.
+func (p *portAllocator) AllocatePorts(n int) ([]int32, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ports := make([]int32, 0, n)
+	for range n {
+		port, err := p.allocatePort()
+		if err != nil {
+			p.inUse.Delete(ports...)
+			return nil, err
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+// ReleasePorts releases the ports allocated by AllocatePorts.
+func (p *portAllocator) ReleasePorts(ports ...int32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.inUse.Delete(ports...)
+}
+
+func (p *portAllocator) allocatePort() (int32, error) {
+	for range maxPortAllocationAttempts {
+		port, err := p.freePort()
+		if err != nil {
+			return 0, err
+		}
+		if !p.inUse.Has(port) {
+			p.inUse.Insert(port)
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("no free port found after %d attempts", maxPortAllocationAttempts)
+}
+
+// freeHostPort asks the kernel for a free port on all the interfaces,
+// since the stats listener binds to all of them.
+func freeHostPort() (int32, error) {
+	l, err := net.Listen("tcp", net.JoinHostPort(netutils.IPv4ListenerAddress, "0"))
+	if err != nil {
+		return 0, err
+	}
+	// nolint:gosec
+	return int32(l.Addr().(*net.TCPAddr).Port), l.Close()
+}
+
+// proxyDir returns the directory holding the runtime files of the given proxy.
+func (i *Infra) proxyDir(proxyName string) string {
+	return filepath.Join(i.HomeDir, proxiesDir, proxyName)
+}
+
+// writeProxyPorts writes the ports allocated to the proxy into its directory,
+// so they can be discovered by the operators and scrapers.
+func (i *Infra) writeProxyPorts(proxyName string, ports *ProxyPorts) error {
+	data, err := json.MarshalIndent(ports, "", "  ")
+	if err != nil {
+		return err
+	}
+	return file.WriteDir(data, i.proxyDir(proxyName), portsFilename)
+}
+
+// removeProxyPorts removes the ports file of the proxy.
+func (i *Infra) removeProxyPorts(proxyName string) {
+	if err := os.Remove(filepath.Join(i.proxyDir(proxyName), portsFilename)); err != nil && !os.IsNotExist(err) {
+		i.Logger.Error(err, "failed to remove ports file", "proxy", proxyName)
+	}
+}
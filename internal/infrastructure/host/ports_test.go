@@ -0,0 +1,84 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package host
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+)
+
+func TestPortAllocator(t *testing.T) {
+	p := newPortAllocator()
+
+	foo, err := p.Allocate("foo", "envoy-gateway-system/foo")
+	require.NoError(t, err)
+	require.NotZero(t, foo.AdminPort)
+	require.NotZero(t, foo.StatsPort)
+	require.NotEqual(t, foo.AdminPort, foo.StatsPort)
+
+	bar, err := p.Allocate("bar", "envoy-gateway-system/bar")
+	require.NoError(t, err)
+	require.NotContains(t, []int32{foo.AdminPort, foo.StatsPort}, bar.AdminPort)
+	require.NotContains(t, []int32{foo.AdminPort, foo.StatsPort}, bar.StatsPort)
+
+	// The ports are kept for the same proxy.
+	again, err := p.Allocate("foo", "envoy-gateway-system/foo")
+	require.NoError(t, err)
+	require.Equal(t, foo, again)
+
+	p.Release("foo")
+	require.NotContains(t, p.allocated, "foo")
+	require.False(t, p.inUse.Has(foo.AdminPort))
+	require.False(t, p.inUse.Has(foo.StatsPort))
+	require.True(t, p.inUse.Has(bar.AdminPort))
+}
+
+func TestPortAllocatorConflict(t *testing.T) {
+	p := newPortAllocator()
+	// Always return the same port, so the second allocation conflicts with the first one.
+	p.freePort = func() (int32, error) {
+		return 20000, nil
+	}
+
+	_, err := p.Allocate("foo", "envoy-gateway-system/foo")
+	require.ErrorContains(t, err, "failed to allocate stats port")
+	require.Empty(t, p.inUse)
+	require.Empty(t, p.allocated)
+}
+
+func TestWriteProxyPorts(t *testing.T) {
+	i := &Infra{
+		HomeDir: t.TempDir(),
+		Logger:  logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo),
+	}
+	ports := &ProxyPorts{
+		Name:         "envoy-gateway-system/foo",
+		AdminAddress: "127.0.0.1",
+		AdminPort:    20000,
+		StatsAddress: "0.0.0.0",
+		StatsPort:    20001,
+	}
+
+	require.NoError(t, i.writeProxyPorts("foo", ports))
+	data, err := os.ReadFile(filepath.Join(i.HomeDir, proxiesDir, "foo", portsFilename))
+	require.NoError(t, err)
+
+	got := &ProxyPorts{}
+	require.NoError(t, json.Unmarshal(data, got))
+	require.Equal(t, ports, got)
+
+	i.removeProxyPorts("foo")
+	require.NoFileExists(t, filepath.Join(i.HomeDir, proxiesDir, "foo", portsFilename))
+	// Removing a non-existent file is a no-op.
+	i.removeProxyPorts("foo")
+}
@@ -8,11 +8,15 @@ package host
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
-	funcE "github.com/tetratelabs/func-e/api"
 	"k8s.io/utils/ptr"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
@@ -22,12 +26,19 @@ import (
 	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
 )
 
+// proxyStopTimeout is the time to wait for func-e to shut down Envoy when it's stopped
+// before it's killed.
+const proxyStopTimeout = 30 * time.Second
+
 // proxyContext corresponds to the context of the Envoy process.
 type proxyContext struct {
-	// cancel is the function to cancel the context passed to the Envoy process.
-	cancel context.CancelFunc
-	// exit will receive an item when the Envoy process completely stopped, via funcE.ExitChannel.
-	exit chan struct{}
+	// supervisor runs the Envoy process and restarts it when it exits unexpectedly.
+	*supervisor
+	// args are the arguments the Envoy process was started with. The process is
+	// re-created when they change, e.g. when the rendered bootstrap changes.
+	args []string
+	// logFile is the file capturing the output of the Envoy process, if any.
+	logFile io.Closer
 }
 
 // Close implements the Manager interface.
@@ -35,6 +46,7 @@ func (i *Infra) Close() error {
 	for name := range i.proxyContextMap {
 		i.stopEnvoy(name)
 	}
+	i.stopRateLimit()
 	return nil
 }
 
@@ -50,54 +62,102 @@ func (i *Infra) CreateOrUpdateProxyInfra(ctx context.Context, infra *ir.Infra) e
 
 	proxyInfra := infra.GetProxyInfra()
 	proxyName := utils.GetHashedName(proxyInfra.Name, 64)
-	// Return directly if the proxy is running.
-	if _, ok := i.proxyContextMap[proxyName]; ok {
-		return nil
+
+	ports, err := i.proxyPorts.Allocate(proxyName, proxyInfra.Name)
+	if err != nil {
+		return err
 	}
 
 	proxyConfig := proxyInfra.GetProxyConfig()
-	// Disable Prometheus to make envoy running as a host process successfully.
-	// TODO: Add Prometheus support to host infra.
+	var proxyMetrics *egv1a1.ProxyMetrics
+	if proxyConfig.Spec.Telemetry != nil {
+		proxyMetrics = proxyConfig.Spec.Telemetry.Metrics
+	}
 	bootstrapConfigOptions := &bootstrap.RenderBootstrapConfigOptions{
-		ProxyMetrics: &egv1a1.ProxyMetrics{
-			Prometheus: &egv1a1.ProxyPrometheusProvider{
-				Disable: true,
-			},
-		},
+		ProxyMetrics: proxyMetrics,
 		SdsConfig: bootstrap.SdsConfigPath{
 			Certificate: filepath.Join(i.sdsConfigPath, common.SdsCertFilename),
 			TrustedCA:   filepath.Join(i.sdsConfigPath, common.SdsCAFilename),
 		},
-		XdsServerHost:   ptr.To("0.0.0.0"),
+		XdsServerHost:   ptr.To(i.EnvoyGateway.XDSServer.GetAddress()),
+		XdsServerPort:   ptr.To(i.EnvoyGateway.XDSServer.GetPort()),
 		WasmServerPort:  ptr.To(int32(0)),
-		AdminServerPort: ptr.To(int32(0)),
-		StatsServerPort: ptr.To(int32(0)),
+		AdminServerPort: ptr.To(ports.AdminPort),
+		StatsServerPort: ptr.To(ports.StatsPort),
+	}
+	if socket := i.EnvoyGateway.XDSServer.GetUnixSocket(); socket != nil {
+		bootstrapConfigOptions.XdsServerSocketPath = ptr.To(socket.Path)
 	}
 
 	args, err := common.BuildProxyArgs(proxyInfra, proxyConfig.Spec.Shutdown, bootstrapConfigOptions, proxyName)
 	if err != nil {
 		return err
 	}
-	i.runEnvoy(ctx, os.Stdout, proxyName, args)
+	// Capture the Envoy logs into the proxy log file, unless they are redirected by the extra args.
+	if !slices.ContainsFunc(args, func(arg string) bool { return strings.HasPrefix(arg, "--log-path") }) {
+		args = append(args, proxyLogPathArg)
+	}
+
+	pCtx, ok := i.proxyContextMap[proxyName]
+	// Return directly if the proxy is running with the same arguments.
+	if ok && !pCtx.Stopped() && slices.Equal(pCtx.args, args) {
+		return nil
+	}
+
+	// Open the log file before stopping the running proxy, so it isn't stopped if the new one can't be started.
+	logFile, err := i.openProxyLog(proxyName)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		i.Logger.Info("re-creating proxy", "proxy", proxyName, "argsChanged", !slices.Equal(pCtx.args, args))
+		i.stopEnvoy(proxyName)
+	}
+
+	// Report the allocated ports, so the admin interface and metrics of the proxy can be discovered.
+	if err := i.writeProxyPorts(proxyName, ports); err != nil {
+		i.Logger.Error(err, "failed to write proxy ports", "proxy", proxyName)
+	}
+	i.Logger.Info("allocated proxy ports", "proxy", proxyName,
+		"adminPort", ports.AdminPort, "statsPort", ports.StatsPort)
+
+	i.runEnvoy(ctx, logFile, proxyName, args)
+	i.proxyContextMap[proxyName].logFile = logFile
 	return nil
 }
 
 // runEnvoy runs the Envoy process with the given arguments and name in a separate goroutine.
+// The process is restarted with an exponential backoff whenever it exits unexpectedly.
 func (i *Infra) runEnvoy(ctx context.Context, out io.Writer, name string, args []string) {
-	pCtx, cancel := context.WithCancel(ctx)
-	exit := make(chan struct{}, 1)
-	i.proxyContextMap[name] = &proxyContext{cancel: cancel, exit: exit}
-	go func() {
-		// Run blocks until pCtx is done or the process exits where the latter doesn't happen when
-		// Envoy successfully starts up. So, this will not return until pCtx is done in practice.
-		defer func() {
-			exit <- struct{}{}
-		}()
-		err := funcE.Run(pCtx, args, funcE.HomeDir(i.HomeDir), funcE.Out(out))
-		if err != nil {
-			i.Logger.Error(err, "failed to run envoy")
-		}
-	}()
+	run := i.envoyRunner
+	if run == nil {
+		run = i.runFuncE
+	}
+	s := i.supervise(ctx, processKindEnvoy, name, func(pCtx context.Context) error {
+		return run(pCtx, out, args)
+	})
+	i.proxyContextMap[name] = &proxyContext{supervisor: s, args: args}
+}
+
+// runFuncE runs Envoy via func-e in an "envoy run" child process of the current executable,
+// so both the stdout and stderr of Envoy are written to out, which func-e doesn't support in
+// process. It blocks until ctx is done or the process exits where the latter doesn't happen
+// when Envoy successfully starts up.
+func (i *Infra) runFuncE(ctx context.Context, out io.Writer, args []string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find executable: %w", err)
+	}
+	cmd := exec.CommandContext(ctx, executable, append([]string{"envoy", "run", "--home-dir", i.HomeDir, "--"}, args...)...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	// Give func-e a chance to shut down Envoy when it's stopped.
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = proxyStopTimeout
+	return cmd.Run()
 }
 
 // DeleteProxyInfra removes the managed host process, if it doesn't exist.
@@ -109,15 +169,20 @@ func (i *Infra) DeleteProxyInfra(_ context.Context, infra *ir.Infra) error {
 	proxyInfra := infra.GetProxyInfra()
 	proxyName := utils.GetHashedName(proxyInfra.Name, 64)
 	i.stopEnvoy(proxyName)
+	i.proxyPorts.Release(proxyName)
+	i.removeProxyPorts(proxyName)
 	return nil
 }
 
 // stopEnvoy stops the Envoy process by its name. It will block until the process completely stopped.
 func (i *Infra) stopEnvoy(proxyName string) {
 	if pCtx, ok := i.proxyContextMap[proxyName]; ok {
-		pCtx.cancel()    // Cancel causes the Envoy process to exit.
-		<-pCtx.exit      // Wait for the Envoy process to completely exit.
-		close(pCtx.exit) // Close the channel to avoid leaking.
+		pCtx.Stop()
+		if pCtx.logFile != nil {
+			if err := pCtx.logFile.Close(); err != nil {
+				i.Logger.Error(err, "failed to close proxy log file", "proxy", proxyName)
+			}
+		}
 		delete(i.proxyContextMap, proxyName)
 	}
 }
@@ -8,21 +8,39 @@ package host
 import (
 	"bytes"
 	"context"
+	"io"
 	"os"
 	"path"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	funcE "github.com/tetratelabs/func-e/api"
+	"k8s.io/utils/ptr"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/cmd/envoy"
 	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/utils"
 	"github.com/envoyproxy/gateway/internal/utils/file"
 )
 
+func TestMain(m *testing.M) {
+	// The test binary stands in for the "envoy run" command, which runFuncE runs Envoy with.
+	if len(os.Args) > 5 && os.Args[1] == "envoy" && os.Args[2] == "run" && os.Args[3] == "--home-dir" && os.Args[5] == "--" {
+		if err := envoy.Run(os.Args[4], os.Args[6:]); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
 func newMockInfra(t *testing.T, cfg *config.Server) *Infra {
 	t.Helper()
 	homeDir := t.TempDir()
@@ -46,6 +64,8 @@ func newMockInfra(t *testing.T, cfg *config.Server) *Infra {
 		Logger:          logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo),
 		EnvoyGateway:    cfg.EnvoyGateway,
 		proxyContextMap: make(map[string]*proxyContext),
+		proxyPorts:      newPortAllocator(),
+		certsDir:        homeDir,
 		sdsConfigPath:   proxyDir,
 	}
 	return infra
@@ -88,6 +108,109 @@ func TestInfraCreateProxy(t *testing.T) {
 	}
 }
 
+func TestInfraCreateProxyRecreate(t *testing.T) {
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+	infra := newMockInfra(t, cfg)
+
+	var runs atomic.Int32
+	infra.envoyRunner = func(ctx context.Context, _ io.Writer, _ []string) error {
+		runs.Add(1)
+		<-ctx.Done()
+		return nil
+	}
+	defer func() {
+		require.NoError(t, infra.Close())
+	}()
+
+	ctx := context.Background()
+	infraIR := ir.NewInfra()
+	proxyName := utils.GetHashedName(infraIR.GetProxyInfra().Name, 64)
+	require.NoError(t, infra.CreateOrUpdateProxyInfra(ctx, infraIR))
+	require.Contains(t, infra.proxyContextMap, proxyName)
+	pCtx := infra.proxyContextMap[proxyName]
+
+	// The proxy is kept if its arguments are the same.
+	require.NoError(t, infra.CreateOrUpdateProxyInfra(ctx, infraIR))
+	require.Same(t, pCtx, infra.proxyContextMap[proxyName])
+
+	// The proxy is re-created if its arguments changed.
+	infraIR.Proxy.Config = &egv1a1.EnvoyProxy{
+		Spec: egv1a1.EnvoyProxySpec{Concurrency: ptr.To[int32](2)},
+	}
+	require.NoError(t, infra.CreateOrUpdateProxyInfra(ctx, infraIR))
+	require.NotSame(t, pCtx, infra.proxyContextMap[proxyName])
+	require.True(t, pCtx.Stopped())
+	require.Contains(t, infra.proxyContextMap[proxyName].args, "--concurrency 2")
+
+	// The proxy is re-created if it's no longer supervised.
+	pCtx = infra.proxyContextMap[proxyName]
+	pCtx.Stop()
+	require.NoError(t, infra.CreateOrUpdateProxyInfra(ctx, infraIR))
+	require.NotSame(t, pCtx, infra.proxyContextMap[proxyName])
+
+	// The running proxy is kept if the log file of the new one can't be opened.
+	pCtx = infra.proxyContextMap[proxyName]
+	logPath := filepath.Join(infra.proxyDir(proxyName), proxyLogFilename)
+	require.NoError(t, os.Remove(logPath))
+	require.NoError(t, os.Mkdir(logPath, 0o750))
+	infraIR.Proxy.Config.Spec.Concurrency = ptr.To[int32](3)
+	require.Error(t, infra.CreateOrUpdateProxyInfra(ctx, infraIR))
+	require.Same(t, pCtx, infra.proxyContextMap[proxyName])
+	require.False(t, pCtx.Stopped())
+
+	require.Eventually(t, func() bool {
+		return runs.Load() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestInfraCreateProxyLogs(t *testing.T) {
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+	infra := newMockInfra(t, cfg)
+
+	var args atomic.Value
+	infra.envoyRunner = func(ctx context.Context, out io.Writer, a []string) error {
+		args.Store(a)
+		_, err := out.Write([]byte("envoy started\n"))
+		<-ctx.Done()
+		return err
+	}
+
+	ctx := context.Background()
+	infraIR := ir.NewInfra()
+	infraIR.Proxy.Config = &egv1a1.EnvoyProxy{
+		Spec: egv1a1.EnvoyProxySpec{
+			Logging: egv1a1.ProxyLogging{
+				Level: map[egv1a1.ProxyLogComponent]egv1a1.LogLevel{
+					egv1a1.LogComponentDefault:  egv1a1.LogLevelDebug,
+					egv1a1.LogComponentUpstream: egv1a1.LogLevelError,
+				},
+			},
+		},
+	}
+	proxyName := utils.GetHashedName(infraIR.GetProxyInfra().Name, 64)
+	require.NoError(t, infra.CreateOrUpdateProxyInfra(ctx, infraIR))
+
+	// The output of the proxy is captured into its own log file.
+	logPath := filepath.Join(infra.proxyDir(proxyName), proxyLogFilename)
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(logPath)
+		return err == nil && string(data) == "envoy started\n"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The logging levels of the EnvoyProxy are honoured.
+	runArgs := args.Load().([]string)
+	require.Contains(t, runArgs, "--log-level debug")
+	require.Contains(t, runArgs, "--component-log-level upstream:error")
+	require.Contains(t, runArgs, proxyLogPathArg)
+
+	// The log file is closed and retained once the proxy is deleted.
+	require.NoError(t, infra.DeleteProxyInfra(ctx, infraIR))
+	require.FileExists(t, logPath)
+	require.NoError(t, infra.Close())
+}
+
 func TestInfra_runEnvoy_stopEnvoy(t *testing.T) {
 	tmpdir := t.TempDir()
 	// Ensures that all the required binaries are available.
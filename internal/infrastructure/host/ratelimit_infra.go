@@ -7,17 +7,200 @@ package host
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/crypto"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/ratelimit"
 )
 
-// TODO: add ratelimit support for host infra
+const (
+	// rateLimitDir is the directory under the home directory holding the runtime files
+	// of the rate limit service.
+	rateLimitDir = "ratelimit"
+	// rateLimitAddress is the listening address of the rate limit service.
+	rateLimitAddress = "127.0.0.1"
+	// rateLimitXdsServerAddress is the address of the xDS config server of the rate limit service.
+	rateLimitXdsServerAddress = "127.0.0.1"
+
+	// rateLimitStopTimeout is the time to wait for the rate limit service to exit
+	// gracefully before it's killed.
+	rateLimitStopTimeout = 10 * time.Second
+
+	// The environment variables of the rate limit service which are not used by
+	// the Kubernetes infrastructure provider.
+	hostEnvVar                         = "HOST"
+	portEnvVar                         = "PORT"
+	debugHostEnvVar                    = "DEBUG_HOST"
+	debugPortEnvVar                    = "DEBUG_PORT"
+	grpcHostEnvVar                     = "GRPC_HOST"
+	grpcPortEnvVar                     = "GRPC_PORT"
+	configGRPCXDSServerTLSSANEnvVar    = "CONFIG_GRPC_XDS_SERVER_TLS_SAN"
+	rateLimitRuntimeSubdirectoryEnvVal = "ratelimit"
+)
+
+// rateLimitContext corresponds to the context of the rate limit service process.
+type rateLimitContext struct {
+	// supervisor runs the rate limit service and restarts it when it exits unexpectedly.
+	*supervisor
+	// ports are the HTTP and debug ports allocated to the rate limit service.
+	ports []int32
+}
+
+// GetRateLimitServiceURL returns the URL of the rate limit service run on the host.
+func GetRateLimitServiceURL(hostProvider *egv1a1.EnvoyGatewayHostInfrastructureProvider) string {
+	return fmt.Sprintf("grpc://%s", net.JoinHostPort(rateLimitAddress, strconv.Itoa(int(hostProvider.GetRateLimitPort()))))
+}
 
 // CreateOrUpdateRateLimitInfra creates the managed host rate limit process, if it doesn't exist.
 func (i *Infra) CreateOrUpdateRateLimitInfra(ctx context.Context) error {
-	return fmt.Errorf("create/update ratelimit infrastructure is not supported yet for host infrastructure")
+	if i.EnvoyGateway == nil || i.EnvoyGateway.RateLimit == nil {
+		return errors.New("ratelimit is not configured")
+	}
+
+	i.rateLimitMu.Lock()
+	defer i.rateLimitMu.Unlock()
+
+	if rCtx := i.rateLimitContext; rCtx != nil {
+		// Return directly if the rate limit service is running.
+		if !rCtx.Stopped() {
+			return nil
+		}
+		i.proxyPorts.ReleasePorts(rCtx.ports...)
+		i.rateLimitContext = nil
+	}
+
+	hostProvider := i.EnvoyGateway.Provider.GetHostInfrastructureProvider()
+	binary, err := exec.LookPath(hostProvider.GetRateLimitBinary())
+	if err != nil {
+		return fmt.Errorf("failed to find ratelimit binary: %w", err)
+	}
+
+	runtimeDir := filepath.Join(i.HomeDir, rateLimitDir)
+	if err = os.MkdirAll(filepath.Join(runtimeDir, rateLimitRuntimeSubdirectoryEnvVal), 0o750); err != nil {
+		return fmt.Errorf("failed to create dir: %w", err)
+	}
+
+	// The HTTP and debug servers of the rate limit service.
+	ports, err := i.proxyPorts.AllocatePorts(2)
+	if err != nil {
+		return fmt.Errorf("failed to allocate ratelimit ports: %w", err)
+	}
+
+	env, err := i.rateLimitEnv(hostProvider, runtimeDir, ports[0], ports[1])
+	if err != nil {
+		i.proxyPorts.ReleasePorts(ports...)
+		return err
+	}
+
+	i.runRateLimit(ctx, os.Stdout, binary, env, ports)
+	i.Logger.Info("started ratelimit service", "binary", binary,
+		"grpcPort", hostProvider.GetRateLimitPort(), "httpPort", ports[0], "debugPort", ports[1])
+	return nil
+}
+
+// rateLimitEnv returns the environment variables of the rate limit service, which
+// mirror the ones of the rate limit Deployment managed by the Kubernetes provider.
+func (i *Infra) rateLimitEnv(hostProvider *egv1a1.EnvoyGatewayHostInfrastructureProvider,
+	runtimeDir string, httpPort, debugPort int32,
+) ([]string, error) {
+	rateLimit := i.EnvoyGateway.RateLimit
+	certPaths := crypto.GetLocalCertPaths(i.certsDir, crypto.LocalEnvoyRateLimitDir)
+
+	env := map[string]string{
+		ratelimit.RuntimeRootEnvVar:           runtimeDir,
+		ratelimit.RuntimeSubdirectoryEnvVar:   rateLimitRuntimeSubdirectoryEnvVal,
+		ratelimit.RuntimeIgnoreDotfilesEnvVar: "true",
+		ratelimit.RuntimeWatchRootEnvVar:      "false",
+		ratelimit.LogLevelEnvVar:              "info",
+		ratelimit.UseStatsdEnvVar:             "false",
+		ratelimit.ConfigTypeEnvVar:            "GRPC_XDS_SOTW",
+		ratelimit.ConfigGrpcXdsServerURLEnvVar: net.JoinHostPort(rateLimitXdsServerAddress,
+			strconv.Itoa(ratelimit.XdsGrpcSotwConfigServerPort)),
+		// The xDS config server presents the Envoy Gateway certificate, which is issued for its service name.
+		configGRPCXDSServerTLSSANEnvVar:                ratelimit.XdsGrpcSotwConfigServerHost,
+		ratelimit.ConfigGrpcXdsNodeIDEnvVar:            ratelimit.InfraName,
+		hostEnvVar:                                     rateLimitAddress,
+		portEnvVar:                                     strconv.Itoa(int(httpPort)),
+		debugHostEnvVar:                                rateLimitAddress,
+		debugPortEnvVar:                                strconv.Itoa(int(debugPort)),
+		grpcHostEnvVar:                                 rateLimitAddress,
+		grpcPortEnvVar:                                 strconv.Itoa(int(hostProvider.GetRateLimitPort())),
+		ratelimit.GRPCServerUseTLSEnvVar:               "true",
+		ratelimit.GRPCServerTLSCertEnvVar:              certPaths.TLSCert,
+		ratelimit.GRPCServerTLSKeyEnvVarEnvVar:         certPaths.TLSKey,
+		ratelimit.GRPCServerTLSCACertEnvVar:            certPaths.CACert,
+		ratelimit.ConfigGRPCXDSServerUseTLSEnvVar:      "true",
+		ratelimit.ConfigGRPCXDSClientTLSCertEnvVar:     certPaths.TLSCert,
+		ratelimit.ConfigGRPCXDSClientTLSKeyEnvVar:      certPaths.TLSKey,
+		ratelimit.ConfigGRPCXDSServerTLSCACertEnvVar:   certPaths.CACert,
+		ratelimit.ForceStartWithoutInitialConfigEnvVar: "true",
+	}
+
+	if rateLimit.Backend.Redis != nil {
+		env[ratelimit.RedisSocketTypeEnvVar] = "tcp"
+		env[ratelimit.RedisURLEnvVar] = rateLimit.Backend.Redis.URL
+
+		if rateLimit.Backend.Redis.TLS != nil {
+			// The client certificate is referenced as a Kubernetes Secret, which can't be resolved on the host.
+			if rateLimit.Backend.Redis.TLS.CertificateRef != nil {
+				return nil, errors.New("redis client certificate is not supported for host infrastructure")
+			}
+			env[ratelimit.RedisTLSEnvVar] = "true"
+		}
+	}
+
+	// TODO: support the metrics and tracing of the rate limit service.
+
+	ret := make([]string, 0, len(env))
+	for k, v := range env {
+		ret = append(ret, k+"="+v)
+	}
+	slices.Sort(ret)
+	return ret, nil
+}
+
+// runRateLimit runs the rate limit service in a separate goroutine.
+// The service is restarted with an exponential backoff whenever it exits unexpectedly.
+func (i *Infra) runRateLimit(ctx context.Context, out io.Writer, binary string, env []string, ports []int32) {
+	s := i.supervise(ctx, processKindRateLimit, ratelimit.InfraName, func(rCtx context.Context) error {
+		cmd := exec.CommandContext(rCtx, binary)
+		cmd.Env = append(os.Environ(), env...)
+		cmd.Stdout = out
+		cmd.Stderr = out
+		// Give the service a chance to exit gracefully when it's stopped.
+		cmd.Cancel = func() error {
+			return cmd.Process.Signal(os.Interrupt)
+		}
+		cmd.WaitDelay = rateLimitStopTimeout
+		return cmd.Run()
+	})
+	i.rateLimitContext = &rateLimitContext{supervisor: s, ports: ports}
 }
 
 // DeleteRateLimitInfra removes the managed host rate limit process, if it doesn't exist.
-func (i *Infra) DeleteRateLimitInfra(ctx context.Context) error {
-	return fmt.Errorf("delete ratelimit infrastructure is not supported yet for host infrastructure")
+func (i *Infra) DeleteRateLimitInfra(_ context.Context) error {
+	i.stopRateLimit()
+	return nil
+}
+
+// stopRateLimit stops the rate limit service. It will block until the process completely stopped.
+func (i *Infra) stopRateLimit() {
+	i.rateLimitMu.Lock()
+	defer i.rateLimitMu.Unlock()
+
+	if rCtx := i.rateLimitContext; rCtx != nil {
+		rCtx.Stop()
+		i.proxyPorts.ReleasePorts(rCtx.ports...)
+		i.rateLimitContext = nil
+	}
 }
@@ -0,0 +1,146 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package host
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+)
+
+func newMockRateLimitInfra(t *testing.T, binary string) *Infra {
+	t.Helper()
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+	infra := newMockInfra(t, cfg)
+
+	infra.EnvoyGateway.RateLimit = &egv1a1.RateLimit{
+		Backend: egv1a1.RateLimitDatabaseBackend{
+			Type:  egv1a1.RedisBackendType,
+			Redis: &egv1a1.RateLimitRedisSettings{URL: "localhost:6379"},
+		},
+	}
+	infra.EnvoyGateway.Provider = &egv1a1.EnvoyGatewayProvider{
+		Type: egv1a1.ProviderTypeCustom,
+		Custom: &egv1a1.EnvoyGatewayCustomProvider{
+			Infrastructure: &egv1a1.EnvoyGatewayInfrastructureProvider{
+				Type: egv1a1.InfrastructureProviderTypeHost,
+				Host: &egv1a1.EnvoyGatewayHostInfrastructureProvider{
+					RateLimit: &egv1a1.HostRateLimitService{
+						Binary: ptr.To(binary),
+						Port:   ptr.To[int32](18081),
+					},
+				},
+			},
+		},
+	}
+	return infra
+}
+
+// writeFakeRateLimit writes a script which records its environment and each of its runs.
+func writeFakeRateLimit(t *testing.T, dir, script string) string {
+	t.Helper()
+	binary := filepath.Join(dir, "ratelimit")
+	content := "#!/bin/sh\nenv > " + filepath.Join(dir, "env") + "\necho run >> " + filepath.Join(dir, "runs") + "\n" + script + "\n"
+	require.NoError(t, os.WriteFile(binary, []byte(content), 0o700)) // nolint: gosec
+	return binary
+}
+
+func TestRateLimitEnv(t *testing.T) {
+	i := newMockRateLimitInfra(t, "ratelimit")
+	// Use the default settings of the host infrastructure provider.
+	i.EnvoyGateway.Provider.Custom.Infrastructure.Host = nil
+	hostProvider := i.EnvoyGateway.Provider.GetHostInfrastructureProvider()
+
+	env, err := i.rateLimitEnv(hostProvider, "/tmp/ratelimit", 20000, 20001)
+	require.NoError(t, err)
+	require.Contains(t, env, "CONFIG_TYPE=GRPC_XDS_SOTW")
+	require.Contains(t, env, "CONFIG_GRPC_XDS_SERVER_URL=127.0.0.1:18001")
+	require.Contains(t, env, "CONFIG_GRPC_XDS_SERVER_TLS_SAN=envoy-gateway")
+	require.Contains(t, env, "CONFIG_GRPC_XDS_NODE_ID=envoy-ratelimit")
+	require.Contains(t, env, "GRPC_HOST=127.0.0.1")
+	require.Contains(t, env, "GRPC_PORT=8081")
+	require.Contains(t, env, "PORT=20000")
+	require.Contains(t, env, "DEBUG_PORT=20001")
+	require.Contains(t, env, "GRPC_SERVER_TLS_CERT="+filepath.Join(i.certsDir, "envoy-rate-limit", "tls.crt"))
+	require.Contains(t, env, "CONFIG_GRPC_XDS_SERVER_TLS_CACERT="+filepath.Join(i.certsDir, "envoy-rate-limit", "ca.crt"))
+	require.Contains(t, env, "REDIS_URL=localhost:6379")
+	require.NotContains(t, env, "REDIS_TLS=true")
+
+	t.Run("redis tls", func(t *testing.T) {
+		i.EnvoyGateway.RateLimit.Backend.Redis.TLS = &egv1a1.RedisTLSSettings{}
+		env, err := i.rateLimitEnv(hostProvider, "/tmp/ratelimit", 20000, 20001)
+		require.NoError(t, err)
+		require.Contains(t, env, "REDIS_TLS=true")
+	})
+
+	t.Run("redis client certificate", func(t *testing.T) {
+		i.EnvoyGateway.RateLimit.Backend.Redis.TLS = &egv1a1.RedisTLSSettings{
+			CertificateRef: &gwapiv1.SecretObjectReference{Name: "redis-client-cert"},
+		}
+		_, err := i.rateLimitEnv(hostProvider, "/tmp/ratelimit", 20000, 20001)
+		require.Error(t, err)
+	})
+}
+
+func TestCreateAndDeleteRateLimitInfra(t *testing.T) {
+	dir := t.TempDir()
+	i := newMockRateLimitInfra(t, writeFakeRateLimit(t, dir, "exec sleep 60"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, i.CreateOrUpdateRateLimitInfra(ctx))
+	require.NotNil(t, i.rateLimitContext)
+	ports := i.rateLimitContext.ports
+	require.Len(t, ports, 2)
+
+	require.Eventually(t, func() bool {
+		env, err := os.ReadFile(filepath.Join(dir, "env"))
+		return err == nil && strings.Contains(string(env), "GRPC_PORT=18081")
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// It's a no-op if the rate limit service is running.
+	require.NoError(t, i.CreateOrUpdateRateLimitInfra(ctx))
+
+	require.NoError(t, i.DeleteRateLimitInfra(ctx))
+	require.Nil(t, i.rateLimitContext)
+	require.False(t, i.proxyPorts.inUse.HasAny(ports...))
+	// Deleting a non-existent rate limit service is a no-op.
+	require.NoError(t, i.DeleteRateLimitInfra(ctx))
+}
+
+func TestRateLimitInfraRestart(t *testing.T) {
+	dir := t.TempDir()
+	i := newMockRateLimitInfra(t, writeFakeRateLimit(t, dir, "exit 1"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, i.CreateOrUpdateRateLimitInfra(ctx))
+	// The rate limit service is restarted after it exits.
+	require.Eventually(t, func() bool {
+		runs, err := os.ReadFile(filepath.Join(dir, "runs"))
+		return err == nil && strings.Count(string(runs), "run") >= 2
+	}, 5*time.Second, 50*time.Millisecond)
+	require.NoError(t, i.Close())
+}
+
+func TestCreateRateLimitInfraWithoutBinary(t *testing.T) {
+	i := newMockRateLimitInfra(t, filepath.Join(t.TempDir(), "not-exist"))
+	require.ErrorContains(t, i.CreateOrUpdateRateLimitInfra(context.Background()), "failed to find ratelimit binary")
+	require.Nil(t, i.rateLimitContext)
+}
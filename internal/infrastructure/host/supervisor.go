@@ -0,0 +1,132 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package host
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/envoyproxy/gateway/internal/logging"
+)
+
+const (
+	// processKindEnvoy is the kind of the Envoy processes.
+	processKindEnvoy = "Envoy"
+	// processKindRateLimit is the kind of the rate limit service process.
+	processKindRateLimit = "RateLimit"
+
+	// defaultMinRestartDelay is the delay before restarting a process the first time
+	// it exits, the delay is doubled on each consecutive exit.
+	defaultMinRestartDelay = time.Second
+	// defaultMaxRestartDelay is the max delay before restarting a process.
+	// The delay is reset if the process ran for longer than it.
+	defaultMaxRestartDelay = 30 * time.Second
+)
+
+// restartBackoff defines the delays between the restarts of a supervised process.
+type restartBackoff struct {
+	minDelay time.Duration
+	maxDelay time.Duration
+}
+
+func defaultRestartBackoff() restartBackoff {
+	return restartBackoff{
+		minDelay: defaultMinRestartDelay,
+		maxDelay: defaultMaxRestartDelay,
+	}
+}
+
+// supervisor runs a process managed by the host infrastructure provider, and restarts
+// it with an exponential backoff whenever it exits until the supervisor is stopped.
+type supervisor struct {
+	// kind and name identify the supervised process in the logs and metrics.
+	kind string
+	name string
+
+	backoff restartBackoff
+	logger  logging.Logger
+
+	// restarts is the number of times the process has been restarted.
+	restarts atomic.Int64
+	// cancel is the function to cancel the context passed to the process.
+	cancel context.CancelFunc
+	// done is closed when the process completely stopped and it's no longer supervised.
+	done chan struct{}
+}
+
+// supervise runs the process in a separate goroutine under a new supervisor.
+// run is expected to block until the process exits or ctx is done.
+func (i *Infra) supervise(ctx context.Context, kind, name string, run func(context.Context) error) *supervisor {
+	backoff := i.restartBackoff
+	if backoff == (restartBackoff{}) {
+		backoff = defaultRestartBackoff()
+	}
+
+	sCtx, cancel := context.WithCancel(ctx)
+	s := &supervisor{
+		kind:    kind,
+		name:    name,
+		backoff: backoff,
+		logger:  i.Logger,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go s.run(sCtx, run)
+	return s
+}
+
+func (s *supervisor) run(ctx context.Context, run func(context.Context) error) {
+	defer close(s.done)
+
+	delay := s.backoff.minDelay
+	for {
+		started := time.Now()
+		err := run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// The process ran long enough to be considered healthy, so it's not crash looping.
+		if time.Since(started) > s.backoff.maxDelay {
+			delay = s.backoff.minDelay
+		}
+		s.logger.Error(err, "process exited unexpectedly, restarting",
+			"kind", s.kind, "name", s.name, "delay", delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		s.restarts.Add(1)
+		processRestartsTotal.With(kindLabel.Value(s.kind), nameLabel.Value(s.name)).Increment()
+		delay = min(delay*2, s.backoff.maxDelay)
+	}
+}
+
+// Stop stops the process. It will block until the process completely stopped.
+func (s *supervisor) Stop() {
+	s.cancel()
+	<-s.done
+}
+
+// Stopped returns true if the process is no longer supervised, e.g. the context
+// passed to the supervisor is done.
+func (s *supervisor) Stopped() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Restarts returns the number of times the process has been restarted.
+func (s *supervisor) Restarts() int64 {
+	return s.restarts.Load()
+}
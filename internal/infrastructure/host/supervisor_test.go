@@ -0,0 +1,85 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package host
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+)
+
+func newTestSupervisorInfra() *Infra {
+	return &Infra{
+		Logger: logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo),
+		restartBackoff: restartBackoff{
+			minDelay: 10 * time.Millisecond,
+			maxDelay: 40 * time.Millisecond,
+		},
+	}
+}
+
+func TestSupervisorRestart(t *testing.T) {
+	i := newTestSupervisorInfra()
+
+	var runs atomic.Int32
+	s := i.supervise(context.Background(), processKindEnvoy, "test", func(ctx context.Context) error {
+		// Crash on the first runs, then keep running until stopped.
+		if runs.Add(1) < 3 {
+			return errors.New("crashed")
+		}
+		<-ctx.Done()
+		return nil
+	})
+
+	require.Eventually(t, func() bool {
+		return s.Restarts() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.False(t, s.Stopped())
+
+	s.Stop()
+	require.True(t, s.Stopped())
+	require.Equal(t, int32(3), runs.Load())
+	require.Equal(t, int64(2), s.Restarts())
+}
+
+func TestSupervisorStopDuringBackoff(t *testing.T) {
+	i := newTestSupervisorInfra()
+	i.restartBackoff = restartBackoff{minDelay: time.Hour, maxDelay: time.Hour}
+
+	var runs atomic.Int32
+	s := i.supervise(context.Background(), processKindRateLimit, "test", func(context.Context) error {
+		runs.Add(1)
+		return errors.New("crashed")
+	})
+
+	require.Eventually(t, func() bool {
+		return runs.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	// Stop doesn't wait for the pending restart.
+	s.Stop()
+	require.Equal(t, int64(0), s.Restarts())
+}
+
+func TestSupervisorContextDone(t *testing.T) {
+	i := newTestSupervisorInfra()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := i.supervise(ctx, processKindEnvoy, "test", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	cancel()
+	require.Eventually(t, s.Stopped, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, int64(0), s.Restarts())
+}
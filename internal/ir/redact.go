@@ -0,0 +1,99 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package ir
+
+import (
+	"bytes"
+	"reflect"
+)
+
+var privateBytesType = reflect.TypeOf(PrivateBytes{})
+
+// RestoreRedacted restores the PrivateBytes of the IR which were redacted when it was serialized,
+// e.g. when it was sent to an extension, by copying them from the original IR.
+// The elements of the lists are matched by name if they have one, and by index otherwise.
+func (x *Xds) RestoreRedacted(original *Xds) {
+	if x == nil || original == nil {
+		return
+	}
+	restoreRedacted(reflect.ValueOf(x).Elem(), reflect.ValueOf(original).Elem())
+}
+
+func restoreRedacted(modified, original reflect.Value) {
+	if !original.IsValid() || modified.Type() != original.Type() {
+		return
+	}
+
+	switch modified.Kind() {
+	case reflect.Pointer:
+		if modified.IsNil() || original.IsNil() {
+			return
+		}
+		restoreRedacted(modified.Elem(), original.Elem())
+	case reflect.Struct:
+		for i := 0; i < modified.NumField(); i++ {
+			if modified.Type().Field(i).IsExported() {
+				restoreRedacted(modified.Field(i), original.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if modified.Type() == privateBytesType {
+			if bytes.Equal(modified.Bytes(), redacted) {
+				modified.Set(original)
+			}
+			return
+		}
+		for i := 0; i < modified.Len(); i++ {
+			restoreRedacted(modified.Index(i), matchingElem(modified.Index(i), original, i))
+		}
+	case reflect.Map:
+		if modified.IsNil() || original.IsNil() {
+			return
+		}
+		iter := modified.MapRange()
+		for iter.Next() {
+			// Map values are not addressable, so they are restored on a copy.
+			value := reflect.New(iter.Value().Type()).Elem()
+			value.Set(iter.Value())
+			restoreRedacted(value, original.MapIndex(iter.Key()))
+			modified.SetMapIndex(iter.Key(), value)
+		}
+	}
+}
+
+// matchingElem returns the element of the original list matching the given element.
+func matchingElem(elem, original reflect.Value, index int) reflect.Value {
+	if name := elemName(elem); name != "" {
+		for i := 0; i < original.Len(); i++ {
+			if elemName(original.Index(i)) == name {
+				return original.Index(i)
+			}
+		}
+		return reflect.Value{}
+	}
+	if index < original.Len() {
+		return original.Index(index)
+	}
+	return reflect.Value{}
+}
+
+// elemName returns the value of the Name field of the given element, if any.
+func elemName(elem reflect.Value) string {
+	if elem.Kind() == reflect.Pointer {
+		if elem.IsNil() {
+			return ""
+		}
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return ""
+	}
+	name := elem.FieldByName("Name")
+	if !name.IsValid() || name.Kind() != reflect.String {
+		return ""
+	}
+	return name.String()
+}
@@ -0,0 +1,72 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package ir
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreRedacted(t *testing.T) {
+	original := &Xds{
+		HTTP: []*HTTPListener{
+			{
+				CoreListenerDetails: CoreListenerDetails{Name: "first"},
+				TLS: &TLSConfig{
+					Certificates: []TLSCertificate{
+						{Name: "first-cert", Certificate: []byte("cert"), PrivateKey: PrivateBytes("first-key")},
+					},
+				},
+				Routes: []*HTTPRoute{
+					{
+						Name: "route",
+						Security: &SecurityFeatures{
+							BasicAuth: &BasicAuth{Name: "basic-auth", Users: PrivateBytes("users")},
+							APIKeyAuth: &APIKeyAuth{
+								Credentials: map[string]PrivateBytes{"client": PrivateBytes("key")},
+							},
+						},
+					},
+				},
+			},
+			{
+				CoreListenerDetails: CoreListenerDetails{Name: "second"},
+				TLS: &TLSConfig{
+					Certificates: []TLSCertificate{
+						{Name: "second-cert", Certificate: []byte("cert"), PrivateKey: PrivateBytes("second-key")},
+					},
+				},
+			},
+		},
+	}
+
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "first-key")
+
+	modified := &Xds{}
+	require.NoError(t, json.Unmarshal(data, modified))
+	require.Equal(t, PrivateBytes(redacted), modified.HTTP[0].TLS.Certificates[0].PrivateKey)
+
+	// The listeners are reordered and a new one is added without secret.
+	modified.HTTP[0], modified.HTTP[1] = modified.HTTP[1], modified.HTTP[0]
+	modified.HTTP = append(modified.HTTP, &HTTPListener{
+		CoreListenerDetails: CoreListenerDetails{Name: "third"},
+		TLS: &TLSConfig{
+			Certificates: []TLSCertificate{{Name: "third-cert", PrivateKey: PrivateBytes("third-key")}},
+		},
+	})
+
+	modified.RestoreRedacted(original)
+	require.Equal(t, PrivateBytes("second-key"), modified.HTTP[0].TLS.Certificates[0].PrivateKey)
+	require.Equal(t, PrivateBytes("first-key"), modified.HTTP[1].TLS.Certificates[0].PrivateKey)
+	require.Equal(t, PrivateBytes("users"), modified.HTTP[1].Routes[0].Security.BasicAuth.Users)
+	require.Equal(t, PrivateBytes("third-key"), modified.HTTP[2].TLS.Certificates[0].PrivateKey)
+	require.Equal(t, PrivateBytes("key"), modified.HTTP[1].Routes[0].Security.APIKeyAuth.Credentials["client"])
+	require.Equal(t, original.HTTP[0], modified.HTTP[1])
+}
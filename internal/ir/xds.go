@@ -31,6 +31,11 @@ import (
 
 const (
 	EmptyPath = ""
+
+	// XdsSchemaVersion is the version of the JSON schema of Xds sent to the extensions. It must be
+	// bumped when the JSON fields of Xds are renamed, removed or given a different meaning, adding
+	// fields doesn't require a new version.
+	XdsSchemaVersion = "v1"
 )
 
 var (
@@ -801,6 +806,8 @@ type Compression struct {
 type TrafficFeatures struct {
 	// Name of the backend traffic policy and namespace
 	Name string `json:"name,omitempty"`
+	// Metadata is the metadata of the backend traffic policy.
+	Metadata *ResourceMetadata `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	// RateLimit defines the more specific match conditions as well as limits for ratelimiting
 	// the requests on this route.
 	RateLimit *RateLimit `json:"rateLimit,omitempty" yaml:"rateLimit,omitempty"`
@@ -1494,6 +1501,8 @@ type DestinationSetting struct {
 	// ZoneAwareRoutingEnabled specifies whether to enable Zone Aware Routing for this destination's endpoints.
 	// This is derived from the backend service and depends on having Kubernetes Topology Aware Routing or Traffic Distribution enabled.
 	ZoneAwareRoutingEnabled bool `json:"zoneAwareRoutingEnabled,omitempty" yaml:"zoneAwareRoutingEnabled,omitempty"`
+	// Metadata is the metadata of the backend this destination was generated from, e.g. a Service or a Backend.
+	Metadata *ResourceMetadata `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
 // Validate the fields within the DestinationSetting structure
@@ -33,6 +33,10 @@ type ProviderResources struct {
 
 	// ExtensionStatuses is a group of gw-api extension resource statuses map.
 	ExtensionStatuses
+
+	// XdsStatuses is a map from an IR key to the status of its xDS
+	// resources as reported by the proxies.
+	XdsStatuses watchable.Map[string, *xdstypes.XdsStatus]
 }
 
 func (p *ProviderResources) GetResources() []*resource.Resources {
@@ -71,6 +75,7 @@ func (p *ProviderResources) Close() {
 	p.GatewayAPIResources.Close()
 	p.GatewayAPIStatuses.Close()
 	p.PolicyStatuses.Close()
+	p.XdsStatuses.Close()
 }
 
 // GatewayAPIStatuses contains gateway API resources statuses
@@ -19,6 +19,7 @@ import (
 	"github.com/fsnotify/fsnotify"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
@@ -33,6 +34,7 @@ type Provider struct {
 	logger         logr.Logger
 	watcher        filewatcher.FileWatcher
 	resourcesStore *resourcesStore
+	statusStore    *statusStore
 
 	// ready indicates whether the provider can start watching filesystem events.
 	ready atomic.Bool
@@ -41,8 +43,10 @@ type Provider struct {
 func New(svr *config.Server, resources *message.ProviderResources) (*Provider, error) {
 	logger := svr.Logger.Logger
 	paths := sets.New[string]()
+	var statusPath string
 	if svr.EnvoyGateway.Provider.Custom.Resource.File != nil {
 		paths.Insert(svr.EnvoyGateway.Provider.Custom.Resource.File.Paths...)
+		statusPath = ptr.Deref(svr.EnvoyGateway.Provider.Custom.Resource.File.StatusPath, "")
 	}
 
 	return &Provider{
@@ -50,6 +54,7 @@ func New(svr *config.Server, resources *message.ProviderResources) (*Provider, e
 		logger:         logger,
 		watcher:        filewatcher.NewWatcher(),
 		resourcesStore: newResourcesStore(svr.EnvoyGateway.Gateway.ControllerName, resources, logger),
+		statusStore:    newStatusStore(statusPath, resources, logger),
 	}, nil
 }
 
@@ -71,6 +76,9 @@ func (p *Provider) Start(ctx context.Context) error {
 	}
 	go p.startHealthProbeServer(ctx, readyzChecker)
 
+	// Subscribe to the statuses of the loaded resources.
+	p.statusStore.Subscribe(ctx)
+
 	initDirs, initFiles := path.ListDirsAndFiles(p.paths)
 	// Initially load resources from paths on host.
 	if err := p.resourcesStore.LoadAndStore(initFiles.UnsortedList(), initDirs.UnsortedList()); err != nil {
@@ -163,8 +171,9 @@ func (p *Provider) Start(ctx context.Context) error {
 
 func (p *Provider) startHealthProbeServer(ctx context.Context, readyzChecker healthz.Checker) {
 	const (
-		readyzEndpoint  = "/readyz"
-		healthzEndpoint = "/healthz"
+		readyzEndpoint   = "/readyz"
+		healthzEndpoint  = "/healthz"
+		statusesEndpoint = "/statuses"
 	)
 
 	mux := http.NewServeMux()
@@ -194,6 +203,9 @@ func (p *Provider) startHealthProbeServer(ctx context.Context, readyzChecker hea
 	// Append '/' suffix to handle subpaths.
 	mux.Handle(healthzEndpoint+"/", http.StripPrefix(healthzEndpoint, readyzHandler))
 
+	// Serve the statuses of the loaded resources.
+	mux.Handle(statusesEndpoint, p.statusStore)
+
 	go func() {
 		<-ctx.Done()
 		if err := srv.Close(); err != nil {
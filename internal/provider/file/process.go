@@ -0,0 +1,408 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package file
+
+import (
+	"cmp"
+	"slices"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcsapiv1a1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/utils"
+)
+
+// loadedResources holds all the resources loaded from files and directories,
+// before they are grouped by GatewayClass.
+type loadedResources struct {
+	// gatewayClasses holds all the loaded GatewayClasses.
+	gatewayClasses []*gwapiv1.GatewayClass
+	// envoyProxies holds all the loaded EnvoyProxies.
+	envoyProxies []*egv1a1.EnvoyProxy
+	// fileEnvoyProxies maps a GatewayClass name to the EnvoyProxy that was loaded
+	// from the same file, it's used when the GatewayClass has no parametersRef.
+	fileEnvoyProxies map[string]*egv1a1.EnvoyProxy
+	// all holds all the other loaded resources.
+	all *resource.Resources
+}
+
+// mergeResources merges the resources loaded from each file into a single set of resources.
+func mergeResources(rs []*resource.Resources) *loadedResources {
+	lr := &loadedResources{
+		fileEnvoyProxies: make(map[string]*egv1a1.EnvoyProxy),
+		all:              resource.NewResources(),
+	}
+
+	namespaces := sets.New[string]()
+	for _, r := range rs {
+		if r == nil {
+			continue
+		}
+
+		if r.EnvoyProxyForGatewayClass != nil {
+			lr.envoyProxies = append(lr.envoyProxies, r.EnvoyProxyForGatewayClass)
+		}
+		lr.envoyProxies = append(lr.envoyProxies, r.EnvoyProxiesForGateways...)
+		if r.GatewayClass != nil {
+			lr.gatewayClasses = append(lr.gatewayClasses, r.GatewayClass)
+			if r.EnvoyProxyForGatewayClass != nil {
+				lr.fileEnvoyProxies[r.GatewayClass.Name] = r.EnvoyProxyForGatewayClass
+			}
+		}
+
+		for _, ns := range r.Namespaces {
+			if !namespaces.Has(ns.Name) {
+				namespaces.Insert(ns.Name)
+				lr.all.Namespaces = append(lr.all.Namespaces, ns)
+			}
+		}
+
+		lr.all.Gateways = append(lr.all.Gateways, r.Gateways...)
+		lr.all.HTTPRoutes = append(lr.all.HTTPRoutes, r.HTTPRoutes...)
+		lr.all.GRPCRoutes = append(lr.all.GRPCRoutes, r.GRPCRoutes...)
+		lr.all.TLSRoutes = append(lr.all.TLSRoutes, r.TLSRoutes...)
+		lr.all.TCPRoutes = append(lr.all.TCPRoutes, r.TCPRoutes...)
+		lr.all.UDPRoutes = append(lr.all.UDPRoutes, r.UDPRoutes...)
+		lr.all.ReferenceGrants = append(lr.all.ReferenceGrants, r.ReferenceGrants...)
+		lr.all.Services = append(lr.all.Services, r.Services...)
+		lr.all.ServiceImports = append(lr.all.ServiceImports, r.ServiceImports...)
+		lr.all.EndpointSlices = append(lr.all.EndpointSlices, r.EndpointSlices...)
+		lr.all.Secrets = append(lr.all.Secrets, r.Secrets...)
+		lr.all.ConfigMaps = append(lr.all.ConfigMaps, r.ConfigMaps...)
+		lr.all.ExtensionRefFilters = append(lr.all.ExtensionRefFilters, r.ExtensionRefFilters...)
+		lr.all.EnvoyPatchPolicies = append(lr.all.EnvoyPatchPolicies, r.EnvoyPatchPolicies...)
+		lr.all.ClientTrafficPolicies = append(lr.all.ClientTrafficPolicies, r.ClientTrafficPolicies...)
+		lr.all.BackendTrafficPolicies = append(lr.all.BackendTrafficPolicies, r.BackendTrafficPolicies...)
+		lr.all.SecurityPolicies = append(lr.all.SecurityPolicies, r.SecurityPolicies...)
+		lr.all.BackendTLSPolicies = append(lr.all.BackendTLSPolicies, r.BackendTLSPolicies...)
+		lr.all.EnvoyExtensionPolicies = append(lr.all.EnvoyExtensionPolicies, r.EnvoyExtensionPolicies...)
+		lr.all.ExtensionServerPolicies = append(lr.all.ExtensionServerPolicies, r.ExtensionServerPolicies...)
+		lr.all.Backends = append(lr.all.Backends, r.Backends...)
+		lr.all.HTTPRouteFilters = append(lr.all.HTTPRouteFilters, r.HTTPRouteFilters...)
+	}
+
+	return lr
+}
+
+// groupByGatewayClass groups the loaded resources by the GatewayClasses managed by
+// the given controller, the same way as the Kubernetes provider does:
+//
+//   - Gateways are grouped by their gatewayClassName.
+//   - Routes are grouped by the Gateways they are attached to by parentRefs.
+//   - EnvoyProxies are resolved from the parametersRef of GatewayClasses and Gateways.
+//   - Policies are grouped by the GatewayClass, Gateways, routes and backends they target.
+//   - Secrets, ConfigMaps, Services, ServiceImports, Backends, HTTPRouteFilters and the EndpointSlices
+//     of the Services and ServiceImports are grouped by the resources of the GatewayClass referencing them.
+//   - Namespaces, ReferenceGrants and the extension resources are shared by all the GatewayClasses.
+//
+// The GatewayClasses are sorted by name to keep the output stable.
+func groupByGatewayClass(controllerName string, rs []*resource.Resources) resource.ControllerResources {
+	lr := mergeResources(rs)
+
+	managedGCs := make([]*gwapiv1.GatewayClass, 0, len(lr.gatewayClasses))
+	seen := sets.New[string]()
+	for _, gc := range lr.gatewayClasses {
+		if string(gc.Spec.ControllerName) != controllerName || seen.Has(gc.Name) {
+			continue
+		}
+		seen.Insert(gc.Name)
+		managedGCs = append(managedGCs, gc)
+	}
+	slices.SortFunc(managedGCs, func(a, b *gwapiv1.GatewayClass) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	gwcResources := make(resource.ControllerResources, 0, len(managedGCs))
+	for _, gc := range managedGCs {
+		gwcResources = append(gwcResources, lr.resourcesForGatewayClass(gc))
+	}
+
+	return gwcResources
+}
+
+// resourcesForGatewayClass collects the resources associated with the given GatewayClass.
+func (lr *loadedResources) resourcesForGatewayClass(gc *gwapiv1.GatewayClass) *resource.Resources {
+	gwcResource := resource.NewResources()
+	gwcResource.GatewayClass = gc
+	gwcResource.EnvoyProxyForGatewayClass = lr.envoyProxyForGatewayClass(gc)
+
+	targets := newPolicyTargets(gc.Name)
+	refs := resource.NewReferences()
+	if gwcResource.EnvoyProxyForGatewayClass != nil {
+		refs.Add(gwcResource.EnvoyProxyForGatewayClass.Namespace, gwcResource.EnvoyProxyForGatewayClass.Spec)
+	}
+
+	gateways := sets.New[types.NamespacedName]()
+	envoyProxies := sets.New[types.NamespacedName]()
+	for _, gtw := range lr.all.Gateways {
+		if string(gtw.Spec.GatewayClassName) != gc.Name {
+			continue
+		}
+		gateways.Insert(utils.NamespacedName(gtw))
+		gwcResource.Gateways = append(gwcResource.Gateways, gtw)
+		targets.add(resource.KindGateway, gtw)
+		refs.Add(gtw.Namespace, gtw.Spec)
+
+		ep := lr.envoyProxyForGateway(gtw)
+		if ep != nil && !envoyProxies.Has(utils.NamespacedName(ep)) {
+			envoyProxies.Insert(utils.NamespacedName(ep))
+			gwcResource.EnvoyProxiesForGateways = append(gwcResource.EnvoyProxiesForGateways, ep)
+			refs.Add(ep.Namespace, ep.Spec)
+		}
+	}
+
+	for _, route := range lr.all.HTTPRoutes {
+		if refsAnyGateway(gateways, route.Namespace, route.Spec.ParentRefs) {
+			gwcResource.HTTPRoutes = append(gwcResource.HTTPRoutes, route)
+			targets.add(resource.KindHTTPRoute, route)
+			refs.Add(route.Namespace, route.Spec)
+		}
+	}
+	for _, route := range lr.all.GRPCRoutes {
+		if refsAnyGateway(gateways, route.Namespace, route.Spec.ParentRefs) {
+			gwcResource.GRPCRoutes = append(gwcResource.GRPCRoutes, route)
+			targets.add(resource.KindGRPCRoute, route)
+			refs.Add(route.Namespace, route.Spec)
+		}
+	}
+	for _, route := range lr.all.TLSRoutes {
+		if refsAnyGateway(gateways, route.Namespace, route.Spec.ParentRefs) {
+			gwcResource.TLSRoutes = append(gwcResource.TLSRoutes, route)
+			targets.add(resource.KindTLSRoute, route)
+			refs.Add(route.Namespace, route.Spec)
+		}
+	}
+	for _, route := range lr.all.TCPRoutes {
+		if refsAnyGateway(gateways, route.Namespace, route.Spec.ParentRefs) {
+			gwcResource.TCPRoutes = append(gwcResource.TCPRoutes, route)
+			targets.add(resource.KindTCPRoute, route)
+			refs.Add(route.Namespace, route.Spec)
+		}
+	}
+	for _, route := range lr.all.UDPRoutes {
+		if refsAnyGateway(gateways, route.Namespace, route.Spec.ParentRefs) {
+			gwcResource.UDPRoutes = append(gwcResource.UDPRoutes, route)
+			targets.add(resource.KindUDPRoute, route)
+			refs.Add(route.Namespace, route.Spec)
+		}
+	}
+
+	for _, policy := range lr.all.EnvoyPatchPolicies {
+		ref := policy.Spec.TargetRef
+		if targets.targetsAny(policy.Namespace, egv1a1.PolicyTargetReferences{
+			TargetRefs: []gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{{LocalPolicyTargetReference: ref}},
+		}) {
+			gwcResource.EnvoyPatchPolicies = append(gwcResource.EnvoyPatchPolicies, policy)
+		}
+	}
+	for _, policy := range lr.all.ClientTrafficPolicies {
+		if targets.targetsAny(policy.Namespace, policy.Spec.PolicyTargetReferences) {
+			gwcResource.ClientTrafficPolicies = append(gwcResource.ClientTrafficPolicies, policy)
+			refs.Add(policy.Namespace, policy.Spec)
+		}
+	}
+	for _, policy := range lr.all.BackendTrafficPolicies {
+		if targets.targetsAny(policy.Namespace, policy.Spec.PolicyTargetReferences) {
+			gwcResource.BackendTrafficPolicies = append(gwcResource.BackendTrafficPolicies, policy)
+			refs.Add(policy.Namespace, policy.Spec)
+		}
+	}
+	for _, policy := range lr.all.SecurityPolicies {
+		if targets.targetsAny(policy.Namespace, policy.Spec.PolicyTargetReferences) {
+			gwcResource.SecurityPolicies = append(gwcResource.SecurityPolicies, policy)
+			refs.Add(policy.Namespace, policy.Spec)
+		}
+	}
+	for _, policy := range lr.all.EnvoyExtensionPolicies {
+		if targets.targetsAny(policy.Namespace, policy.Spec.PolicyTargetReferences) {
+			gwcResource.EnvoyExtensionPolicies = append(gwcResource.EnvoyExtensionPolicies, policy)
+			refs.Add(policy.Namespace, policy.Spec)
+		}
+	}
+
+	// The HTTPRouteFilters and Backends may reference other resources, so they are collected
+	// before the Services, Secrets and ConfigMaps.
+	for _, filter := range lr.all.HTTPRouteFilters {
+		if refs.HTTPRouteFilters.Has(utils.NamespacedName(filter)) {
+			gwcResource.HTTPRouteFilters = append(gwcResource.HTTPRouteFilters, filter)
+			refs.Add(filter.Namespace, filter.Spec)
+		}
+	}
+	for _, backend := range lr.all.Backends {
+		if refs.Backends.Has(utils.NamespacedName(backend)) {
+			gwcResource.Backends = append(gwcResource.Backends, backend)
+			targets.add(resource.KindBackend, backend)
+			refs.Add(backend.Namespace, backend.Spec)
+		}
+	}
+	for _, svc := range lr.all.Services {
+		if refs.Services.Has(utils.NamespacedName(svc)) {
+			gwcResource.Services = append(gwcResource.Services, svc)
+			targets.add(resource.KindService, svc)
+		}
+	}
+	for _, svcImport := range lr.all.ServiceImports {
+		if refs.ServiceImports.Has(utils.NamespacedName(svcImport)) {
+			gwcResource.ServiceImports = append(gwcResource.ServiceImports, svcImport)
+			targets.add(resource.KindServiceImport, svcImport)
+		}
+	}
+	for _, endpointSlice := range lr.all.EndpointSlices {
+		nn := types.NamespacedName{Namespace: endpointSlice.Namespace, Name: endpointSlice.Labels[discoveryv1.LabelServiceName]}
+		importNN := types.NamespacedName{Namespace: endpointSlice.Namespace, Name: endpointSlice.Labels[mcsapiv1a1.LabelServiceName]}
+		if refs.Services.Has(nn) || refs.ServiceImports.Has(importNN) {
+			gwcResource.EndpointSlices = append(gwcResource.EndpointSlices, endpointSlice)
+		}
+	}
+	for _, policy := range lr.all.BackendTLSPolicies {
+		if targets.targetsAny(policy.Namespace, egv1a1.PolicyTargetReferences{TargetRefs: policy.Spec.TargetRefs}) {
+			gwcResource.BackendTLSPolicies = append(gwcResource.BackendTLSPolicies, policy)
+			refs.Add(policy.Namespace, policy.Spec)
+		}
+	}
+
+	for _, secret := range lr.all.Secrets {
+		// The HMAC Secret of OIDC is not referenced by the SecurityPolicies.
+		if refs.Secrets.Has(utils.NamespacedName(secret)) || (secret.Name == gatewayapi.OIDCHMACSecretName && len(gwcResource.SecurityPolicies) > 0) {
+			gwcResource.Secrets = append(gwcResource.Secrets, secret)
+		}
+	}
+	for _, configMap := range lr.all.ConfigMaps {
+		if refs.ConfigMaps.Has(utils.NamespacedName(configMap)) {
+			gwcResource.ConfigMaps = append(gwcResource.ConfigMaps, configMap)
+		}
+	}
+
+	gwcResource.Namespaces = lr.all.Namespaces
+	gwcResource.ReferenceGrants = lr.all.ReferenceGrants
+	gwcResource.ExtensionRefFilters = lr.all.ExtensionRefFilters
+	gwcResource.ExtensionServerPolicies = lr.all.ExtensionServerPolicies
+
+	return gwcResource
+}
+
+// envoyProxyForGatewayClass returns the EnvoyProxy referenced by the parametersRef of the GatewayClass.
+// For backward compatibility, the EnvoyProxy loaded from the same file as the GatewayClass
+// is returned if the GatewayClass has no parametersRef.
+func (lr *loadedResources) envoyProxyForGatewayClass(gc *gwapiv1.GatewayClass) *egv1a1.EnvoyProxy {
+	ref := gc.Spec.ParametersRef
+	if ref == nil {
+		return lr.fileEnvoyProxies[gc.Name]
+	}
+	if string(ref.Group) != egv1a1.GroupName || string(ref.Kind) != egv1a1.KindEnvoyProxy {
+		return nil
+	}
+
+	// The GatewayClass is cluster-scoped, so the parametersRef can't be resolved without a namespace.
+	if ref.Namespace == nil {
+		return nil
+	}
+	return lr.getEnvoyProxy(string(*ref.Namespace), ref.Name)
+}
+
+// envoyProxyForGateway returns the EnvoyProxy referenced by the infrastructure parametersRef of the Gateway.
+func (lr *loadedResources) envoyProxyForGateway(gtw *gwapiv1.Gateway) *egv1a1.EnvoyProxy {
+	if gtw.Spec.Infrastructure == nil || gtw.Spec.Infrastructure.ParametersRef == nil {
+		return nil
+	}
+
+	ref := gtw.Spec.Infrastructure.ParametersRef
+	if string(ref.Group) != egv1a1.GroupName || string(ref.Kind) != egv1a1.KindEnvoyProxy {
+		return nil
+	}
+	return lr.getEnvoyProxy(gtw.Namespace, ref.Name)
+}
+
+func (lr *loadedResources) getEnvoyProxy(namespace, name string) *egv1a1.EnvoyProxy {
+	for _, ep := range lr.envoyProxies {
+		if ep.Namespace == namespace && ep.Name == name {
+			return ep
+		}
+	}
+	return nil
+}
+
+// refsAnyGateway returns true if any of the parentRefs refers to one of the given Gateways.
+func refsAnyGateway(gateways sets.Set[types.NamespacedName], routeNamespace string, parentRefs []gwapiv1.ParentReference) bool {
+	for _, ref := range parentRefs {
+		if ref.Group != nil && *ref.Group != gwapiv1.GroupName {
+			continue
+		}
+		if ref.Kind != nil && *ref.Kind != resource.KindGateway {
+			continue
+		}
+
+		namespace := routeNamespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		if gateways.Has(types.NamespacedName{Namespace: namespace, Name: string(ref.Name)}) {
+			return true
+		}
+	}
+	return false
+}
+
+// policyTargets holds the resources of a GatewayClass that policies can target, by kind.
+type policyTargets struct {
+	gatewayClass string
+	objects      map[string]map[types.NamespacedName]map[string]string
+}
+
+func newPolicyTargets(gatewayClass string) *policyTargets {
+	return &policyTargets{
+		gatewayClass: gatewayClass,
+		objects:      make(map[string]map[types.NamespacedName]map[string]string),
+	}
+}
+
+func (t *policyTargets) add(kind string, obj metav1.Object) {
+	if t.objects[kind] == nil {
+		t.objects[kind] = make(map[types.NamespacedName]map[string]string)
+	}
+	t.objects[kind][types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}] = obj.GetLabels()
+}
+
+// targetsAny returns true if a policy in the given namespace targets the GatewayClass, or one
+// of its resources by reference or by label selector.
+func (t *policyTargets) targetsAny(namespace string, refs egv1a1.PolicyTargetReferences) bool {
+	for _, ref := range refs.GetTargetRefs() {
+		if string(ref.Kind) == resource.KindGatewayClass {
+			if string(ref.Name) == t.gatewayClass {
+				return true
+			}
+			continue
+		}
+		if _, ok := t.objects[string(ref.Kind)][types.NamespacedName{Namespace: namespace, Name: string(ref.Name)}]; ok {
+			return true
+		}
+	}
+
+	for _, ts := range refs.TargetSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+			MatchLabels:      ts.MatchLabels,
+			MatchExpressions: ts.MatchExpressions,
+		})
+		if err != nil {
+			continue
+		}
+		for nn, objLabels := range t.objects[string(ts.Kind)] {
+			if nn.Namespace == namespace && selector.Matches(labels.Set(objLabels)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	multiGatewayClassController = "gateway.envoyproxy.io/gatewayclass-controller"
+
+	internalGatewayClassYAML = `
+apiVersion: gateway.networking.k8s.io/v1
+kind: GatewayClass
+metadata:
+  name: internal
+spec:
+  controllerName: gateway.envoyproxy.io/gatewayclass-controller
+  parametersRef:
+    group: gateway.envoyproxy.io
+    kind: EnvoyProxy
+    name: internal-proxy
+    namespace: envoy-gateway-system
+`
+	externalGatewayClassYAML = `
+apiVersion: gateway.networking.k8s.io/v1
+kind: GatewayClass
+metadata:
+  name: external
+spec:
+  controllerName: gateway.envoyproxy.io/gatewayclass-controller
+---
+apiVersion: gateway.envoyproxy.io/v1alpha1
+kind: EnvoyProxy
+metadata:
+  name: external-proxy
+spec:
+  mergeGateways: true
+`
+	unresolvedGatewayClassYAML = `
+apiVersion: gateway.networking.k8s.io/v1
+kind: GatewayClass
+metadata:
+  name: unresolved
+spec:
+  controllerName: gateway.envoyproxy.io/gatewayclass-controller
+  parametersRef:
+    group: gateway.envoyproxy.io
+    kind: EnvoyProxy
+    name: internal-proxy
+`
+	otherGatewayClassYAML = `
+apiVersion: gateway.networking.k8s.io/v1
+kind: GatewayClass
+metadata:
+  name: other
+spec:
+  controllerName: example.com/other-controller
+`
+	envoyProxyYAML = `
+apiVersion: gateway.envoyproxy.io/v1alpha1
+kind: EnvoyProxy
+metadata:
+  name: internal-proxy
+spec:
+  mergeGateways: false
+`
+	internalGatewayYAML = `
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: internal-gw
+spec:
+  gatewayClassName: internal
+  listeners:
+    - name: http
+      protocol: HTTP
+      port: 8080
+`
+	externalGatewayYAML = `
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: external-gw
+spec:
+  gatewayClassName: external
+  listeners:
+    - name: http
+      protocol: HTTP
+      port: 8081
+`
+	internalRouteYAML = `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: internal-route
+spec:
+  parentRefs:
+    - name: internal-gw
+  rules:
+    - backendRefs:
+        - group: gateway.envoyproxy.io
+          kind: Backend
+          name: backend
+`
+	externalRouteYAML = `
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: external-route
+spec:
+  parentRefs:
+    - name: external-gw
+  rules:
+    - backendRefs:
+        - group: gateway.envoyproxy.io
+          kind: Backend
+          name: backend
+---
+apiVersion: gateway.envoyproxy.io/v1alpha1
+kind: Backend
+metadata:
+  name: backend
+spec:
+  endpoints:
+    - ip:
+        address: 0.0.0.0
+        port: 3000
+---
+apiVersion: gateway.envoyproxy.io/v1alpha1
+kind: SecurityPolicy
+metadata:
+  name: basic-auth
+spec:
+  targetRefs:
+    - group: gateway.networking.k8s.io
+      kind: HTTPRoute
+      name: external-route
+  basicAuth:
+    users:
+      name: basic-auth-users
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: basic-auth-users
+data:
+  .htpasswd: Zm9vOntTSEF9WXZ1N2FKYk1zZjkxa0hRdnZ4Q0t6V1Q3aHpRPQo=
+`
+)
+
+func Test_groupByGatewayClass(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"gatewayclass-internal.yaml":   internalGatewayClassYAML,
+		"gatewayclass-external.yaml":   externalGatewayClassYAML,
+		"gatewayclass-other.yaml":      otherGatewayClassYAML,
+		"gatewayclass-unresolved.yaml": unresolvedGatewayClassYAML,
+		"envoyproxy.yaml":              envoyProxyYAML,
+		"gateway-internal.yaml":        internalGatewayYAML,
+		"gateway-external.yaml":        externalGatewayYAML,
+		"route-internal.yaml":          internalRouteYAML,
+		"route-external.yaml":          externalRouteYAML,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	rs, err := loadFromFilesAndDirs(nil, []string{dir})
+	require.NoError(t, err)
+
+	gwcResources := groupByGatewayClass(multiGatewayClassController, rs)
+	require.Len(t, gwcResources, 3)
+
+	external := gwcResources[0]
+	require.Equal(t, "external", external.GatewayClass.Name)
+	require.NotNil(t, external.EnvoyProxyForGatewayClass)
+	require.Equal(t, "external-proxy", external.EnvoyProxyForGatewayClass.Name)
+	require.Len(t, external.Gateways, 1)
+	require.Equal(t, "external-gw", external.Gateways[0].Name)
+	require.Len(t, external.HTTPRoutes, 1)
+	require.Equal(t, "external-route", external.HTTPRoutes[0].Name)
+	require.Len(t, external.SecurityPolicies, 1)
+	require.Len(t, external.Secrets, 1)
+	require.Equal(t, "basic-auth-users", external.Secrets[0].Name)
+	require.Len(t, external.Backends, 1)
+	require.Len(t, external.Namespaces, 1)
+
+	internal := gwcResources[1]
+	require.Equal(t, "internal", internal.GatewayClass.Name)
+	require.NotNil(t, internal.EnvoyProxyForGatewayClass)
+	require.Equal(t, "internal-proxy", internal.EnvoyProxyForGatewayClass.Name)
+	require.Len(t, internal.Gateways, 1)
+	require.Equal(t, "internal-gw", internal.Gateways[0].Name)
+	require.Len(t, internal.HTTPRoutes, 1)
+	require.Equal(t, "internal-route", internal.HTTPRoutes[0].Name)
+	require.Len(t, internal.Backends, 1)
+	// The SecurityPolicy and its Secret are only related to the route of the external GatewayClass.
+	require.Empty(t, internal.SecurityPolicies)
+	require.Empty(t, internal.Secrets)
+	require.Len(t, internal.Namespaces, 1)
+
+	// The parametersRef of a GatewayClass without a namespace can't be resolved.
+	unresolved := gwcResources[2]
+	require.Equal(t, "unresolved", unresolved.GatewayClass.Name)
+	require.Nil(t, unresolved.EnvoyProxyForGatewayClass)
+	require.Empty(t, unresolved.Gateways)
+	require.Empty(t, unresolved.Backends)
+
+	t.Run("no managed GatewayClass", func(t *testing.T) {
+		require.Empty(t, groupByGatewayClass("example.com/unknown-controller", rs))
+	})
+}
@@ -0,0 +1,340 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package file
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/telepresenceio/watchable"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+	"sigs.k8s.io/yaml"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
+	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// resourceStatus is the status of a resource loaded by the file provider.
+type resourceStatus struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Status     any    `json:"status"`
+}
+
+type statusKey struct {
+	kind string
+	types.NamespacedName
+}
+
+// statusStore keeps the latest statuses of the resources loaded by the file provider,
+// and optionally writes them into files under a directory.
+type statusStore struct {
+	// dir is the directory where the statuses are written to, statuses are only
+	// kept in memory if it's empty.
+	dir       string
+	resources *message.ProviderResources
+	logger    logr.Logger
+
+	mu       sync.RWMutex
+	statuses map[statusKey]*resourceStatus
+	// gateways are the statuses of the Gateways as computed by the translator, before the
+	// xDS updates rejected by the proxies are reflected in them.
+	gateways map[types.NamespacedName]*gwapiv1.GatewayStatus
+}
+
+func newStatusStore(dir string, resources *message.ProviderResources, logger logr.Logger) *statusStore {
+	return &statusStore{
+		dir:       dir,
+		resources: resources,
+		logger:    logger,
+		statuses:  make(map[statusKey]*resourceStatus),
+		gateways:  make(map[types.NamespacedName]*gwapiv1.GatewayStatus),
+	}
+}
+
+// Subscribe subscribes to all the status updates published by the translators and
+// keeps them in the store.
+func (s *statusStore) Subscribe(ctx context.Context) {
+	gwAPIVersion := gwapiv1.GroupVersion.String()
+	gwAlphaAPIVersion := gwapiv1a2.GroupVersion.String()
+	egAPIVersion := egv1a1.GroupVersion.String()
+
+	go subscribeStatus(s, "gatewayclass-status", gwAPIVersion, resource.KindGatewayClass,
+		s.resources.GatewayClassStatuses.Subscribe(ctx))
+	go s.subscribeGatewayStatus(ctx)
+	go subscribeStatus(s, "httproute-status", gwAPIVersion, resource.KindHTTPRoute,
+		s.resources.HTTPRouteStatuses.Subscribe(ctx))
+	go subscribeStatus(s, "grpcroute-status", gwAPIVersion, resource.KindGRPCRoute,
+		s.resources.GRPCRouteStatuses.Subscribe(ctx))
+	go subscribeStatus(s, "tlsroute-status", gwAlphaAPIVersion, resource.KindTLSRoute,
+		s.resources.TLSRouteStatuses.Subscribe(ctx))
+	go subscribeStatus(s, "tcproute-status", gwAlphaAPIVersion, resource.KindTCPRoute,
+		s.resources.TCPRouteStatuses.Subscribe(ctx))
+	go subscribeStatus(s, "udproute-status", gwAlphaAPIVersion, resource.KindUDPRoute,
+		s.resources.UDPRouteStatuses.Subscribe(ctx))
+	go subscribeStatus(s, "envoypatchpolicy-status", egAPIVersion, resource.KindEnvoyPatchPolicy,
+		s.resources.EnvoyPatchPolicyStatuses.Subscribe(ctx))
+	go subscribeStatus(s, "clienttrafficpolicy-status", egAPIVersion, resource.KindClientTrafficPolicy,
+		s.resources.ClientTrafficPolicyStatuses.Subscribe(ctx))
+	go subscribeStatus(s, "backendtrafficpolicy-status", egAPIVersion, resource.KindBackendTrafficPolicy,
+		s.resources.BackendTrafficPolicyStatuses.Subscribe(ctx))
+	go subscribeStatus(s, "securitypolicy-status", egAPIVersion, resource.KindSecurityPolicy,
+		s.resources.SecurityPolicyStatuses.Subscribe(ctx))
+	go subscribeStatus(s, "backendtlspolicy-status", gwapiv1a3.GroupVersion.String(), resource.KindBackendTLSPolicy,
+		s.resources.BackendTLSPolicyStatuses.Subscribe(ctx))
+	go subscribeStatus(s, "envoyextensionpolicy-status", egAPIVersion, resource.KindEnvoyExtensionPolicy,
+		s.resources.EnvoyExtensionPolicyStatuses.Subscribe(ctx))
+	go subscribeStatus(s, "backend-status", egAPIVersion, resource.KindBackend,
+		s.resources.BackendStatuses.Subscribe(ctx))
+
+	// Extension server policies are keyed by their GVK as well.
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "extensionpolicy-status"},
+			s.resources.ExtensionPolicyStatuses.Subscribe(ctx),
+			func(update message.Update[message.NamespacedNameAndGVK, *gwapiv1a2.PolicyStatus], errChan chan error) {
+				key := statusKey{kind: update.Key.Kind, NamespacedName: update.Key.NamespacedName}
+				if update.Delete {
+					s.delete(key)
+					return
+				}
+
+				if err := s.store(key, &resourceStatus{
+					APIVersion: update.Key.GroupVersion().String(),
+					Kind:       update.Key.Kind,
+					Namespace:  update.Key.Namespace,
+					Name:       update.Key.Name,
+					Status:     update.Value,
+				}); err != nil {
+					errChan <- err
+				}
+			},
+		)
+		s.logger.Info("extension policy status subscriber shutting down")
+	}()
+}
+
+// subscribeGatewayStatus keeps the statuses of the Gateways, their Programmed condition is set
+// to false while the proxies reject their xDS configuration.
+func (s *statusStore) subscribeGatewayStatus(ctx context.Context) {
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "xds-status"},
+			s.resources.XdsStatuses.Subscribe(ctx),
+			func(update message.Update[string, *xdstypes.XdsStatus], errChan chan error) {
+				s.mu.RLock()
+				keys := make([]types.NamespacedName, 0, len(s.gateways))
+				for key := range s.gateways {
+					keys = append(keys, key)
+				}
+				s.mu.RUnlock()
+
+				for _, key := range keys {
+					if s.irKeyForGateway(key) != update.Key {
+						continue
+					}
+					if err := s.storeGatewayStatus(key); err != nil {
+						errChan <- err
+					}
+				}
+			},
+		)
+		s.logger.Info("xds status subscriber shutting down")
+	}()
+
+	message.HandleSubscription(
+		message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "gateway-status"},
+		s.resources.GatewayStatuses.Subscribe(ctx),
+		func(update message.Update[types.NamespacedName, *gwapiv1.GatewayStatus], errChan chan error) {
+			if update.Delete {
+				s.mu.Lock()
+				delete(s.gateways, update.Key)
+				s.mu.Unlock()
+				s.delete(statusKey{kind: resource.KindGateway, NamespacedName: update.Key})
+				return
+			}
+
+			s.mu.Lock()
+			s.gateways[update.Key] = update.Value
+			s.mu.Unlock()
+			if err := s.storeGatewayStatus(update.Key); err != nil {
+				errChan <- err
+			}
+		},
+	)
+	s.logger.Info("status subscriber shutting down", "kind", resource.KindGateway)
+}
+
+// storeGatewayStatus stores the status of a Gateway, reflecting the xDS updates rejected by the proxies.
+func (s *statusStore) storeGatewayStatus(key types.NamespacedName) error {
+	s.mu.RLock()
+	gatewayStatus, ok := s.gateways[key]
+	s.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	gateway := &gwapiv1.Gateway{Status: *gatewayStatus.DeepCopy()}
+	if s.resources.XdsStatuses.Len() > 0 {
+		if xdsStatus, ok := s.resources.XdsStatuses.Load(s.irKeyForGateway(key)); ok && len(xdsStatus.NACKs) > 0 {
+			status.UpdateGatewayStatusXdsRejected(gateway, xdsStatus.Message())
+		}
+	}
+
+	return s.store(statusKey{kind: resource.KindGateway, NamespacedName: key}, &resourceStatus{
+		APIVersion: gwapiv1.GroupVersion.String(),
+		Kind:       resource.KindGateway,
+		Namespace:  key.Namespace,
+		Name:       key.Name,
+		Status:     &gateway.Status,
+	})
+}
+
+// irKeyForGateway returns the key of the IR a Gateway is translated into.
+func (s *statusStore) irKeyForGateway(key types.NamespacedName) string {
+	gateway := &gwapiv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+	for _, resources := range s.resources.GetResources() {
+		for _, gw := range resources.Gateways {
+			if gw.Namespace == key.Namespace && gw.Name == key.Name {
+				return gatewayapi.GetIRKey(gw, gatewayapi.IsMergeGatewaysEnabled(resources))
+			}
+		}
+	}
+	return gatewayapi.GetIRKey(gateway, false)
+}
+
+func subscribeStatus[V any](
+	s *statusStore, msg, apiVersion, kind string,
+	sub <-chan watchable.Snapshot[types.NamespacedName, V],
+) {
+	message.HandleSubscription(
+		message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: msg},
+		sub,
+		func(update message.Update[types.NamespacedName, V], errChan chan error) {
+			key := statusKey{kind: kind, NamespacedName: update.Key}
+			if update.Delete {
+				s.delete(key)
+				return
+			}
+
+			if err := s.store(key, &resourceStatus{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Namespace:  update.Key.Namespace,
+				Name:       update.Key.Name,
+				Status:     update.Value,
+			}); err != nil {
+				errChan <- err
+			}
+		},
+	)
+	s.logger.Info("status subscriber shutting down", "kind", kind)
+}
+
+func (s *statusStore) store(key statusKey, status *resourceStatus) error {
+	s.mu.Lock()
+	s.statuses[key] = status
+	s.mu.Unlock()
+
+	if s.dir == "" {
+		return nil
+	}
+
+	out, err := yaml.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status of %s %s: %w", key.kind, key.NamespacedName, err)
+	}
+
+	path := s.statusFilePath(key)
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create status directory: %w", err)
+	}
+	// Write into a temporary file first, so readers never observe a partially written status.
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, out, 0o600); err != nil {
+		return fmt.Errorf("failed to write status of %s %s: %w", key.kind, key.NamespacedName, err)
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *statusStore) delete(key statusKey) {
+	s.mu.Lock()
+	delete(s.statuses, key)
+	s.mu.Unlock()
+
+	if s.dir == "" {
+		return
+	}
+
+	if err := os.Remove(s.statusFilePath(key)); err != nil && !os.IsNotExist(err) {
+		s.logger.Error(err, "failed to remove status file", "kind", key.kind, "resource", key.NamespacedName)
+	}
+}
+
+// statusFilePath returns the path of the status file of a resource, which is
+// <dir>/<kind>/<namespace>/<name>.status.yaml.
+func (s *statusStore) statusFilePath(key statusKey) string {
+	return filepath.Join(s.dir, key.kind, key.Namespace, key.Name+".status.yaml")
+}
+
+// List returns all the statuses sorted by kind, namespace and name.
+func (s *statusStore) List() []*resourceStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]*resourceStatus, 0, len(s.statuses))
+	for _, status := range s.statuses {
+		statuses = append(statuses, status)
+	}
+	slices.SortFunc(statuses, func(a, b *resourceStatus) int {
+		return cmp.Or(
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	return statuses
+}
+
+// ServeHTTP serves all the statuses as JSON, the statuses can be filtered by
+// the kind, namespace and name query parameters.
+func (s *statusStore) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	kind, namespace, name := query.Get("kind"), query.Get("namespace"), query.Get("name")
+
+	statuses := make([]*resourceStatus, 0)
+	for _, status := range s.List() {
+		if (kind != "" && kind != status.Kind) ||
+			(namespace != "" && namespace != status.Namespace) ||
+			(name != "" && name != status.Name) {
+			continue
+		}
+		statuses = append(statuses, status)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(statuses); err != nil {
+		s.logger.Error(err, "failed to write statuses")
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestStatusStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	resources := new(message.ProviderResources)
+	store := newStatusStore(dir, resources, logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo).Logger)
+	store.Subscribe(ctx)
+
+	key := types.NamespacedName{Namespace: "envoy-gateway-system", Name: "backend"}
+	resources.HTTPRouteStatuses.Store(key, &gwapiv1.HTTPRouteStatus{
+		RouteStatus: gwapiv1.RouteStatus{
+			Parents: []gwapiv1.RouteParentStatus{
+				{
+					ParentRef:      gwapiv1.ParentReference{Name: "eg"},
+					ControllerName: "gateway.envoyproxy.io/gatewayclass-controller",
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(gwapiv1.RouteConditionResolvedRefs),
+							Status: metav1.ConditionFalse,
+							Reason: string(gwapiv1.RouteReasonBackendNotFound),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	statusFile := filepath.Join(dir, "HTTPRoute", "envoy-gateway-system", "backend.status.yaml")
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(statusFile)
+		return err == nil
+	}, resourcesUpdateTimeout, resourcesUpdateTick)
+
+	t.Run("status file", func(t *testing.T) {
+		content, err := os.ReadFile(statusFile)
+		require.NoError(t, err)
+
+		got := map[string]any{}
+		require.NoError(t, yaml.Unmarshal(content, &got))
+		require.Equal(t, "HTTPRoute", got["kind"])
+		require.Equal(t, "gateway.networking.k8s.io/v1", got["apiVersion"])
+		require.Contains(t, string(content), string(gwapiv1.RouteReasonBackendNotFound))
+	})
+
+	t.Run("serve statuses", func(t *testing.T) {
+		for query, want := range map[string]int{
+			"":                 1,
+			"?kind=HTTPRoute":  1,
+			"?kind=Gateway":    0,
+			"?name=backend":    1,
+			"?namespace=other": 0,
+		} {
+			rec := httptest.NewRecorder()
+			store.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/statuses"+query, nil))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var got []map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			require.Len(t, got, want, query)
+		}
+	})
+
+	t.Run("delete status", func(t *testing.T) {
+		resources.HTTPRouteStatuses.Delete(key)
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(statusFile)
+			return os.IsNotExist(err)
+		}, resourcesUpdateTimeout, resourcesUpdateTick)
+		require.Empty(t, store.List())
+	})
+}
+
+func TestStatusStoreXdsRejected(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resources := new(message.ProviderResources)
+	store := newStatusStore(t.TempDir(), resources, logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo).Logger)
+	store.Subscribe(ctx)
+
+	key := types.NamespacedName{Namespace: "envoy-gateway-system", Name: "eg"}
+	resources.GatewayStatuses.Store(key, &gwapiv1.GatewayStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type:   string(gwapiv1.GatewayConditionAccepted),
+				Status: metav1.ConditionTrue,
+				Reason: string(gwapiv1.GatewayReasonAccepted),
+			},
+		},
+	})
+
+	programmed := func() *metav1.Condition {
+		for _, s := range store.List() {
+			if s.Kind != "Gateway" {
+				continue
+			}
+			gatewayStatus, ok := s.Status.(*gwapiv1.GatewayStatus)
+			if !ok {
+				return nil
+			}
+			return meta.FindStatusCondition(gatewayStatus.Conditions, string(gwapiv1.GatewayConditionProgrammed))
+		}
+		return nil
+	}
+	require.Eventually(t, func() bool {
+		return len(store.List()) == 1
+	}, resourcesUpdateTimeout, resourcesUpdateTick)
+	require.Nil(t, programmed())
+
+	// The Gateway is not programmed while the proxies reject its xDS configuration.
+	resources.XdsStatuses.Store("envoy-gateway-system/eg", &xdstypes.XdsStatus{
+		NACKs: []xdstypes.NACK{{NodeID: "envoy", TypeURL: "listeners", Message: "invalid listener"}},
+	})
+	require.Eventually(t, func() bool {
+		cond := programmed()
+		return cond != nil && cond.Status == metav1.ConditionFalse && strings.Contains(cond.Message, "invalid listener")
+	}, resourcesUpdateTimeout, resourcesUpdateTick)
+
+	resources.XdsStatuses.Delete("envoy-gateway-system/eg")
+	require.Eventually(t, func() bool {
+		return programmed() == nil
+	}, resourcesUpdateTimeout, resourcesUpdateTick)
+}
@@ -6,10 +6,15 @@
 package file
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
-	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
 	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/tracing"
+	"github.com/envoyproxy/gateway/internal/utils"
 )
 
 type resourcesStore struct {
@@ -29,7 +34,7 @@ func newResourcesStore(name string, resources *message.ProviderResources, logger
 
 // HandleEvent simply removes all the resources and triggers a resources reload from files
 // and directories despite of the event type.
-// TODO: Enhance this method by respecting the event type, and add support for multiple GatewayClass.
+// TODO: Enhance this method by respecting the event type.
 func (r *resourcesStore) HandleEvent(files, dirs []string) {
 	r.logger.Info("reload all resources")
 
@@ -41,33 +46,36 @@ func (r *resourcesStore) HandleEvent(files, dirs []string) {
 
 // LoadAndStore loads and stores all resources from files and directories.
 func (r *resourcesStore) LoadAndStore(files, dirs []string) error {
+	ctx, span := tracing.Start(context.Background(), "provider-load", tracing.ControllerKey.String(r.name))
+	defer span.End()
+
 	resources, err := loadFromFilesAndDirs(files, dirs)
 	if err != nil {
 		return err
 	}
 
-	// TODO(sh2): For now, we assume that one file only contains one GatewayClass and all its other
-	// related resources, like Gateway, HTTPRoute, etc. If we managed to extend Resources structure,
-	// we also need to process all the resources and its relationship, like what is done in
-	// Kubernetes provider. However, this will cause us to maintain two places of the same logic
-	// in each provider. The ideal case is two different providers share the same resources process logic.
-	//
-	// - This issue is tracked by https://github.com/envoyproxy/gateway/issues/3213
-
+	// Group all the loaded resources by the GatewayClasses managed by this controller,
+	// resources across files are processed together, so a GatewayClass, its Gateways
+	// and their related resources can be spread over multiple files.
 	// We cannot make sure by the time the Write event was triggered, whether the GatewayClass exist,
-	// so here we just simply Store the first gatewayapi.Resources that has GatewayClass.
-	gwcResources := make(resource.ControllerResources, 0, 1)
-	for _, res := range resources {
-		if res.GatewayClass != nil {
-			gwcResources = append(gwcResources, res)
-		}
-	}
+	// so here we just simply skip storing if no managed GatewayClass was found.
+	gwcResources := groupByGatewayClass(r.name, resources)
 	if len(gwcResources) == 0 {
 		return nil
 	}
 
+	for _, gwcResource := range gwcResources {
+		gc := status.SetGatewayClassAccepted(
+			gwcResource.GatewayClass.DeepCopy(),
+			true,
+			string(gwapiv1.GatewayClassReasonAccepted),
+			status.MsgValidGatewayClass)
+		r.resources.GatewayClassStatuses.Store(utils.NamespacedName(gc), &gc.Status)
+	}
+
+	tracing.Publish(ctx, tracing.ProviderResourcesMessage, r.name)
 	r.resources.GatewayAPIResources.Store(r.name, &gwcResources)
-	r.logger.Info("loaded and stored resources successfully")
+	r.logger.Info("loaded and stored resources successfully", "gatewayClasses", len(gwcResources))
 
 	return nil
 }
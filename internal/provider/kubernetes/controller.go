@@ -43,6 +43,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
 	"github.com/envoyproxy/gateway/internal/logging"
 	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/tracing"
 	"github.com/envoyproxy/gateway/internal/utils"
 	"github.com/envoyproxy/gateway/internal/utils/slice"
 	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
@@ -89,12 +90,12 @@ func newGatewayAPIController(ctx context.Context, mgr manager.Manager, cfg *conf
 	// Gather additional resources to watch from registered extensions
 	var extServerPoliciesGVKs []schema.GroupVersionKind
 	var extGVKs []schema.GroupVersionKind
-	if cfg.EnvoyGateway.ExtensionManager != nil {
-		for _, rsrc := range cfg.EnvoyGateway.ExtensionManager.Resources {
+	for _, extensionManager := range cfg.EnvoyGateway.GetExtensionManagers() {
+		for _, rsrc := range extensionManager.Resources {
 			gvk := schema.GroupVersionKind(rsrc)
 			extGVKs = append(extGVKs, gvk)
 		}
-		for _, rsrc := range cfg.EnvoyGateway.ExtensionManager.PolicyResources {
+		for _, rsrc := range extensionManager.PolicyResources {
 			gvk := schema.GroupVersionKind(rsrc)
 			extServerPoliciesGVKs = append(extServerPoliciesGVKs, gvk)
 		}
@@ -140,11 +141,11 @@ func newGatewayAPIController(ctx context.Context, mgr manager.Manager, cfg *conf
 			case <-ctx.Done():
 				return
 			case <-cfg.Elected:
-				r.subscribeAndUpdateStatus(ctx, cfg.EnvoyGateway.EnvoyGatewaySpec.ExtensionManager != nil)
+				r.subscribeAndUpdateStatus(ctx, len(cfg.EnvoyGateway.GetExtensionManagers()) > 0)
 			}
 		}()
 	} else {
-		r.subscribeAndUpdateStatus(ctx, cfg.EnvoyGateway.EnvoyGatewaySpec.ExtensionManager != nil)
+		r.subscribeAndUpdateStatus(ctx, len(cfg.EnvoyGateway.GetExtensionManagers()) > 0)
 	}
 	return nil
 }
@@ -175,6 +176,8 @@ func (r *gatewayAPIReconciler) Reconcile(ctx context.Context, _ reconcile.Reques
 		err        error
 	)
 	r.log.Info("reconciling gateways")
+	ctx, span := tracing.Start(ctx, "provider-reconcile", tracing.ControllerKey.String(string(r.classController)))
+	defer span.End()
 
 	// Get the GatewayClasses managed by the Envoy Gateway Controller.
 	managedGCs, err = r.managedGatewayClasses(ctx)
@@ -184,6 +187,7 @@ func (r *gatewayAPIReconciler) Reconcile(ctx context.Context, _ reconcile.Reques
 
 	// The gatewayclass was already deleted/finalized and there are stale queue entries.
 	if managedGCs == nil {
+		tracing.Publish(ctx, tracing.ProviderResourcesMessage, string(r.classController))
 		r.resources.GatewayAPIResources.Delete(string(r.classController))
 		r.log.Info("no accepted gatewayclass")
 		return reconcile.Result{}, nil
@@ -335,6 +339,7 @@ func (r *gatewayAPIReconciler) Reconcile(ctx context.Context, _ reconcile.Reques
 	// The Store is triggered even when there are no Gateways associated to the
 	// GatewayClass. This would happen in case the last Gateway is removed and the
 	// Store will be required to trigger a cleanup of envoy infra resources.
+	tracing.Publish(ctx, tracing.ProviderResourcesMessage, string(r.classController))
 	r.resources.GatewayAPIResources.Store(string(r.classController), &gwcResources)
 
 	r.log.Info("reconciled gateways successfully")
@@ -18,10 +18,12 @@ import (
 	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/utils"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
 )
 
 // subscribeAndUpdateStatus subscribes to gateway API object status updates and
@@ -81,6 +83,29 @@ func (r *gatewayAPIReconciler) subscribeAndUpdateStatus(ctx context.Context, ext
 		r.log.Info("gateway status subscriber shutting down")
 	}()
 
+	// Gateway object status updater for the xDS updates rejected by the proxies
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "xds-status"},
+			r.resources.XdsStatuses.Subscribe(ctx),
+			func(update message.Update[string, *xdstypes.XdsStatus], errChan chan error) {
+				gateways := new(gwapiv1.GatewayList)
+				if err := r.client.List(ctx, gateways); err != nil {
+					r.log.Error(err, "failed to list gateways")
+					errChan <- err
+					return
+				}
+				// Trigger a status update for the Gateways translated into the IR key.
+				for i := range gateways.Items {
+					if r.irKeyForGateway(&gateways.Items[i]) == update.Key {
+						r.updateGatewayStatus(&gateways.Items[i])
+					}
+				}
+			},
+		)
+		r.log.Info("xds status subscriber shutting down")
+	}()
+
 	// HTTPRoute object status updater
 	go func() {
 		message.HandleSubscription(
@@ -549,6 +574,15 @@ func isParentRefEqual(ref1, ref2 gwapiv1.ParentReference, routeNS string) bool {
 	return reflect.DeepEqual(ref1, ref2)
 }
 
+// irKeyForGateway returns the key of the IR the Gateway is translated into.
+func (r *gatewayAPIReconciler) irKeyForGateway(gtw *gwapiv1.Gateway) string {
+	mergeGateways := false
+	if resources := r.resources.GetResourcesByGatewayClass(string(gtw.Spec.GatewayClassName)); resources != nil {
+		mergeGateways = gatewayapi.IsMergeGatewaysEnabled(resources)
+	}
+	return gatewayapi.GetIRKey(gtw, mergeGateways)
+}
+
 func (r *gatewayAPIReconciler) updateStatusForGateway(ctx context.Context, gtw *gwapiv1.Gateway) {
 	// nil check for unit tests.
 	if r.statusUpdater == nil {
@@ -577,6 +611,12 @@ func (r *gatewayAPIReconciler) updateStatusForGateway(ctx context.Context, gtw *
 		status.UpdateGatewayStatusAccepted(gtw)
 		// update address field and programmed condition
 		status.UpdateGatewayStatusProgrammedCondition(gtw, svc, envoyObj, r.store.listNodeAddresses()...)
+		// the Gateway is not programmed if the proxies rejected its xDS configuration
+		if r.resources.XdsStatuses.Len() > 0 {
+			if xdsStatus, ok := r.resources.XdsStatuses.Load(r.irKeyForGateway(gtw)); ok && len(xdsStatus.NACKs) > 0 {
+				status.UpdateGatewayStatusXdsRejected(gtw, xdsStatus.Message())
+			}
+		}
 	}
 
 	key := utils.NamespacedName(gtw)
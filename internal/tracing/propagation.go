@@ -0,0 +1,56 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The messages whose updates carry the spans of the translation pipeline from one runner to the next.
+const (
+	ProviderResourcesMessage = "provider-resources"
+	XdsIRMessage             = "xds-ir"
+	XdsMessage               = "xds"
+)
+
+// messageKey is a key of a watchable message.
+type messageKey struct {
+	message string
+	key     string
+}
+
+// spanContexts are the span contexts of the last updates published to the watchable messages.
+// The watchable maps compare the published values to detect updates, so the span contexts are
+// carried next to the values rather than in them.
+var spanContexts sync.Map
+
+// Publish records the span of ctx as the parent of the spans handling the update of the key of a
+// message. It must be called before the update is stored.
+func Publish(ctx context.Context, message, key string) {
+	mk := messageKey{message: message, key: key}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsSampled() {
+		spanContexts.Store(mk, sc)
+		return
+	}
+	spanContexts.Delete(mk)
+}
+
+// Subscribe returns a context whose span is the span that published the last update of the key of
+// a message, or ctx if the update wasn't traced.
+func Subscribe(ctx context.Context, message, key string) context.Context {
+	if sc, ok := spanContexts.Load(messageKey{message: message, key: key}); ok {
+		return trace.ContextWithSpanContext(ctx, sc.(trace.SpanContext))
+	}
+	return ctx
+}
+
+// Forget forgets the span of the last update of the key of a message once the key is deleted.
+func Forget(message, key string) {
+	spanContexts.Delete(messageKey{message: message, key: key})
+}
@@ -0,0 +1,55 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPropagation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	// The span that published an update is the parent of the span handling it.
+	ctx, provider := Start(context.Background(), "provider-reconcile", ControllerKey.String("eg"))
+	Publish(ctx, ProviderResourcesMessage, "eg")
+	provider.End()
+
+	ctx, translate := Start(Subscribe(context.Background(), ProviderResourcesMessage, "eg"), "gatewayapi-translate")
+	Publish(ctx, XdsIRMessage, "default/eg")
+	translate.End()
+	require.Equal(t, provider.SpanContext().TraceID(), translate.SpanContext().TraceID())
+
+	_, xds := Start(Subscribe(context.Background(), XdsIRMessage, "default/eg"), "xds-translate")
+	xds.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	require.False(t, spans[0].Parent().IsValid())
+	require.Equal(t, spans[0].SpanContext().SpanID(), spans[1].Parent().SpanID())
+	require.Equal(t, spans[1].SpanContext().SpanID(), spans[2].Parent().SpanID())
+
+	// The updates of the other keys aren't related.
+	require.False(t, trace.SpanContextFromContext(Subscribe(context.Background(), XdsIRMessage, "default/other")).IsValid())
+
+	// The span is forgotten once the key is deleted.
+	Forget(XdsIRMessage, "default/eg")
+	require.False(t, trace.SpanContextFromContext(Subscribe(context.Background(), XdsIRMessage, "default/eg")).IsValid())
+
+	// The updates which aren't sampled aren't recorded.
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample())))
+	ctx, provider = Start(context.Background(), "provider-reconcile")
+	Publish(ctx, ProviderResourcesMessage, "eg")
+	provider.End()
+	require.False(t, trace.SpanContextFromContext(Subscribe(context.Background(), ProviderResourcesMessage, "eg")).IsValid())
+}
@@ -0,0 +1,138 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+)
+
+const (
+	// tracerName is the name of the tracer of the translation pipeline, and the service name
+	// of its traces.
+	tracerName = "envoy-gateway"
+
+	// ControllerKey is the attribute of the name of the controller whose resources are translated.
+	ControllerKey = attribute.Key("envoy_gateway.controller")
+	// GatewayClassKey is the attribute of the name of the GatewayClass whose resources are translated.
+	GatewayClassKey = attribute.Key("envoy_gateway.gateway_class")
+	// GatewaysKey is the attribute of the number of Gateways of the translated resources.
+	GatewaysKey = attribute.Key("envoy_gateway.gateways")
+	// IRKeysKey is the attribute of the IR keys published by a translation.
+	IRKeysKey = attribute.Key("envoy_gateway.ir_keys")
+	// IRKeyKey is the attribute of the IR key of a Gateway, which is its <namespace>/<name>, or
+	// the name of its GatewayClass if its Gateways are merged.
+	IRKeyKey = attribute.Key("envoy_gateway.ir_key")
+	// HookKey is the attribute of the extension hook invoked during the xDS translation.
+	HookKey = attribute.Key("envoy_gateway.extension_hook")
+)
+
+// ShutdownFunc flushes the spans which are not exported yet and disables tracing.
+type ShutdownFunc func(ctx context.Context) error
+
+// Init sets the global tracer provider, which exports the traces of the translation pipeline to
+// the OpenTelemetry sinks of the metrics. Tracing is disabled if it isn't configured. The returned
+// func must be called before tracing is initialized again, e.g. when the configuration is reloaded.
+func Init(cfg *config.Server) (ShutdownFunc, error) {
+	tracing := cfg.EnvoyGateway.GetEnvoyGatewayTracing()
+	if tracing == nil {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		return func(context.Context) error { return nil }, nil
+	}
+	logger := cfg.Logger.WithName("tracing")
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(tracerName))),
+		// The spans of a translation are sampled if the span of the provider update is.
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(tracing.GetSamplingRate() / 100))),
+	}
+	for _, sink := range cfg.EnvoyGateway.GetEnvoyGatewayTelemetry().Metrics.Sinks {
+		if sink.Type != egv1a1.MetricSinkTypeOpenTelemetry || sink.OpenTelemetry == nil {
+			continue
+		}
+		exporter, err := newExporter(sink.OpenTelemetry)
+		if err != nil {
+			return nil, err
+		}
+
+		// If we do not set the timeout for the exporter, we let the upstream set the default value for it.
+		batchOpts := []sdktrace.BatchSpanProcessorOption{}
+		if sink.OpenTelemetry.ExportTimeout != nil && len(*sink.OpenTelemetry.ExportTimeout) != 0 {
+			timeout, err := time.ParseDuration(string(*sink.OpenTelemetry.ExportTimeout))
+			if err != nil {
+				logger.Error(err, "failed to parse exporter timeout time format")
+				return nil, err
+			}
+			batchOpts = append(batchOpts, sdktrace.WithExportTimeout(timeout))
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter, batchOpts...))
+		logger.Info("initialized otel traces push endpoint", "protocol", sink.OpenTelemetry.Protocol,
+			"address", net.JoinHostPort(sink.OpenTelemetry.Host, fmt.Sprint(sink.OpenTelemetry.Port)))
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	return func(ctx context.Context) error {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// newExporter returns an OTLP exporter of the traces to an OpenTelemetry sink.
+func newExporter(sink *egv1a1.EnvoyGatewayOpenTelemetrySink) (sdktrace.SpanExporter, error) {
+	address := net.JoinHostPort(sink.Host, fmt.Sprint(sink.Port))
+	switch sink.Protocol {
+	case egv1a1.GRPCProtocol:
+		return otlptracegrpc.New(
+			context.Background(),
+			otlptracegrpc.WithEndpoint(address),
+			otlptracegrpc.WithInsecure(),
+		)
+	case egv1a1.HTTPProtocol:
+		return otlptracehttp.New(
+			context.Background(),
+			otlptracehttp.WithEndpoint(address),
+			otlptracehttp.WithInsecure(),
+		)
+	default:
+		return nil, fmt.Errorf("unsupported OpenTelemetry sink protocol %q", sink.Protocol)
+	}
+}
+
+// Start starts a span of the translation pipeline, which is a child of the span of ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends a span, and records err as its error if it isn't nil.
+func End(span trace.Span, err error) {
+	RecordError(span, err)
+	span.End()
+}
+
+// RecordError records err as the error of a span if it isn't nil.
+func RecordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package tracing
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+)
+
+func TestInit(t *testing.T) {
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+
+	recording := func() bool {
+		_, span := Start(context.Background(), "gatewayapi-translate")
+		defer span.End()
+		return span.IsRecording()
+	}
+
+	// The spans are recorded once tracing is enabled.
+	cfg.EnvoyGateway.Telemetry = &egv1a1.EnvoyGatewayTelemetry{Tracing: &egv1a1.EnvoyGatewayTracing{}}
+	shutdown, err := Init(cfg)
+	require.NoError(t, err)
+	require.True(t, recording())
+
+	// Tracing is disabled once it is shut down, e.g. when the configuration is reloaded.
+	require.NoError(t, shutdown(context.Background()))
+	require.False(t, recording())
+
+	// Tracing stays disabled when the reloaded configuration doesn't enable it.
+	cfg.EnvoyGateway.Telemetry = nil
+	shutdown, err = Init(cfg)
+	require.NoError(t, err)
+	require.False(t, recording())
+	require.NoError(t, shutdown(context.Background()))
+}
@@ -36,13 +36,16 @@ const (
 	envoyAdminAccessLogPath = "/dev/null"
 
 	// DefaultXdsServerPort is the default listening port of the xds-server.
-	DefaultXdsServerPort = 18000
+	DefaultXdsServerPort = egv1a1.DefaultXDSServerPort
 
 	wasmServerHost = envoyGatewayXdsServerHost
 	// DefaultWasmServerPort is the default listening port of the wasm HTTP server.
 	wasmServerPort = 18002
 
 	EnvoyStatsPort = 19001
+	// StatsPortMetadataKey is the key of the node metadata holding the port of the Prometheus
+	// stats listener, it is only set when the Prometheus stats are enabled.
+	StatsPortMetadataKey = "envoy_gateway_stats_port"
 
 	EnvoyReadinessPort = 19003
 	EnvoyReadinessPath = "/ready"
@@ -106,6 +109,9 @@ type serverParameters struct {
 	Address string
 	// Port is the port of the XDS Server that Envoy is managed by.
 	Port int32
+	// SocketPath is the path of the Unix domain socket of the server, which
+	// takes precedence over the address and port if set.
+	SocketPath string
 }
 
 type metricSink struct {
@@ -136,15 +142,18 @@ type overloadManagerParameters struct {
 }
 
 type RenderBootstrapConfigOptions struct {
-	IPFamily         *egv1a1.IPFamily
-	ProxyMetrics     *egv1a1.ProxyMetrics
-	SdsConfig        SdsConfigPath
-	XdsServerHost    *string
-	XdsServerPort    *int32
-	WasmServerPort   *int32
-	AdminServerPort  *int32
-	StatsServerPort  *int32
-	MaxHeapSizeBytes uint64
+	IPFamily      *egv1a1.IPFamily
+	ProxyMetrics  *egv1a1.ProxyMetrics
+	SdsConfig     SdsConfigPath
+	XdsServerHost *string
+	XdsServerPort *int32
+	// XdsServerSocketPath is the path of the Unix domain socket of the xDS server, if it
+	// listens on one rather than on TCP.
+	XdsServerSocketPath *string
+	WasmServerPort      *int32
+	AdminServerPort     *int32
+	StatsServerPort     *int32
+	MaxHeapSizeBytes    uint64
 }
 
 type SdsConfigPath struct {
@@ -287,6 +296,9 @@ func GetRenderedBootstrapConfig(opts *RenderBootstrapConfigOptions) (string, err
 		if opts.XdsServerPort != nil {
 			cfg.parameters.XdsServer.Port = *opts.XdsServerPort
 		}
+		if opts.XdsServerSocketPath != nil {
+			cfg.parameters.XdsServer.SocketPath = *opts.XdsServerSocketPath
+		}
 		if opts.AdminServerPort != nil {
 			cfg.parameters.AdminServer.Port = *opts.AdminServerPort
 		}
@@ -1,3 +1,8 @@
+{{ if .EnablePrometheus -}}
+node:
+  metadata:
+    envoy_gateway_stats_port: {{ .StatsServer.Port }}
+{{ end -}}
 admin:
   access_log:
   - name: envoy.access_loggers.file
@@ -175,9 +180,14 @@ static_resources:
         - load_balancing_weight: 1
           endpoint:
             address:
+              {{- if .XdsServer.SocketPath }}
+              pipe:
+                path: {{ .XdsServer.SocketPath }}
+              {{- else }}
               socket_address:
                 address: {{ .XdsServer.Address }}
                 port_value: {{ .XdsServer.Port }}
+              {{- end }}
     typed_extension_protocol_options:
       envoy.extensions.upstreams.http.v3.HttpProtocolOptions:
         "@type": "type.googleapis.com/envoy.extensions.upstreams.http.v3.HttpProtocolOptions"
@@ -187,6 +197,9 @@ static_resources:
               interval: 30s
               timeout: 5s
     name: xds_cluster
+    {{- if .XdsServer.SocketPath }}
+    type: STATIC
+    {{- else }}
     type: STRICT_DNS
     transport_socket:
       name: envoy.transport_sockets.tls
@@ -207,6 +220,7 @@ static_resources:
               path_config_source:
                 path: {{ .SdsTrustedCAPath }}
               resource_api_version: V3
+    {{- end }}
   - name: wasm_cluster
     type: STRICT_DNS
     connect_timeout: 10s
@@ -167,6 +167,13 @@ func TestGetRenderedBootstrapConfig(t *testing.T) {
 				SdsConfig:       sds,
 			},
 		},
+		{
+			name: "xds-server-unix-socket",
+			opts: &RenderBootstrapConfigOptions{
+				XdsServerSocketPath: ptr.To("/run/envoy-gateway/xds.sock"),
+				SdsConfig:           sds,
+			},
+		},
 		{
 			name: "with-max-heap-size-bytes",
 			opts: &RenderBootstrapConfigOptions{
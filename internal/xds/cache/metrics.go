@@ -24,7 +24,29 @@ var (
 		[]float64{0.1, 10, 50, 100, 1000, 10000},
 	)
 
+	xdsNACKTotal = metrics.NewCounter(
+		"xds_nack_total",
+		"Total number of xds updates rejected by node id and type url.",
+	)
+
+	xdsNACKActive = metrics.NewGauge(
+		"xds_nack_active",
+		"Whether the last xds update of a type url is currently rejected by node id.",
+	)
+
+	xdsSnapshotRollbackTotal = metrics.NewCounter(
+		"xds_snapshot_rollback_total",
+		"Total number of rollbacks to the last acknowledged xds snapshot by node id.",
+	)
+
+	xdsRolloutTotal = metrics.NewCounter(
+		"xds_rollout_total",
+		"Total number of staged xds snapshot rollouts completed or held.",
+	)
+
 	nodeIDLabel        = metrics.NewLabel("nodeID")
+	typeURLLabel       = metrics.NewLabel("typeURL")
+	rolloutPhaseLabel  = metrics.NewLabel("phase")
 	streamIDLabel      = metrics.NewLabel("streamID")
 	isDeltaStreamLabel = metrics.NewLabel("isDeltaStream")
 )
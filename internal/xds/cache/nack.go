@@ -0,0 +1,135 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// recordResponseVersion records the version of the last response of a type URL sent on a stream,
+// which is the version rejected if the node NACKs it.
+// The caller must hold the lock.
+func (s *snapshotCache) recordResponseVersion(streamID int64, typeURL, version string) {
+	if s.responseVersions[streamID] == nil {
+		s.responseVersions[streamID] = make(map[string]string)
+	}
+	s.responseVersions[streamID][typeURL] = version
+}
+
+// handleAck tracks whether the node of a stream accepted or rejected the last update of a
+// type URL. The status of the IR key of the node is published if it changed, and its last
+// snapshot is rolled back if rejected and rollback is enabled.
+// The caller must hold the lock.
+func (s *snapshotCache) handleAck(streamID int64, typeURL, responseNonce string, rejected bool, errorMessage string) {
+	node := s.streamIDNodeInfo[streamID]
+	// The first request of a type URL on a stream is neither an ACK nor a NACK.
+	if node == nil || responseNonce == "" {
+		return
+	}
+
+	version := s.responseVersions[streamID][typeURL]
+	nodeNACKs := s.nacks[node.Id]
+	if !rejected {
+		if s.ackedVersions[node.Id] == nil {
+			s.ackedVersions[node.Id] = make(map[string]string)
+		}
+		s.ackedVersions[node.Id][typeURL] = version
+		s.updateLastAcked(node.Cluster)
+
+		if _, ok := nodeNACKs[typeURL]; !ok {
+			return
+		}
+		delete(nodeNACKs, typeURL)
+		if len(nodeNACKs) == 0 {
+			delete(s.nacks, node.Id)
+		}
+		xdsNACKActive.With(nodeIDLabel.Value(node.Id), typeURLLabel.Value(typeURL)).Record(0)
+		s.publishStatus(node.Cluster)
+		return
+	}
+
+	xdsNACKTotal.With(nodeIDLabel.Value(node.Id), typeURLLabel.Value(typeURL)).Increment()
+	xdsNACKActive.With(nodeIDLabel.Value(node.Id), typeURLLabel.Value(typeURL)).Record(1)
+	if nodeNACKs == nil {
+		nodeNACKs = make(map[string]*types.NACK)
+		s.nacks[node.Id] = nodeNACKs
+	}
+	nack := &types.NACK{
+		NodeID:  node.Id,
+		TypeURL: typeURL,
+		Version: version,
+		Message: errorMessage,
+	}
+	s.recordLastNACK(nack)
+	if existing, ok := nodeNACKs[typeURL]; !ok || *existing != *nack {
+		nodeNACKs[typeURL] = nack
+		s.publishStatus(node.Cluster)
+	}
+	s.holdRolloutOnNACK(node.Cluster, node.Id, typeURL, version)
+	s.rollback(node.Cluster, typeURL, version)
+}
+
+// forgetStream removes the bookkeeping of a closed stream. The updates rejected by its node
+// are forgotten if the node has no other stream.
+// The caller must hold the lock.
+func (s *snapshotCache) forgetStream(streamID int64) {
+	node := s.streamIDNodeInfo[streamID]
+	delete(s.streamIDNodeInfo, streamID)
+	delete(s.responseVersions, streamID)
+	delete(s.streamAddresses, streamID)
+	if node == nil {
+		return
+	}
+
+	for _, other := range s.streamIDNodeInfo {
+		if other != nil && other.Id == node.Id {
+			return
+		}
+	}
+	delete(s.ackedVersions, node.Id)
+	delete(s.lastNACKs, node.Id)
+	// The remaining nodes may have acknowledged the latest snapshot.
+	s.updateLastAcked(node.Cluster)
+	if _, ok := s.nacks[node.Id]; !ok {
+		return
+	}
+	for typeURL := range s.nacks[node.Id] {
+		xdsNACKActive.With(nodeIDLabel.Value(node.Id), typeURLLabel.Value(typeURL)).Record(0)
+	}
+	delete(s.nacks, node.Id)
+	s.publishStatus(node.Cluster)
+}
+
+// xdsStatus returns the status of the xDS resources of an IR key.
+// The caller must hold the lock.
+func (s *snapshotCache) xdsStatus(irKey string) *types.XdsStatus {
+	status := &types.XdsStatus{}
+	for _, streamNode := range s.streamIDNodeInfo {
+		if streamNode == nil || streamNode.Cluster != irKey {
+			continue
+		}
+		for _, nack := range s.nacks[streamNode.Id] {
+			if !slices.Contains(status.NACKs, *nack) {
+				status.NACKs = append(status.NACKs, *nack)
+			}
+		}
+	}
+	slices.SortFunc(status.NACKs, func(a, b types.NACK) int {
+		return cmp.Or(cmp.Compare(a.NodeID, b.NodeID), cmp.Compare(a.TypeURL, b.TypeURL))
+	})
+	return status
+}
+
+// publishStatus calls the status handler with the status of the xDS resources of an IR key.
+// The caller must hold the lock.
+func (s *snapshotCache) publishStatus(irKey string) {
+	if s.onStatus != nil {
+		s.onStatus(irKey, s.xdsStatus(irKey))
+	}
+}
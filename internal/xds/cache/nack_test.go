@@ -0,0 +1,78 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestNACKTracking(t *testing.T) {
+	statuses := map[string]*types.XdsStatus{}
+	published := 0
+	c := NewSnapshotCache(true, logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo), func(irKey string, status *types.XdsStatus) {
+		statuses[irKey] = status
+		published++
+	}).(*snapshotCache)
+
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.ListenerType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{
+		Node:    &corev3.Node{Id: "envoy-1", Cluster: "gateway"},
+		TypeUrl: resourcev3.ListenerType,
+	}))
+	c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{
+		TypeUrl:     resourcev3.ListenerType,
+		VersionInfo: "2",
+	})
+
+	c.mu.Lock()
+	// The first request of a stream is neither an ACK nor a NACK.
+	c.handleAck(1, resourcev3.ListenerType, "", true, "ignored")
+	require.Equal(t, 0, published)
+
+	c.handleAck(1, resourcev3.ListenerType, "nonce", true, "invalid listener")
+	require.Equal(t, 1, published)
+	require.Equal(t, []types.NACK{{
+		NodeID:  "envoy-1",
+		TypeURL: resourcev3.ListenerType,
+		Version: "2",
+		Message: "invalid listener",
+	}}, statuses["gateway"].NACKs)
+	require.Equal(t, "Envoy proxy envoy-1 rejected the "+resourcev3.ListenerType+" resources: invalid listener",
+		statuses["gateway"].Message())
+
+	// The same rejection is not published again.
+	c.handleAck(1, resourcev3.ListenerType, "nonce", true, "invalid listener")
+	require.Equal(t, 1, published)
+
+	// An ACK clears the rejection.
+	c.handleAck(1, resourcev3.ListenerType, "nonce", false, "")
+	require.Equal(t, 2, published)
+	require.Empty(t, statuses["gateway"].NACKs)
+	require.Empty(t, statuses["gateway"].Message())
+
+	// The rejections of a node are forgotten when its last stream is closed.
+	c.handleAck(1, resourcev3.ClusterType, "nonce", true, "invalid cluster")
+	require.Equal(t, 3, published)
+	require.Len(t, statuses["gateway"].NACKs, 1)
+	c.mu.Unlock()
+
+	c.OnStreamClosed(1, &corev3.Node{Id: "envoy-1"})
+	require.Equal(t, 4, published)
+	require.Empty(t, statuses["gateway"].NACKs)
+	require.Empty(t, c.nacks)
+	require.Empty(t, c.responseVersions)
+}
@@ -0,0 +1,100 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// nodeBuildVersion returns the Envoy version of a node, e.g. v1.31.0, or an empty string if
+// the node didn't report it.
+func nodeBuildVersion(node *corev3.Node) string {
+	if bv := node.GetUserAgentBuildVersion(); bv != nil && bv.Version != nil {
+		return fmt.Sprintf("v%d.%d.%d", bv.Version.MajorNumber, bv.Version.MinorNumber, bv.Version.Patch)
+	}
+	return ""
+}
+
+// Proxies returns the status of the proxies connected to the xDS server, sorted by node ID.
+func (s *snapshotCache) Proxies() []types.ProxyStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proxies := make(map[string]*types.ProxyStatus)
+	for streamID, node := range s.streamIDNodeInfo {
+		// The node of a stream is unknown until its first request.
+		if node == nil {
+			continue
+		}
+		connectTime, ok := s.streamDuration[streamID]
+		if !ok {
+			connectTime = s.deltaStreamDuration[streamID]
+		}
+
+		proxy, ok := proxies[node.Id]
+		if !ok {
+			proxy = &types.ProxyStatus{
+				NodeID:       node.Id,
+				IRKey:        node.Cluster,
+				BuildVersion: nodeBuildVersion(node),
+				ConnectTime:  connectTime,
+			}
+			proxies[node.Id] = proxy
+		}
+		proxy.Streams++
+		if connectTime.Before(proxy.ConnectTime) {
+			proxy.ConnectTime = connectTime
+		}
+		if address := s.streamAddresses[streamID]; address != "" {
+			proxy.Address = address
+		}
+	}
+
+	statuses := make([]types.ProxyStatus, 0, len(proxies))
+	for nodeID, proxy := range proxies {
+		proxy.AckedVersions = maps.Clone(s.ackedVersions[nodeID])
+		if snapshot := s.servedSnapshot(proxy.IRKey, nodeID); snapshot != nil {
+			proxy.ServedVersions = snapshotVersions(snapshot)
+		}
+		proxy.Synced = isSynced(proxy.AckedVersions, proxy.ServedVersions)
+		if nack := s.lastNACKs[nodeID]; nack != nil {
+			lastNACK := *nack
+			proxy.LastNACK = &lastNACK
+		}
+		statuses = append(statuses, *proxy)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].NodeID < statuses[j].NodeID
+	})
+	return statuses
+}
+
+// isSynced returns whether a node acknowledged the served version of each type URL it subscribed to.
+// The type URLs the node didn't acknowledge yet are ignored, since nodes don't subscribe to all of them.
+func isSynced(acked, served map[string]string) bool {
+	if len(acked) == 0 {
+		return len(served) == 0
+	}
+	for typeURL, version := range acked {
+		if servedVersion, ok := served[typeURL]; ok && servedVersion != version {
+			return false
+		}
+	}
+	return true
+}
+
+// recordLastNACK records the last update rejected by a node.
+// The caller must hold the lock.
+func (s *snapshotCache) recordLastNACK(nack *types.NACK) {
+	s.lastNACKs[nack.NodeID] = &types.ProxyNACK{NACK: *nack, Time: time.Now()}
+}
@@ -0,0 +1,103 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestProxies(t *testing.T) {
+	c := NewSnapshotCache(true, logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo), nil).(*snapshotCache)
+	require.NoError(t, c.GenerateNewSnapshot("gateway", types.XdsResources{
+		resourcev3.ListenerType: {&listenerv3.Listener{Name: "listener"}},
+	}))
+	version := c.lastSnapshot["gateway"].GetVersion(resourcev3.ListenerType)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 40000},
+	})
+	node := &corev3.Node{
+		Id:      "envoy-1",
+		Cluster: "gateway",
+		UserAgentVersionType: &corev3.Node_UserAgentBuildVersion{
+			UserAgentBuildVersion: &corev3.BuildVersion{
+				Version: &typev3.SemanticVersion{MajorNumber: 1, MinorNumber: 31, Patch: 2},
+			},
+		},
+	}
+	require.NoError(t, c.OnStreamOpen(ctx, 1, resourcev3.ListenerType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType}))
+	require.NoError(t, c.OnDeltaStreamOpen(context.Background(), 2, resourcev3.ListenerType))
+	require.NoError(t, c.OnStreamDeltaRequest(2, &discoveryv3.DeltaDiscoveryRequest{
+		Node:    &corev3.Node{Id: "envoy-2", Cluster: "gateway"},
+		TypeUrl: resourcev3.ListenerType,
+	}))
+	// The node of a stream without request is unknown.
+	require.NoError(t, c.OnStreamOpen(context.Background(), 3, resourcev3.ListenerType))
+
+	proxies := c.Proxies()
+	require.Len(t, proxies, 2)
+	require.Equal(t, "envoy-1", proxies[0].NodeID)
+	require.Equal(t, "gateway", proxies[0].IRKey)
+	require.Equal(t, "v1.31.2", proxies[0].BuildVersion)
+	require.Equal(t, "10.0.0.1", proxies[0].Address)
+	require.Equal(t, 1, proxies[0].Streams)
+	require.False(t, proxies[0].ConnectTime.IsZero())
+	require.Equal(t, map[string]string{resourcev3.ListenerType: version}, proxies[0].ServedVersions)
+	require.False(t, proxies[0].Synced)
+	require.Equal(t, "envoy-2", proxies[1].NodeID)
+	require.Empty(t, proxies[1].BuildVersion)
+
+	// The node rejects an update, then acknowledges the served snapshot.
+	c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{
+		TypeUrl:     resourcev3.ListenerType,
+		VersionInfo: "rejected",
+	})
+	c.mu.Lock()
+	c.handleAck(1, resourcev3.ListenerType, "1", true, "invalid listener")
+	c.mu.Unlock()
+	c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{
+		TypeUrl:     resourcev3.ListenerType,
+		VersionInfo: version,
+	})
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{
+		Node:          node,
+		TypeUrl:       resourcev3.ListenerType,
+		ResponseNonce: "2",
+	}))
+
+	proxies = c.Proxies()
+	require.Equal(t, map[string]string{resourcev3.ListenerType: version}, proxies[0].AckedVersions)
+	require.True(t, proxies[0].Synced)
+	require.NotNil(t, proxies[0].LastNACK)
+	require.Equal(t, types.NACK{
+		NodeID:  "envoy-1",
+		TypeURL: resourcev3.ListenerType,
+		Version: "rejected",
+		Message: "invalid listener",
+	}, proxies[0].LastNACK.NACK)
+
+	// The proxies are forgotten when their streams are closed.
+	c.OnStreamClosed(1, node)
+	c.OnDeltaStreamClosed(2, &corev3.Node{Id: "envoy-2"})
+	require.Empty(t, c.Proxies())
+	require.Empty(t, c.lastNACKs)
+}
@@ -0,0 +1,64 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import "context"
+
+// updateLastAcked remembers the latest snapshot of an IR key as the last acknowledged one
+// once all its nodes acknowledged the versions of all the types they received.
+// The caller must hold the lock.
+func (s *snapshotCache) updateLastAcked(irKey string) {
+	snapshot := s.lastSnapshot[irKey]
+	if snapshot == nil || s.lastAckedSnapshot[irKey] == snapshot {
+		return
+	}
+
+	nodeIDs := s.getNodeIDs(irKey)
+	if len(nodeIDs) == 0 {
+		return
+	}
+	for _, nodeID := range nodeIDs {
+		ackedVersions := s.ackedVersions[nodeID]
+		if len(ackedVersions) == 0 {
+			return
+		}
+		for typeURL, version := range ackedVersions {
+			if snapshot.GetVersion(typeURL) != version {
+				return
+			}
+		}
+	}
+
+	s.log.Debugf("The latest snapshot of %s was acknowledged by all the nodes", irKey)
+	s.lastAckedSnapshot[irKey] = snapshot
+}
+
+// rollback serves the last acknowledged snapshot of an IR key to all its nodes, if enabled,
+// when the latest snapshot is rejected by one of them.
+// The caller must hold the lock.
+func (s *snapshotCache) rollback(irKey, typeURL, rejectedVersion string) {
+	if !s.rollbackOnNACK || s.rolledBack[irKey] {
+		return
+	}
+
+	snapshot, lastAcked := s.lastSnapshot[irKey], s.lastAckedSnapshot[irKey]
+	// Nothing to roll back to, or the rejection is about an older snapshot.
+	if snapshot == nil || lastAcked == nil || lastAcked == snapshot ||
+		snapshot.GetVersion(typeURL) != rejectedVersion {
+		return
+	}
+
+	s.log.Warnf("Rolling back %s to the last acknowledged snapshot, the %s resources of version %s were rejected",
+		irKey, typeURL, rejectedVersion)
+	s.rolledBack[irKey] = true
+	for _, nodeID := range s.getNodeIDs(irKey) {
+		if err := s.SetSnapshot(context.TODO(), nodeID, lastAcked); err != nil {
+			s.log.Errorf("Failed to roll back the snapshot of node %s: %v", nodeID, err)
+			continue
+		}
+		xdsSnapshotRollbackTotal.With(nodeIDLabel.Value(nodeID)).Increment()
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestRollbackOnNACK(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rollbackOnNACK bool
+		wantRollback   bool
+	}{
+		{
+			name:           "rollback enabled",
+			rollbackOnNACK: true,
+			wantRollback:   true,
+		},
+		{
+			name:           "rollback disabled",
+			rollbackOnNACK: false,
+			wantRollback:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewSnapshotCacheWithOptions(true, logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo), Options{
+				RollbackOnNACK: tc.rollbackOnNACK,
+			}).(*snapshotCache)
+			servedVersion := func(nodeID string) string {
+				snapshot, err := c.GetSnapshot(nodeID)
+				require.NoError(t, err)
+				return snapshot.GetVersion(resourcev3.ListenerType)
+			}
+			connect := func(streamID int64, nodeID string) {
+				require.NoError(t, c.OnStreamOpen(context.Background(), streamID, resourcev3.ListenerType))
+				require.NoError(t, c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+					Node:    &corev3.Node{Id: nodeID, Cluster: "gateway"},
+					TypeUrl: resourcev3.ListenerType,
+				}))
+			}
+			respond := func(streamID int64, version string) {
+				c.OnStreamResponse(context.Background(), streamID, nil, &discoveryv3.DiscoveryResponse{
+					TypeUrl:     resourcev3.ListenerType,
+					VersionInfo: version,
+				})
+			}
+
+			generate := func(listenerName string) string {
+				require.NoError(t, c.GenerateNewSnapshot("gateway", types.XdsResources{
+					resourcev3.ListenerType: {&listenerv3.Listener{Name: listenerName}},
+				}))
+				return c.lastSnapshot["gateway"].GetVersion(resourcev3.ListenerType)
+			}
+
+			first := generate("first")
+			connect(1, "envoy-1")
+			require.Equal(t, first, servedVersion("envoy-1"))
+
+			// The first snapshot is acknowledged by the only node of the IR key.
+			respond(1, first)
+			require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{
+				TypeUrl:       resourcev3.ListenerType,
+				VersionInfo:   first,
+				ResponseNonce: "1",
+			}))
+			require.Equal(t, c.lastSnapshot["gateway"], c.lastAckedSnapshot["gateway"])
+
+			// The second snapshot is rejected.
+			second := generate("second")
+			require.Equal(t, second, servedVersion("envoy-1"))
+			respond(1, second)
+			c.mu.Lock()
+			c.handleAck(1, resourcev3.ListenerType, "2", true, "invalid listener")
+			c.mu.Unlock()
+			wantVersion := second
+			if tc.wantRollback {
+				wantVersion = first
+			}
+			require.Equal(t, wantVersion, servedVersion("envoy-1"))
+
+			// New nodes receive the same snapshot.
+			connect(2, "envoy-2")
+			require.Equal(t, wantVersion, servedVersion("envoy-2"))
+
+			// A new snapshot is attempted again.
+			third := generate("third")
+			require.Equal(t, third, servedVersion("envoy-1"))
+			require.Equal(t, third, servedVersion("envoy-2"))
+		})
+	}
+}
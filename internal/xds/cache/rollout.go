@@ -0,0 +1,305 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+
+	"github.com/envoyproxy/gateway/internal/metrics"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// ErrorCountsFunc returns the number of downstream requests served by the proxy whose Prometheus
+// stats listen on an address, and the number of those answered with a 5xx status.
+type ErrorCountsFunc func(ctx context.Context, address string) (requests, errors uint64, err error)
+
+// RolloutOptions are the settings of the staged rollout of the new snapshots.
+type RolloutOptions struct {
+	// CanaryPercentage is the percentage of the nodes of an IR key receiving a new snapshot first.
+	CanaryPercentage uint32
+	// BakeDuration is how long the canaries run a new snapshot before it's pushed to the other nodes.
+	BakeDuration time.Duration
+	// MaxErrorRate is the maximum ratio of the requests answered with a 5xx status by the canaries
+	// during the bake. The error rate is not checked if negative.
+	MaxErrorRate float64
+	// ErrorCounts reads the request counts of a node, it defaults to reading the Prometheus stats of Envoy.
+	ErrorCounts ErrorCountsFunc
+}
+
+// errorCountsTimeout is the timeout of reading the request counts of the canaries.
+const errorCountsTimeout = 10 * time.Second
+
+type requestCounts struct {
+	requests, errors uint64
+}
+
+// rollout is the staged rollout of the latest snapshot of an IR key.
+type rollout struct {
+	status types.RolloutStatus
+	// snapshot is the snapshot being rolled out.
+	snapshot *cachev3.Snapshot
+	// stable is the snapshot served to the other nodes until the rollout completes.
+	stable *cachev3.Snapshot
+	timer  *time.Timer
+	// baseline are the request counts of the canaries when the rollout started,
+	// baselineDone is closed once they are read.
+	baseline     map[string]requestCounts
+	baselineErr  error
+	baselineDone chan struct{}
+}
+
+// inProgress returns whether the snapshot is not served to all the nodes yet.
+func (r *rollout) inProgress() bool {
+	return r != nil && r.status.Phase != types.RolloutPhaseCompleted
+}
+
+// servedSnapshot returns the snapshot served to a node of an IR key: the last acknowledged
+// snapshot if the latest one was rolled back, the stable snapshot if the node is not a canary
+// of the rollout in progress, and the latest snapshot otherwise.
+// The caller must hold the lock.
+func (s *snapshotCache) servedSnapshot(irKey, nodeID string) *cachev3.Snapshot {
+	if s.rolledBack[irKey] && s.lastAckedSnapshot[irKey] != nil {
+		return s.lastAckedSnapshot[irKey]
+	}
+	if r := s.rollouts[irKey]; r.inProgress() && !slices.Contains(r.status.Canaries, nodeID) {
+		return r.stable
+	}
+	return s.lastSnapshot[irKey]
+}
+
+// startRollout starts the staged rollout of a new snapshot of an IR key if enabled, and returns
+// the IDs of the nodes which must receive it now.
+// The caller must hold the lock.
+func (s *snapshotCache) startRollout(irKey string, snapshot, stable *cachev3.Snapshot) []string {
+	nodeIDs := s.getNodeIDs(irKey)
+	if previous := s.rollouts[irKey]; previous != nil && previous.timer != nil {
+		previous.timer.Stop()
+	}
+	// There is nothing to protect if no snapshot was served yet.
+	if s.rolloutOptions == nil || stable == nil || len(nodeIDs) == 0 {
+		delete(s.rollouts, irKey)
+		return nodeIDs
+	}
+
+	slices.Sort(nodeIDs)
+	nodeIDs = slices.Compact(nodeIDs)
+	canaryCount := int(math.Ceil(float64(len(nodeIDs)) * float64(s.rolloutOptions.CanaryPercentage) / 100))
+	canaryCount = min(max(canaryCount, 1), len(nodeIDs))
+
+	r := &rollout{
+		status: types.RolloutStatus{
+			IRKey:     irKey,
+			Versions:  snapshotVersions(snapshot),
+			Phase:     types.RolloutPhaseBaking,
+			Canaries:  nodeIDs[:canaryCount],
+			StartTime: time.Now(),
+		},
+		snapshot:     snapshot,
+		stable:       stable,
+		baselineDone: make(chan struct{}),
+	}
+	s.rollouts[irKey] = r
+	s.log.Infof("Rolling out the new snapshot of %s to the canary nodes %v", irKey, r.status.Canaries)
+
+	if s.rolloutOptions.MaxErrorRate >= 0 {
+		addresses, err := s.statsAddresses(r.status.Canaries)
+		go func() {
+			baseline, readErr := s.readErrorCounts(addresses)
+			s.mu.Lock()
+			r.baseline, r.baselineErr = baseline, errors.Join(err, readErr)
+			s.mu.Unlock()
+			close(r.baselineDone)
+		}()
+	} else {
+		close(r.baselineDone)
+	}
+	r.timer = time.AfterFunc(s.rolloutOptions.BakeDuration, func() {
+		s.endBake(irKey, r)
+	})
+
+	return r.status.Canaries
+}
+
+// endBake completes the rollout of an IR key after the bake, unless the canaries exceeded
+// the maximum error rate.
+func (s *snapshotCache) endBake(irKey string, r *rollout) {
+	var (
+		counts map[string]requestCounts
+		err    error
+	)
+	if s.rolloutOptions.MaxErrorRate >= 0 {
+		<-r.baselineDone
+		s.mu.Lock()
+		addresses, addressErr := s.statsAddresses(r.status.Canaries)
+		baselineErr := r.baselineErr
+		s.mu.Unlock()
+		// The counts are not read again if the baseline could not be read.
+		if err = baselineErr; err == nil {
+			counts, err = s.readErrorCounts(addresses)
+			err = errors.Join(addressErr, err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The rollout was superseded by a newer snapshot, or held.
+	if s.rollouts[irKey] != r || r.status.Phase != types.RolloutPhaseBaking {
+		return
+	}
+
+	if s.rolloutOptions.MaxErrorRate >= 0 {
+		if err != nil {
+			s.holdRollout(r, fmt.Sprintf("failed to read the stats of the canary proxies: %v", err))
+			return
+		}
+		if rate := errorRate(r.baseline, counts); rate > s.rolloutOptions.MaxErrorRate {
+			s.holdRollout(r, fmt.Sprintf("the error rate of the canary proxies is %.2f%%, above the maximum of %.2f%%",
+				rate*100, s.rolloutOptions.MaxErrorRate*100))
+			return
+		}
+	}
+
+	r.status.Phase = types.RolloutPhaseCompleted
+	xdsRolloutTotal.With(rolloutPhaseLabel.Value(string(r.status.Phase))).Increment()
+	s.log.Infof("Rolling out the new snapshot of %s to all the nodes", irKey)
+	for _, nodeID := range s.getNodeIDs(irKey) {
+		if err := s.SetSnapshot(context.TODO(), nodeID, s.servedSnapshot(irKey, nodeID)); err != nil {
+			xdsSnapshotUpdateTotal.WithFailure(metrics.ReasonError, nodeIDLabel.Value(nodeID)).Increment()
+			s.log.Errorf("Failed to set the snapshot of node %s: %v", nodeID, err)
+			continue
+		}
+		xdsSnapshotUpdateTotal.WithSuccess(nodeIDLabel.Value(nodeID)).Increment()
+	}
+}
+
+// holdRolloutOnNACK holds the rollout in progress of the IR key of a node if the node
+// rejected the snapshot being rolled out.
+// The caller must hold the lock.
+func (s *snapshotCache) holdRolloutOnNACK(irKey, nodeID, typeURL, rejectedVersion string) {
+	r := s.rollouts[irKey]
+	if r == nil || r.status.Phase != types.RolloutPhaseBaking || r.snapshot.GetVersion(typeURL) != rejectedVersion {
+		return
+	}
+	s.holdRollout(r, fmt.Sprintf("proxy %s rejected the %s resources", nodeID, typeURL))
+}
+
+// holdRollout stops a rollout, the canaries keep the new snapshot and the other nodes the stable one.
+// The caller must hold the lock.
+func (s *snapshotCache) holdRollout(r *rollout, reason string) {
+	r.timer.Stop()
+	r.status.Phase = types.RolloutPhaseHeld
+	r.status.Reason = reason
+	xdsRolloutTotal.With(rolloutPhaseLabel.Value(string(r.status.Phase))).Increment()
+	s.log.Warnf("Holding the rollout of the new snapshot of %s: %s", r.status.IRKey, reason)
+}
+
+// Rollouts returns the status of the staged rollouts of the latest snapshots, sorted by IR key.
+func (s *snapshotCache) Rollouts() []types.RolloutStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]types.RolloutStatus, 0, len(s.rollouts))
+	for _, r := range s.rollouts {
+		statuses = append(statuses, r.status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].IRKey < statuses[j].IRKey
+	})
+	return statuses
+}
+
+// statsAddresses returns the addresses of the Prometheus stats of the nodes, by node ID, made of
+// the address of their xDS streams and of the stats port in their metadata. An error is returned
+// for the nodes whose stats address is unknown, e.g. the nodes connected over a Unix domain socket.
+// The caller must hold the lock.
+func (s *snapshotCache) statsAddresses(nodeIDs []string) (map[string]string, error) {
+	addresses := make(map[string]string, len(nodeIDs))
+	for streamID, node := range s.streamIDNodeInfo {
+		if node == nil || !slices.Contains(nodeIDs, node.Id) || s.streamAddresses[streamID] == "" {
+			continue
+		}
+		if port := node.GetMetadata().GetFields()[bootstrap.StatsPortMetadataKey].GetNumberValue(); port > 0 {
+			addresses[node.Id] = net.JoinHostPort(s.streamAddresses[streamID], strconv.Itoa(int(port)))
+		}
+	}
+
+	var errs error
+	for _, nodeID := range nodeIDs {
+		if _, ok := addresses[nodeID]; !ok {
+			errs = errors.Join(errs, fmt.Errorf("node %s: the address of its stats is unknown", nodeID))
+		}
+	}
+	return addresses, errs
+}
+
+// readErrorCounts reads the request counts of the nodes at the given addresses.
+func (s *snapshotCache) readErrorCounts(addresses map[string]string) (map[string]requestCounts, error) {
+	errorCounts := s.rolloutOptions.ErrorCounts
+	if errorCounts == nil {
+		errorCounts = envoyErrorCounts
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), errorCountsTimeout)
+	defer cancel()
+
+	counts := make(map[string]requestCounts, len(addresses))
+	var errs error
+	for nodeID, address := range addresses {
+		requests, failed, err := errorCounts(ctx, address)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("node %s: %w", nodeID, err))
+			continue
+		}
+		counts[nodeID] = requestCounts{requests: requests, errors: failed}
+	}
+	return counts, errs
+}
+
+// errorRate returns the ratio of the requests answered with a 5xx status between two
+// readings of the request counts of the nodes.
+func errorRate(before, after map[string]requestCounts) float64 {
+	var requests, failed uint64
+	for nodeID, counts := range after {
+		previous := before[nodeID]
+		// The counters are reset when Envoy restarts.
+		if counts.requests < previous.requests || counts.errors < previous.errors {
+			previous = requestCounts{}
+		}
+		requests += counts.requests - previous.requests
+		failed += counts.errors - previous.errors
+	}
+	if requests == 0 {
+		return 0
+	}
+	return float64(failed) / float64(requests)
+}
+
+// snapshotVersions returns the versions of the resource types of a snapshot, by type URL.
+func snapshotVersions(snapshot *cachev3.Snapshot) map[string]string {
+	versions := make(map[string]string)
+	for i, resources := range snapshot.Resources {
+		if resources.Version == "" {
+			continue
+		}
+		if typeURL, err := cachev3.GetResponseTypeURL(cachetypes.ResponseType(i)); err == nil {
+			versions[typeURL] = resources.Version
+		}
+	}
+	return versions
+}
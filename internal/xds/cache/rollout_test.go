@@ -0,0 +1,178 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestStagedRollout(t *testing.T) {
+	testCases := []struct {
+		name         string
+		maxErrorRate float64
+		nack         bool
+		// unixSocket connects the nodes over a Unix domain socket, their stats address is unknown.
+		unixSocket bool
+		wantPhase  types.RolloutPhase
+		wantReason string
+	}{
+		{
+			name:         "rollout completed",
+			maxErrorRate: -1,
+			wantPhase:    types.RolloutPhaseCompleted,
+		},
+		{
+			name:         "rollout completed with error rate below the maximum",
+			maxErrorRate: 0.5,
+			wantPhase:    types.RolloutPhaseCompleted,
+		},
+		{
+			name:         "rollout held by the error rate",
+			maxErrorRate: 0.05,
+			wantPhase:    types.RolloutPhaseHeld,
+			wantReason:   "the error rate of the canary proxies is 10.00%, above the maximum of 5.00%",
+		},
+		{
+			name:         "rollout held by a NACK",
+			maxErrorRate: -1,
+			nack:         true,
+			wantPhase:    types.RolloutPhaseHeld,
+			wantReason:   "proxy envoy-1 rejected the " + resourcev3.ListenerType + " resources",
+		},
+		{
+			name:         "rollout held by an unknown stats address",
+			maxErrorRate: 0.5,
+			unixSocket:   true,
+			wantPhase:    types.RolloutPhaseHeld,
+			wantReason:   "failed to read the stats of the canary proxies: node envoy-1: the address of its stats is unknown",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The canary serves 100 requests and 10 errors during the bake. The addresses are
+			// recorded since the counts are read outside of the test goroutine.
+			var (
+				mu        sync.Mutex
+				addresses []string
+			)
+			errorCounts := func(_ context.Context, address string) (uint64, uint64, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				addresses = append(addresses, address)
+				if len(addresses) == 1 {
+					return 1000, 10, nil
+				}
+				return 1100, 20, nil
+			}
+			c := NewSnapshotCacheWithOptions(true, logging.DefaultLogger(os.Stdout, egv1a1.LogLevelInfo), Options{
+				Rollout: &RolloutOptions{
+					CanaryPercentage: 50,
+					BakeDuration:     100 * time.Millisecond,
+					MaxErrorRate:     tc.maxErrorRate,
+					ErrorCounts:      errorCounts,
+				},
+			}).(*snapshotCache)
+			servedVersion := func(nodeID string) string {
+				snapshot, err := c.GetSnapshot(nodeID)
+				require.NoError(t, err)
+				return snapshot.GetVersion(resourcev3.ListenerType)
+			}
+			generate := func(listenerName string) string {
+				require.NoError(t, c.GenerateNewSnapshot("gateway", types.XdsResources{
+					resourcev3.ListenerType: {&listenerv3.Listener{Name: listenerName}},
+				}))
+				return c.lastSnapshot["gateway"].GetVersion(resourcev3.ListenerType)
+			}
+
+			stable := generate("stable")
+			metadata, err := structpb.NewStruct(map[string]any{bootstrap.StatsPortMetadataKey: bootstrap.EnvoyStatsPort})
+			require.NoError(t, err)
+			for i, nodeID := range []string{"envoy-1", "envoy-2"} {
+				streamID := int64(i + 1)
+				var addr net.Addr = &net.TCPAddr{IP: net.IPv4(10, 0, 0, byte(streamID)), Port: 40000}
+				if tc.unixSocket {
+					addr = &net.UnixAddr{Name: "@", Net: "unix"}
+				}
+				ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+				require.NoError(t, c.OnStreamOpen(ctx, streamID, resourcev3.ListenerType))
+				require.NoError(t, c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+					Node:    &corev3.Node{Id: nodeID, Cluster: "gateway", Metadata: metadata},
+					TypeUrl: resourcev3.ListenerType,
+				}))
+			}
+			// The first snapshot is not rolled out.
+			require.Empty(t, c.Rollouts())
+
+			// The new snapshot is pushed to the canary only.
+			canary := generate("canary")
+			rollouts := c.Rollouts()
+			require.Len(t, rollouts, 1)
+			require.Equal(t, types.RolloutPhaseBaking, rollouts[0].Phase)
+			require.Equal(t, []string{"envoy-1"}, rollouts[0].Canaries)
+			require.Equal(t, canary, servedVersion("envoy-1"))
+			require.Equal(t, stable, servedVersion("envoy-2"))
+
+			if tc.nack {
+				c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{
+					TypeUrl:     resourcev3.ListenerType,
+					VersionInfo: canary,
+				})
+				c.mu.Lock()
+				c.handleAck(1, resourcev3.ListenerType, "nonce", true, "invalid listener")
+				c.mu.Unlock()
+			}
+
+			require.Eventually(t, func() bool {
+				return c.Rollouts()[0].Phase != types.RolloutPhaseBaking
+			}, 5*time.Second, 10*time.Millisecond)
+			rollouts = c.Rollouts()
+			require.Equal(t, tc.wantPhase, rollouts[0].Phase)
+			require.Equal(t, tc.wantReason, rollouts[0].Reason)
+			mu.Lock()
+			if tc.maxErrorRate >= 0 && !tc.unixSocket {
+				// The stats of the canary are read when the rollout starts and after the bake.
+				require.Equal(t, []string{"10.0.0.1:19001", "10.0.0.1:19001"}, addresses)
+			} else {
+				require.Empty(t, addresses)
+			}
+			mu.Unlock()
+			require.Equal(t, canary, servedVersion("envoy-1"))
+			if tc.wantPhase == types.RolloutPhaseCompleted {
+				require.Equal(t, canary, servedVersion("envoy-2"))
+			} else {
+				require.Equal(t, stable, servedVersion("envoy-2"))
+			}
+
+			// A newer snapshot starts a new rollout from the stable snapshot.
+			generate("newer")
+			require.Equal(t, types.RolloutPhaseBaking, c.Rollouts()[0].Phase)
+			if tc.wantPhase == types.RolloutPhaseCompleted {
+				require.Equal(t, canary, servedVersion("envoy-2"))
+			} else {
+				require.Equal(t, stable, servedVersion("envoy-2"))
+			}
+		})
+	}
+}
@@ -16,7 +16,7 @@ package cache
 import (
 	"context"
 	"fmt"
-	"math"
+	"net"
 	"strconv"
 	"sync"
 	"time"
@@ -26,6 +26,7 @@ import (
 	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
 
 	"github.com/envoyproxy/gateway/internal/logging"
 	"github.com/envoyproxy/gateway/internal/metrics"
@@ -47,6 +48,10 @@ type SnapshotCacheWithCallbacks interface {
 	cachev3.SnapshotCache
 	serverv3.Callbacks
 	GenerateNewSnapshot(string, types.XdsResources) error
+	// Rollouts returns the status of the staged rollouts of the latest snapshots.
+	Rollouts() []types.RolloutStatus
+	// Proxies returns the status of the connected proxies.
+	Proxies() []types.ProxyStatus
 }
 
 type snapshotMap map[string]*cachev3.Snapshot
@@ -55,39 +60,75 @@ type nodeInfoMap map[int64]*corev3.Node
 
 type streamDurationMap map[int64]time.Time
 
+// nackMap holds the updates currently rejected by each node, by node ID and type URL.
+type nackMap map[string]map[string]*types.NACK
+
+// responseVersionMap holds the versions of the last responses sent on each stream, by type URL.
+type responseVersionMap map[int64]map[string]string
+
+// ackedVersionMap holds the versions of the last updates acknowledged by each node, by node ID and type URL.
+type ackedVersionMap map[string]map[string]string
+
+// lastNACKMap holds the last update rejected by each node, by node ID.
+type lastNACKMap map[string]*types.ProxyNACK
+
+// StatusHandler is called with the status of the xDS resources of an IR key whenever it changes.
+type StatusHandler func(irKey string, status *types.XdsStatus)
+
+// Options are the optional settings of the snapshot cache.
+type Options struct {
+	// OnStatus is called whenever the updates rejected by the nodes of an IR key change.
+	OnStatus StatusHandler
+	// RollbackOnNACK enables serving the last snapshot acknowledged by all the nodes of an IR key
+	// when one of them rejects a newer snapshot.
+	RollbackOnNACK bool
+	// Rollout enables the staged rollout of the new snapshots if set.
+	Rollout *RolloutOptions
+}
+
 type snapshotCache struct {
 	cachev3.SnapshotCache
 	streamIDNodeInfo    nodeInfoMap
 	streamDuration      streamDurationMap
 	deltaStreamDuration streamDurationMap
-	snapshotVersion     int64
 	lastSnapshot        snapshotMap
+	lastAckedSnapshot   snapshotMap
+	rolledBack          map[string]bool
+	rollouts            map[string]*rollout
+	streamAddresses     map[int64]string
+	nacks               nackMap
+	responseVersions    responseVersionMap
+	ackedVersions       ackedVersionMap
+	lastNACKs           lastNACKMap
+	onStatus            StatusHandler
+	rollbackOnNACK      bool
+	rolloutOptions      *RolloutOptions
 	log                 *zap.SugaredLogger
 	mu                  sync.Mutex
 }
 
 // GenerateNewSnapshot takes a table of resources (the output from the IR->xDS
-// translator) and updates the snapshot version.
+// translator) and creates a snapshot whose versions are derived from its resources.
 func (s *snapshotCache) GenerateNewSnapshot(irKey string, resources types.XdsResources) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	version := s.newSnapshotVersion()
-
 	// Create a snapshot with all xDS resources.
-	snapshot, err := cachev3.NewSnapshot(
-		version,
-		resources,
-	)
+	snapshot, err := newSnapshot(resources)
 	if err != nil {
 		xdsSnapshotCreateTotal.WithFailure(metrics.ReasonError).Increment()
 		return err
 	}
 	xdsSnapshotCreateTotal.WithSuccess().Increment()
 
+	stable := s.servedSnapshot(irKey, "")
 	s.lastSnapshot[irKey] = snapshot
+	// A new snapshot is always attempted, even if the previous one was rolled back.
+	delete(s.rolledBack, irKey)
+	// The nodes may have acknowledged the same resources already.
+	s.updateLastAcked(irKey)
 
-	for _, node := range s.getNodeIDs(irKey) {
+	for _, node := range s.startRollout(irKey, snapshot, stable) {
 		s.log.Debugf("Generating a snapshot with Node %s", node)
 
 		if err = s.SetSnapshot(context.TODO(), node, snapshot); err != nil {
@@ -101,32 +142,51 @@ func (s *snapshotCache) GenerateNewSnapshot(irKey string, resources types.XdsRes
 	return nil
 }
 
-// newSnapshotVersion increments the current snapshotVersion
-// and returns as a string.
-func (s *snapshotCache) newSnapshotVersion() string {
-	// Reset the snapshotVersion if it ever hits max size.
-	if s.snapshotVersion == math.MaxInt64 {
-		s.snapshotVersion = 0
-	}
-
-	// Increment the snapshot version & return as string.
-	s.snapshotVersion++
-	return strconv.FormatInt(s.snapshotVersion, 10)
-}
-
 // NewSnapshotCache gives you a fresh SnapshotCache.
 // It needs a logger that supports the go-control-plane
 // required interface (Debugf, Infof, Warnf, and Errorf).
-func NewSnapshotCache(ads bool, logger logging.Logger) SnapshotCacheWithCallbacks {
+// The optional onStatus handler is called whenever the updates rejected
+// by the nodes of an IR key change.
+func NewSnapshotCache(ads bool, logger logging.Logger, onStatus StatusHandler) SnapshotCacheWithCallbacks {
+	return NewSnapshotCacheWithOptions(ads, logger, Options{OnStatus: onStatus})
+}
+
+// NewSnapshotCacheWithOptions gives you a fresh SnapshotCache with the optional settings
+// of the opts.
+func NewSnapshotCacheWithOptions(ads bool, logger logging.Logger, opts Options) SnapshotCacheWithCallbacks {
 	// Set up the nasty wrapper hack.
 	wrappedLogger := logger.Sugar()
 	return &snapshotCache{
 		SnapshotCache:       cachev3.NewSnapshotCache(ads, &Hash, wrappedLogger),
 		log:                 wrappedLogger,
 		lastSnapshot:        make(snapshotMap),
+		lastAckedSnapshot:   make(snapshotMap),
+		rolledBack:          make(map[string]bool),
+		rollouts:            make(map[string]*rollout),
+		streamAddresses:     make(map[int64]string),
 		streamIDNodeInfo:    make(nodeInfoMap),
 		streamDuration:      make(streamDurationMap),
 		deltaStreamDuration: make(streamDurationMap),
+		nacks:               make(nackMap),
+		responseVersions:    make(responseVersionMap),
+		ackedVersions:       make(ackedVersionMap),
+		lastNACKs:           make(lastNACKMap),
+		onStatus:            opts.OnStatus,
+		rollbackOnNACK:      opts.RollbackOnNACK,
+		rolloutOptions:      opts.Rollout,
+	}
+}
+
+// recordStreamAddress records the address of the peer of a stream, which is used to
+// read the stats of the node.
+// The caller must hold the lock.
+func (s *snapshotCache) recordStreamAddress(ctx context.Context, streamID int64) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		s.streamAddresses[streamID] = host
 	}
 }
 
@@ -145,12 +205,13 @@ func (s *snapshotCache) getNodeIDs(irKey string) []string {
 
 // OnStreamOpen and the other OnStream* functions implement the callbacks for the
 // state-of-the-world stream types.
-func (s *snapshotCache) OnStreamOpen(_ context.Context, streamID int64, _ string) error {
+func (s *snapshotCache) OnStreamOpen(ctx context.Context, streamID int64, _ string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.streamIDNodeInfo[streamID] = nil
 	s.streamDuration[streamID] = time.Now()
+	s.recordStreamAddress(ctx, streamID)
 
 	return nil
 }
@@ -169,7 +230,7 @@ func (s *snapshotCache) OnStreamClosed(streamID int64, node *corev3.Node) {
 		).Record(streamDuration.Seconds())
 	}
 
-	delete(s.streamIDNodeInfo, streamID)
+	s.forgetStream(streamID)
 	delete(s.streamDuration, streamID)
 }
 
@@ -192,8 +253,6 @@ func (s *snapshotCache) OnStreamRequest(streamID int64, req *discoveryv3.Discove
 	nodeID := s.streamIDNodeInfo[streamID].Id
 	cluster := s.streamIDNodeInfo[streamID].Cluster
 
-	var nodeVersion string
-
 	var errorCode int32
 	var errorMessage string
 
@@ -205,23 +264,18 @@ func (s *snapshotCache) OnStreamRequest(streamID int64, req *discoveryv3.Discove
 
 	_, err := s.GetSnapshot(nodeID)
 	if err != nil {
-		err = s.SetSnapshot(context.TODO(), nodeID, s.lastSnapshot[cluster])
+		err = s.SetSnapshot(context.TODO(), nodeID, s.servedSnapshot(cluster, nodeID))
 		if err != nil {
 			return err
 		}
 	}
 
-	if req.Node != nil {
-		if bv := req.Node.GetUserAgentBuildVersion(); bv != nil && bv.Version != nil {
-			nodeVersion = fmt.Sprintf("v%d.%d.%d", bv.Version.MajorNumber, bv.Version.MinorNumber, bv.Version.Patch)
-		}
-	}
+	nodeVersion := nodeBuildVersion(req.Node)
 
 	s.log.Debugf("Got a new request, version_info %s, response_nonce %s, nodeID %s, node_version %s", req.VersionInfo, req.ResponseNonce, nodeID, nodeVersion)
 
 	if status := req.ErrorDetail; status != nil {
 		// if Envoy rejected the last update log the details here.
-		// TODO(youngnick): Handle NACK properly
 		errorCode = status.Code
 		errorMessage = status.Message
 	}
@@ -234,30 +288,35 @@ func (s *snapshotCache) OnStreamRequest(streamID int64, req *discoveryv3.Discove
 	if errorCode != 0 {
 		s.log.Errorf("Envoy rejected the last update with code %d and message %s", errorCode, errorMessage)
 	}
+	s.handleAck(streamID, req.GetTypeUrl(), req.ResponseNonce, req.ErrorDetail != nil, errorMessage)
 
 	return nil
 }
 
-func (s *snapshotCache) OnStreamResponse(_ context.Context, streamID int64, _ *discoveryv3.DiscoveryRequest, _ *discoveryv3.DiscoveryResponse) {
-	// No mutex lock required here because no writing to the cache.
+func (s *snapshotCache) OnStreamResponse(_ context.Context, streamID int64, _ *discoveryv3.DiscoveryRequest, resp *discoveryv3.DiscoveryResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	node := s.streamIDNodeInfo[streamID]
 	if node == nil {
 		s.log.Errorf("Tried to send a response to a node we haven't seen yet on stream %d", streamID)
 	} else {
 		s.log.Debugf("Sending Response on stream %d to node %s", streamID, node.Id)
 	}
+	s.recordResponseVersion(streamID, resp.GetTypeUrl(), resp.GetVersionInfo())
 }
 
 // OnDeltaStreamOpen and the other OnDeltaStream*/OnStreamDelta* functions implement
 // the callbacks for the incremental xDS versions.
 // Yes, the different ordering in the name is part of the go-control-plane interface.
-func (s *snapshotCache) OnDeltaStreamOpen(_ context.Context, streamID int64, _ string) error {
+func (s *snapshotCache) OnDeltaStreamOpen(ctx context.Context, streamID int64, _ string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Ensure that we're adding the streamID to the Node ID list.
 	s.streamIDNodeInfo[streamID] = nil
 	s.deltaStreamDuration[streamID] = time.Now()
+	s.recordStreamAddress(ctx, streamID)
 
 	return nil
 }
@@ -276,7 +335,7 @@ func (s *snapshotCache) OnDeltaStreamClosed(streamID int64, node *corev3.Node) {
 		).Record(deltaStreamDuration.Seconds())
 	}
 
-	delete(s.streamIDNodeInfo, streamID)
+	s.forgetStream(streamID)
 	delete(s.deltaStreamDuration, streamID)
 }
 
@@ -286,7 +345,6 @@ func (s *snapshotCache) OnStreamDeltaRequest(streamID int64, req *discoveryv3.De
 	// but that seemed like a premature optimization.
 	defer s.mu.Unlock()
 
-	var nodeVersion string
 	var errorCode int32
 	var errorMessage string
 
@@ -313,23 +371,18 @@ func (s *snapshotCache) OnStreamDeltaRequest(streamID int64, req *discoveryv3.De
 
 	_, err := s.GetSnapshot(nodeID)
 	if err != nil {
-		err = s.SetSnapshot(context.TODO(), nodeID, s.lastSnapshot[cluster])
+		err = s.SetSnapshot(context.TODO(), nodeID, s.servedSnapshot(cluster, nodeID))
 		if err != nil {
 			return err
 		}
 	}
 
-	if req.Node != nil {
-		if bv := req.Node.GetUserAgentBuildVersion(); bv != nil && bv.Version != nil {
-			nodeVersion = fmt.Sprintf("v%d.%d.%d", bv.Version.MajorNumber, bv.Version.MinorNumber, bv.Version.Patch)
-		}
-	}
+	nodeVersion := nodeBuildVersion(req.Node)
 
 	s.log.Debugf("Got a new request, response_nonce %s, nodeID %s, node_version %s",
 		req.ResponseNonce, nodeID, nodeVersion)
 	if status := req.ErrorDetail; status != nil {
 		// if Envoy rejected the last update log the details here.
-		// TODO(youngnick): Handle NACK properly
 		errorCode = status.Code
 		errorMessage = status.Message
 	}
@@ -343,18 +396,22 @@ func (s *snapshotCache) OnStreamDeltaRequest(streamID int64, req *discoveryv3.De
 	if errorCode != 0 {
 		s.log.Errorf("Envoy rejected the last update with code %d and message %s", errorCode, errorMessage)
 	}
+	s.handleAck(streamID, req.GetTypeUrl(), req.ResponseNonce, req.ErrorDetail != nil, errorMessage)
 
 	return nil
 }
 
-func (s *snapshotCache) OnStreamDeltaResponse(streamID int64, _ *discoveryv3.DeltaDiscoveryRequest, _ *discoveryv3.DeltaDiscoveryResponse) {
-	// No mutex lock required here because no writing to the cache.
+func (s *snapshotCache) OnStreamDeltaResponse(streamID int64, _ *discoveryv3.DeltaDiscoveryRequest, resp *discoveryv3.DeltaDiscoveryResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	node := s.streamIDNodeInfo[streamID]
 	if node == nil {
 		s.log.Errorf("Tried to send a response to a node we haven't seen yet on stream %d", streamID)
 	} else {
 		s.log.Debugf("Sending Incremental Response on stream %d to node %s", streamID, node.Id)
 	}
+	s.recordResponseVersion(streamID, resp.GetTypeUrl(), resp.GetSystemVersionInfo())
 }
 
 func (s *snapshotCache) OnFetchRequest(_ context.Context, _ *discoveryv3.DiscoveryRequest) error {
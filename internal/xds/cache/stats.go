@@ -0,0 +1,90 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	// downstreamRequestsMetric counts the downstream requests of the HTTP connection managers.
+	downstreamRequestsMetric = "envoy_http_downstream_rq_total"
+	// downstreamResponsesMetric counts the downstream responses of the HTTP connection managers by status class.
+	downstreamResponsesMetric = "envoy_http_downstream_rq_xx"
+
+	connManagerPrefixLabel = "envoy_http_conn_manager_prefix"
+	codeClassLabel         = "envoy_response_code_class"
+	// internalStatPrefix is the prefix of the HTTP connection managers of the listeners
+	// created by Envoy Gateway for its own purposes, e.g. to serve the stats.
+	internalStatPrefix = "eg-"
+)
+
+// envoyErrorCounts reads the number of downstream requests and 5xx responses of an Envoy from
+// its Prometheus stats served at an address.
+func envoyErrorCounts(ctx context.Context, address string) (uint64, uint64, error) {
+	url := fmt.Sprintf("http://%s/stats/prometheus", address)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("unexpected status %s from %s", resp.Status, url)
+	}
+	return parseErrorCounts(resp.Body)
+}
+
+// parseErrorCounts sums the downstream requests and 5xx responses of the HTTP connection
+// managers in Prometheus stats.
+func parseErrorCounts(stats io.Reader) (uint64, uint64, error) {
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(stats)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var requests, failed float64
+	if family, ok := families[downstreamRequestsMetric]; ok {
+		for _, metric := range family.GetMetric() {
+			if !isInternalConnManager(metric.GetLabel()) {
+				requests += metric.GetCounter().GetValue()
+			}
+		}
+	}
+	if family, ok := families[downstreamResponsesMetric]; ok {
+		for _, metric := range family.GetMetric() {
+			if isInternalConnManager(metric.GetLabel()) {
+				continue
+			}
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == codeClassLabel && label.GetValue() == "5" {
+					failed += metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return uint64(requests), uint64(failed), nil
+}
+
+func isInternalConnManager(labels []*dto.LabelPair) bool {
+	for _, label := range labels {
+		if label.GetName() == connManagerPrefixLabel {
+			return strings.HasPrefix(label.GetValue(), internalStatPrefix)
+		}
+	}
+	return false
+}
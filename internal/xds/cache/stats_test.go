@@ -0,0 +1,33 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrorCounts(t *testing.T) {
+	stats := `# TYPE envoy_http_downstream_rq_total counter
+envoy_http_downstream_rq_total{envoy_http_conn_manager_prefix="http-10080"} 100
+envoy_http_downstream_rq_total{envoy_http_conn_manager_prefix="https-10443"} 50
+envoy_http_downstream_rq_total{envoy_http_conn_manager_prefix="eg-stats-http"} 1000
+# TYPE envoy_http_downstream_rq_xx counter
+envoy_http_downstream_rq_xx{envoy_response_code_class="2",envoy_http_conn_manager_prefix="http-10080"} 90
+envoy_http_downstream_rq_xx{envoy_response_code_class="5",envoy_http_conn_manager_prefix="http-10080"} 10
+envoy_http_downstream_rq_xx{envoy_response_code_class="5",envoy_http_conn_manager_prefix="https-10443"} 5
+envoy_http_downstream_rq_xx{envoy_response_code_class="5",envoy_http_conn_manager_prefix="eg-stats-http"} 100
+`
+	requests, failed, err := parseErrorCounts(strings.NewReader(stats))
+	require.NoError(t, err)
+	require.Equal(t, uint64(150), requests)
+	require.Equal(t, uint64(15), failed)
+
+	_, _, err = parseErrorCounts(strings.NewReader("invalid stats"))
+	require.Error(t, err)
+}
@@ -0,0 +1,71 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// versionLength is the number of hex characters of the hash used as the version of a resource type.
+const versionLength = 16
+
+// newSnapshot creates a snapshot whose version of each resource type is derived from the hash
+// of its resources, so that the same resources always have the same version, including across
+// restarts of Envoy Gateway, and the proxies are not sent resources they already have.
+func newSnapshot(resources types.XdsResources) (*cachev3.Snapshot, error) {
+	snapshot, err := cachev3.NewSnapshot("", resources)
+	if err != nil {
+		return nil, err
+	}
+
+	for typeURL, typeResources := range resources {
+		version, err := resourcesVersion(typeResources)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute the version of the %s resources: %w", typeURL, err)
+		}
+		snapshot.Resources[cachev3.GetResponseType(typeURL)].Version = version
+	}
+
+	return snapshot, nil
+}
+
+// resourcesVersion returns the hash of a list of resources, regardless of their order.
+func resourcesVersion(resources []cachetypes.Resource) (string, error) {
+	sorted := make([]cachetypes.Resource, 0, len(resources))
+	for _, resource := range resources {
+		if resource != nil {
+			sorted = append(sorted, resource)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return cachev3.GetResourceName(sorted[i]) < cachev3.GetResourceName(sorted[j])
+	})
+
+	h := sha256.New()
+	for _, resource := range sorted {
+		marshaled, err := cachev3.MarshalResource(resource)
+		if err != nil {
+			return "", err
+		}
+		// The lengths are written so that different resources can't have the same encoding.
+		name := cachev3.GetResourceName(resource)
+		h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(name))))
+		h.Write([]byte(name))
+		h.Write(binary.BigEndian.AppendUint64(nil, uint64(len(marshaled))))
+		h.Write(marshaled)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:versionLength], nil
+}
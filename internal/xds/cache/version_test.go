@@ -0,0 +1,50 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"testing"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestNewSnapshotVersions(t *testing.T) {
+	snapshot := func(resources types.XdsResources) map[string]string {
+		s, err := newSnapshot(resources)
+		require.NoError(t, err)
+		return map[string]string{
+			resourcev3.ListenerType: s.GetVersion(resourcev3.ListenerType),
+			resourcev3.ClusterType:  s.GetVersion(resourcev3.ClusterType),
+		}
+	}
+
+	original := snapshot(types.XdsResources{
+		resourcev3.ListenerType: {&listenerv3.Listener{Name: "first"}, &listenerv3.Listener{Name: "second"}},
+		resourcev3.ClusterType:  {&clusterv3.Cluster{Name: "cluster"}},
+	})
+	require.Len(t, original[resourcev3.ListenerType], versionLength)
+	require.NotEqual(t, original[resourcev3.ListenerType], original[resourcev3.ClusterType])
+
+	// The order of the resources doesn't change the versions.
+	reordered := snapshot(types.XdsResources{
+		resourcev3.ListenerType: {&listenerv3.Listener{Name: "second"}, &listenerv3.Listener{Name: "first"}},
+		resourcev3.ClusterType:  {&clusterv3.Cluster{Name: "cluster"}},
+	})
+	require.Equal(t, original, reordered)
+
+	// Only the version of the modified resource type changes.
+	modified := snapshot(types.XdsResources{
+		resourcev3.ListenerType: {&listenerv3.Listener{Name: "first"}, &listenerv3.Listener{Name: "second"}},
+		resourcev3.ClusterType:  {&clusterv3.Cluster{Name: "cluster", AltStatName: "stats"}},
+	})
+	require.Equal(t, original[resourcev3.ListenerType], modified[resourcev3.ListenerType])
+	require.NotEqual(t, original[resourcev3.ClusterType], modified[resourcev3.ClusterType])
+}
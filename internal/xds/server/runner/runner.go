@@ -8,8 +8,14 @@ package runner
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net"
+	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -27,17 +33,18 @@ import (
 	"google.golang.org/grpc/keepalive"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/admin"
 	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/message"
-	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
+	"github.com/envoyproxy/gateway/internal/tracing"
 	"github.com/envoyproxy/gateway/internal/xds/cache"
 	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
 )
 
 const (
-	// XdsServerAddress is the listening address of the xds-server.
-	XdsServerAddress = "0.0.0.0"
+	// XdsServerAddress is the default listening address of the xds-server.
+	XdsServerAddress = egv1a1.DefaultXDSServerAddress
 
 	// Default certificates path for envoy-gateway with Kubernetes provider.
 	// xdsTLSCertFilepath is the fully qualified path of the file containing the
@@ -50,18 +57,20 @@ const (
 	// xDS server trusted CA certificate.
 	xdsTLSCaFilepath = "/certs/ca.crt"
 
-	// TODO: Make these path configurable.
-	// Default certificates path for envoy-gateway with Host infrastructure provider.
-	localTLSCertFilepath = "/tmp/envoy-gateway/certs/envoy-gateway/tls.crt"
-	localTLSKeyFilepath  = "/tmp/envoy-gateway/certs/envoy-gateway/tls.key"
-	localTLSCaFilepath   = "/tmp/envoy-gateway/certs/envoy-gateway/ca.crt"
+	// RolloutsPath is the path of the admin server endpoint serving the status of the
+	// staged rollouts of the xDS snapshots.
+	RolloutsPath = "/api/xds/rollouts"
+	// ProxiesPath is the path of the admin server endpoint serving the status of the proxies
+	// connected to the xDS server.
+	ProxiesPath = "/api/xds/proxies"
 )
 
 type Config struct {
 	config.Server
-	Xds   *message.Xds
-	grpc  *grpc.Server
-	cache cache.SnapshotCacheWithCallbacks
+	Xds               *message.Xds
+	ProviderResources *message.ProviderResources
+	grpc              *grpc.Server
+	cache             cache.SnapshotCacheWithCallbacks
 }
 
 type Runner struct {
@@ -86,19 +95,29 @@ func (r *Runner) Start(ctx context.Context) (err error) {
 	// Set up the gRPC server and register the xDS handler.
 	// Create SnapshotCache before start subscribeAndTranslate,
 	// prevent panics in case cache is nil.
-	tlsConfig, err := r.loadTLSConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load TLS config: %w", err)
-	}
-	r.Logger.Info("loaded TLS certificate and key")
-
-	r.grpc = grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)), grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+	opts := []grpc.ServerOption{grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 		MinTime:             15 * time.Second,
 		PermitWithoutStream: true,
-	}))
+	})}
+	// The proxies connecting over a Unix domain socket are authorized by its file permissions.
+	if r.EnvoyGateway.XDSServer.GetUnixSocket() == nil {
+		tlsConfig, err := r.loadTLSConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		r.Logger.Info("loaded TLS certificate and key")
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	r.grpc = grpc.NewServer(opts...)
 
-	r.cache = cache.NewSnapshotCache(true, r.Logger)
+	r.cache = cache.NewSnapshotCacheWithOptions(true, r.Logger, cache.Options{
+		OnStatus:       r.publishXdsStatus,
+		RollbackOnNACK: r.EnvoyGateway.XDSServer.RollbackOnNACKEnabled(),
+		Rollout:        rolloutOptions(r.EnvoyGateway.XDSServer.GetRollout()),
+	})
 	registerServer(serverv3.NewServer(ctx, r.cache, r.cache), r.grpc)
+	admin.RegisterHandler(RolloutsPath, http.HandlerFunc(r.serveRollouts))
+	admin.RegisterHandler(ProxiesPath, http.HandlerFunc(r.serveProxies))
 
 	// Start and listen xDS gRPC Server.
 	go r.serveXdsServer(ctx)
@@ -113,10 +132,9 @@ func (r *Runner) Start(ctx context.Context) (err error) {
 }
 
 func (r *Runner) serveXdsServer(ctx context.Context) {
-	addr := net.JoinHostPort(XdsServerAddress, strconv.Itoa(bootstrap.DefaultXdsServerPort))
-	l, err := net.Listen("tcp", addr)
+	l, err := r.listen()
 	if err != nil {
-		r.Logger.Error(err, "failed to listen on address", "address", addr)
+		r.Logger.Error(err, "failed to listen")
 		return
 	}
 
@@ -135,6 +153,64 @@ func (r *Runner) serveXdsServer(ctx context.Context) {
 	}
 }
 
+// listen listens on the Unix domain socket of the xDS server if configured, or on its
+// TCP address otherwise.
+func (r *Runner) listen() (net.Listener, error) {
+	xdsServer := r.EnvoyGateway.XDSServer
+	socket := xdsServer.GetUnixSocket()
+	if socket == nil {
+		addr := net.JoinHostPort(xdsServer.GetAddress(), strconv.Itoa(int(xdsServer.GetPort())))
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on address %s: %w", addr, err)
+		}
+		return l, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(socket.Path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create the directory of socket %s: %w", socket.Path, err)
+	}
+	// The socket is created in a private directory, and moved to its path once its permissions
+	// are set, so that it can't be connected to with the permissions of the umask.
+	tmpDir, err := os.MkdirTemp(filepath.Dir(socket.Path), ".xds-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the directory of socket %s: %w", socket.Path, err)
+	}
+	defer os.RemoveAll(tmpDir)
+	tmpPath := filepath.Join(tmpDir, filepath.Base(socket.Path))
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmpPath, Net: "unix"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on socket %s: %w", socket.Path, err)
+	}
+	// The socket is removed from its path on close instead.
+	l.SetUnlinkOnClose(false)
+	if err := os.Chmod(tmpPath, socket.GetMode()); err != nil {
+		_ = l.Close()
+		return nil, fmt.Errorf("failed to set the permissions of socket %s: %w", socket.Path, err)
+	}
+	// The rename replaces the socket left behind by a previous run.
+	if err := os.Rename(tmpPath, socket.Path); err != nil {
+		_ = l.Close()
+		return nil, fmt.Errorf("failed to move socket %s: %w", socket.Path, err)
+	}
+	r.Logger.Info("listening on unix socket", "path", socket.Path, "mode", socket.GetMode())
+	return &unixSocketListener{Listener: l, path: socket.Path}, nil
+}
+
+// unixSocketListener removes its socket when it's closed.
+type unixSocketListener struct {
+	net.Listener
+	path string
+}
+
+func (l *unixSocketListener) Close() error {
+	err := l.Listener.Close()
+	if removeErr := os.Remove(l.path); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+		err = errors.Join(err, removeErr)
+	}
+	return err
+}
+
 // registerServer registers the given xDS protocol Server with the gRPC
 // runtime.
 func registerServer(srv serverv3.Server, g *grpc.Server) {
@@ -155,7 +231,10 @@ func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *xd
 			val := update.Value
 
 			r.Logger.Info("received an update")
+			_, span := tracing.Start(tracing.Subscribe(context.Background(), tracing.XdsMessage, key),
+				"xds-push-snapshot", tracing.IRKeyKey.String(key))
 			var err error
+			defer func() { tracing.End(span, err) }()
 			if update.Delete {
 				err = r.cache.GenerateNewSnapshot(key, nil)
 			} else if val != nil && val.XdsResources != nil {
@@ -177,22 +256,83 @@ func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *xd
 	r.Logger.Info("subscriber shutting down")
 }
 
+// publishXdsStatus publishes the status of the xDS resources of an IR key reported
+// by the proxies, so the rejected updates are surfaced in the status of the Gateways.
+func (r *Runner) publishXdsStatus(irKey string, status *xdstypes.XdsStatus) {
+	if r.ProviderResources == nil {
+		return
+	}
+	if len(status.NACKs) == 0 {
+		r.ProviderResources.XdsStatuses.Delete(irKey)
+		return
+	}
+	r.Logger.Info("envoy rejected the xds resources", "ir-key", irKey, "message", status.Message())
+	r.ProviderResources.XdsStatuses.Store(irKey, status)
+}
+
+// rolloutOptions returns the settings of the staged rollout of the snapshots, or nil if disabled.
+func rolloutOptions(rollout *egv1a1.XDSRollout) *cache.RolloutOptions {
+	if rollout == nil {
+		return nil
+	}
+	opts := &cache.RolloutOptions{
+		CanaryPercentage: rollout.CanaryPercentage,
+		BakeDuration:     rollout.GetBakeDuration(),
+		MaxErrorRate:     -1,
+	}
+	if rollout.MaxErrorRatePercent != nil {
+		opts.MaxErrorRate = float64(*rollout.MaxErrorRatePercent) / 100
+	}
+	return opts
+}
+
+// serveRollouts serves the status of the staged rollouts of the snapshots as JSON.
+func (r *Runner) serveRollouts(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(r.cache.Rollouts()); err != nil {
+		r.Logger.Error(err, "failed to write the rollouts")
+	}
+}
+
+// serveProxies serves the status of the connected proxies as JSON.
+func (r *Runner) serveProxies(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(r.cache.Proxies()); err != nil {
+		r.Logger.Error(err, "failed to write the proxies")
+	}
+}
+
 func (r *Runner) loadTLSConfig() (tlsConfig *tls.Config, err error) {
+	var certPath, keyPath, caPath string
 	switch {
 	case r.EnvoyGateway.Provider.IsRunningOnKubernetes():
-		tlsConfig, err = crypto.LoadTLSConfig(xdsTLSCertFilepath, xdsTLSKeyFilepath, xdsTLSCaFilepath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create tls config: %w", err)
-		}
+		certPath, keyPath, caPath = xdsTLSCertFilepath, xdsTLSKeyFilepath, xdsTLSCaFilepath
 
 	case r.EnvoyGateway.Provider.IsRunningOnHost():
-		tlsConfig, err = crypto.LoadTLSConfig(localTLSCertFilepath, localTLSKeyFilepath, localTLSCaFilepath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create tls config: %w", err)
-		}
+		certsDir := r.EnvoyGateway.Provider.GetHostInfrastructureProvider().GetCertificatesDir()
+		certPaths := crypto.GetLocalCertPaths(certsDir, crypto.LocalEnvoyGatewayDir)
+		certPath, keyPath, caPath = certPaths.TLSCert, certPaths.TLSKey, certPaths.CACert
+	}
 
-	default:
+	// The configured paths override the ones of the provider.
+	if paths := r.EnvoyGateway.XDSServer.GetTLS(); paths != nil {
+		if paths.CertPath != nil {
+			certPath = *paths.CertPath
+		}
+		if paths.KeyPath != nil {
+			keyPath = *paths.KeyPath
+		}
+		if paths.CAPath != nil {
+			caPath = *paths.CAPath
+		}
+	}
+	if certPath == "" || keyPath == "" || caPath == "" {
 		return nil, fmt.Errorf("no valid tls certificates")
 	}
+
+	tlsConfig, err = crypto.LoadTLSConfig(certPath, keyPath, caPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tls config: %w", err)
+	}
 	return
 }
@@ -23,7 +23,9 @@ import (
 	"github.com/tsaarni/certyaml"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"k8s.io/utils/ptr"
 
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
@@ -203,3 +205,74 @@ func TestServeXdsServerListenFailed(t *testing.T) {
 	// Don't crash in this function
 	r.serveXdsServer(context.Background())
 }
+
+func TestLoadTLSConfigFromConfiguredPaths(t *testing.T) {
+	caCert := certyaml.Certificate{
+		Subject: "cn=ca",
+	}
+	serverCert := certyaml.Certificate{
+		Subject:         "cn=eg",
+		SubjectAltNames: []string{"DNS:localhost"},
+		Issuer:          &caCert,
+	}
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, caCert.WritePEM(caFile, filepath.Join(dir, "ca.key")))
+	require.NoError(t, serverCert.WritePEM(certFile, keyFile))
+
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+	cfg.EnvoyGateway.XDSServer = &egv1a1.EnvoyGatewayXDSServer{
+		TLS: &egv1a1.XDSServerTLS{
+			CertPath: ptr.To(certFile),
+			KeyPath:  ptr.To(keyFile),
+			CAPath:   ptr.To(caFile),
+		},
+	}
+	r := New(&Config{Server: *cfg})
+
+	tlsConfig, err := r.loadTLSConfig()
+	require.NoError(t, err)
+	require.NotNil(t, tlsConfig)
+}
+
+func TestListenUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xds", "xds.sock")
+	cfg, err := config.New(os.Stdout)
+	require.NoError(t, err)
+	cfg.EnvoyGateway.XDSServer = &egv1a1.EnvoyGatewayXDSServer{
+		UnixSocket: &egv1a1.XDSUnixSocket{
+			Path: path,
+			Mode: ptr.To("0660"),
+		},
+	}
+	r := New(&Config{Server: *cfg})
+
+	for range 2 {
+		// The socket left behind by the previous listener is replaced.
+		l, err := r.listen()
+		require.NoError(t, err)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, os.ModeSocket, info.Mode().Type())
+		require.Equal(t, os.FileMode(0o660), info.Mode().Perm())
+
+		conn, err := net.Dial("unix", path)
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+
+		// Leave the socket file behind, like a crashed process would.
+		require.NoError(t, l.(*unixSocketListener).Listener.Close())
+	}
+
+	// Only the socket is left in its directory, and it's removed when the listener is closed.
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	l, err := r.listen()
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+	require.NoFileExists(t, path)
+}
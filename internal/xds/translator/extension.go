@@ -46,24 +46,24 @@ func processExtensionPostRouteHook(route *routev3.Route, vHost *routev3.VirtualH
 	for refIdx, ref := range irRoute.ExtensionRefs {
 		unstructuredResources[refIdx] = ref.Object
 	}
-	modifiedRoute, err := extRouteHookClient.PostRouteModifyHook(
+	modifiedRoute, hookErr := extRouteHookClient.PostRouteModifyHook(
 		route,
 		vHost.Domains,
 		unstructuredResources,
 	)
-	if err != nil {
+	if hookErr != nil && !extensionTypes.IsFailOpenError(hookErr) {
 		// Maybe logging the error is better here, but this only happens when an extension is in-use
 		// so if modification fails then we should probably treat that as a serious problem.
-		return err
+		return hookErr
 	}
 
-	// If the extension returned a modified Route, then copy its to the one that was passed in as a reference
+	// If the extensions returned a modified Route, then copy its to the one that was passed in as a reference
 	if modifiedRoute != nil {
 		if err = deepCopyPtr(modifiedRoute, route); err != nil {
 			return err
 		}
 	}
-	return nil
+	return hookErr
 }
 
 func processExtensionPostVHostHook(vHost *routev3.VirtualHost, em *extensionTypes.Manager) error {
@@ -81,21 +81,21 @@ func processExtensionPostVHostHook(vHost *routev3.VirtualHost, em *extensionType
 	if extVHHookClient == nil {
 		return nil
 	}
-	modifiedVH, err := extVHHookClient.PostVirtualHostModifyHook(vHost)
-	if err != nil {
+	modifiedVH, hookErr := extVHHookClient.PostVirtualHostModifyHook(vHost)
+	if hookErr != nil && !extensionTypes.IsFailOpenError(hookErr) {
 		// Maybe logging the error is better here, but this only happens when an extension is in-use
 		// so if modification fails then we should probably treat that as a serious problem.
-		return err
+		return hookErr
 	}
 
-	// If the extension returned a modified Virtual Host, then copy its to the one that was passed in as a reference
+	// If the extensions returned a modified Virtual Host, then copy its to the one that was passed in as a reference
 	if modifiedVH != nil {
 		if err = deepCopyPtr(modifiedVH, vHost); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return hookErr
 }
 
 func processExtensionPostListenerHook(tCtx *types.ResourceVersionTable, xdsListener *listenerv3.Listener, extensionRefs []*ir.UnstructuredRef, em *extensionTypes.Manager) error {
@@ -115,10 +115,11 @@ func processExtensionPostListenerHook(tCtx *types.ResourceVersionTable, xdsListe
 		for refIdx, ref := range extensionRefs {
 			unstructuredResources[refIdx] = ref.Object
 		}
-		modifiedListener, err := extListenerHookClient.PostHTTPListenerModifyHook(xdsListener, unstructuredResources)
-		if err != nil {
-			return err
-		} else if modifiedListener != nil {
+		modifiedListener, hookErr := extListenerHookClient.PostHTTPListenerModifyHook(xdsListener, unstructuredResources)
+		if hookErr != nil && !extensionTypes.IsFailOpenError(hookErr) {
+			return hookErr
+		}
+		if modifiedListener != nil {
 			// Use the resource table to update the listener with the modified version returned by the extension
 			// We're assuming that Listener names are unique.
 			if err := tCtx.AddOrReplaceXdsResource(resourcev3.ListenerType, modifiedListener, func(existing resourceTypes.Resource, new resourceTypes.Resource) bool {
@@ -135,6 +136,7 @@ func processExtensionPostListenerHook(tCtx *types.ResourceVersionTable, xdsListe
 				return err
 			}
 		}
+		return hookErr
 	}
 	return nil
 }
@@ -169,9 +171,11 @@ func processExtensionPostTranslationHook(tCtx *types.ResourceVersionTable, em *e
 		oldSecrets[idx] = secret.(*tlsv3.Secret)
 	}
 
-	newClusters, newSecrets, err := extensionInsertHookClient.PostTranslateModifyHook(oldClusters, oldSecrets)
-	if err != nil {
-		return err
+	newClusters, newSecrets, hookErr := extensionInsertHookClient.PostTranslateModifyHook(oldClusters, oldSecrets)
+	// The resources returned along with an error of the extensions configured to fail open
+	// include the changes of the other extensions.
+	if hookErr != nil && (!extensionTypes.IsFailOpenError(hookErr) || (newClusters == nil && newSecrets == nil)) {
+		return hookErr
 	}
 
 	clusterResources := make([]resourceTypes.Resource, len(newClusters))
@@ -187,7 +191,95 @@ func processExtensionPostTranslationHook(tCtx *types.ResourceVersionTable, em *e
 
 	tCtx.SetResources(resourcev3.SecretType, secretResources)
 
-	return nil
+	return hookErr
+}
+
+func processExtensionPostClusterHook(cluster *clusterv3.Cluster, irRoute *ir.HTTPRoute, settings []*ir.DestinationSetting, em *extensionTypes.Manager) error {
+	// Do nothing unless there is an extension manager
+	if em == nil {
+		return nil
+	}
+
+	// Check if an extension want to modify the cluster that was just configured/created
+	extManager := *em
+	extClusterHookClient, err := extManager.GetPostXDSHookClient(egv1a1.XDSCluster)
+	if err != nil {
+		return err
+	}
+	if extClusterHookClient == nil {
+		return nil
+	}
+
+	backends := make([]*ir.ResourceMetadata, 0, len(settings))
+	for _, setting := range settings {
+		if setting.Metadata != nil {
+			backends = append(backends, setting.Metadata)
+		}
+	}
+	// The clusters which are not generated for the backends of an HTTP route have no route and policy.
+	var routeMetadata, policyMetadata *ir.ResourceMetadata
+	if irRoute != nil {
+		routeMetadata = irRoute.Metadata
+		if irRoute.Traffic != nil {
+			policyMetadata = irRoute.Traffic.Metadata
+		}
+	}
+	modifiedCluster, hookErr := extClusterHookClient.PostClusterModifyHook(
+		cluster,
+		routeMetadata,
+		backends,
+		policyMetadata,
+	)
+	if hookErr != nil && !extensionTypes.IsFailOpenError(hookErr) {
+		return hookErr
+	}
+
+	// If the extensions returned a modified Cluster, then copy its to the one that was passed in as a reference
+	if modifiedCluster != nil {
+		if err = deepCopyPtr(modifiedCluster, cluster); err != nil {
+			return err
+		}
+	}
+	return hookErr
+}
+
+// processExtensionPreXDSTranslateHook returns the IR modified by the extensions before it is translated,
+// or the given IR if it wasn't modified.
+func processExtensionPreXDSTranslateHook(irKey string, xdsIR *ir.Xds, em *extensionTypes.Manager) (*ir.Xds, error) {
+	// Do nothing unless there is an extension manager
+	if em == nil {
+		return xdsIR, nil
+	}
+
+	// Check if an extension want to modify the IR before it is translated
+	extManager := *em
+	extIRHookClient, err := extManager.GetPreXDSHookClient(egv1a1.XDSIR)
+	if err != nil {
+		return xdsIR, err
+	}
+	if extIRHookClient == nil {
+		return xdsIR, nil
+	}
+
+	modifiedIR, hookErr := extIRHookClient.PreXDSTranslateHook(irKey, xdsIR)
+	if hookErr != nil && !extensionTypes.IsFailOpenError(hookErr) {
+		return xdsIR, hookErr
+	}
+
+	// The IR returned by the extensions is validated as it didn't go through the Gateway API translator.
+	if modifiedIR != nil {
+		if err = modifiedIR.Validate(); err != nil {
+			return xdsIR, fmt.Errorf("invalid IR returned by the extensions: %w", err)
+		}
+		xdsIR = modifiedIR
+	}
+	return xdsIR, hookErr
+}
+
+// extensionFailOpen returns true if the error returned by the extension hooks should be ignored,
+// keeping the resources as they were before the extension servers were called.
+func extensionFailOpen(em *extensionTypes.Manager, err error) bool {
+	return em != nil && ((*em).FailOpen() || extensionTypes.IsFailOpenError(err))
 }
 
 func deepCopyPtr(src interface{}, dest interface{}) error {
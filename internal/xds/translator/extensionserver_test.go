@@ -7,6 +7,7 @@ package translator
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -19,8 +20,11 @@ import (
 	tlsV3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
 
+	"github.com/envoyproxy/gateway/internal/ir"
 	pb "github.com/envoyproxy/gateway/proto/extension"
 )
 
@@ -281,3 +285,79 @@ func (t *testingExtensionServer) PostTranslateModify(_ context.Context, req *pb.
 
 	return response, nil
 }
+
+// PostClusterModify records the resources a cluster was generated from in the metadata of the clusters
+// of the backends named "extension-cluster-backend"
+func (t *testingExtensionServer) PostClusterModify(_ context.Context, req *pb.PostClusterModifyRequest) (*pb.PostClusterModifyResponse, error) {
+	// This simulates an extension server that returns an error. It allows verifying that fail-close is working.
+	if req.PostClusterContext.Route.GetName() == "extension-cluster-error" {
+		return nil, fmt.Errorf("extension post xds cluster hook error")
+	}
+
+	for _, backend := range req.PostClusterContext.Backends {
+		if backend.Name != "extension-cluster-backend" {
+			continue
+		}
+		fields := map[string]any{
+			"backend": backend.Kind + "/" + backend.Namespace + "/" + backend.Name,
+		}
+		if route := req.PostClusterContext.Route; route != nil {
+			fields["route"] = route.Namespace + "/" + route.Name
+		}
+		if btp := req.PostClusterContext.BackendTrafficPolicy; btp != nil {
+			fields["backendTrafficPolicy"] = btp.Namespace + "/" + btp.Name
+		}
+		metadata, err := structpb.NewStruct(fields)
+		if err != nil {
+			return nil, err
+		}
+		cluster := proto.Clone(req.Cluster).(*clusterV3.Cluster)
+		cluster.Metadata = &coreV3.Metadata{
+			FilterMetadata: map[string]*structpb.Struct{
+				"mock-extension": metadata,
+			},
+		}
+		return &pb.PostClusterModifyResponse{Cluster: cluster}, nil
+	}
+	return &pb.PostClusterModifyResponse{}, nil
+}
+
+// PreXDSTranslate injects a direct response route into the listeners named "extension-ir-listener"
+func (t *testingExtensionServer) PreXDSTranslate(_ context.Context, req *pb.PreXDSTranslateRequest) (*pb.PreXDSTranslateResponse, error) {
+	xdsIR := &ir.Xds{}
+	if err := json.Unmarshal(req.Ir, xdsIR); err != nil {
+		return nil, err
+	}
+
+	modified := false
+	for _, listener := range xdsIR.HTTP {
+		// This simulates an extension server that returns an error. It allows verifying that fail-close is working.
+		if listener.Name == "extension-ir-error" {
+			return nil, fmt.Errorf("extension pre xds translate hook error")
+		}
+		if listener.Name != "extension-ir-listener" {
+			continue
+		}
+		listener.Routes = append(listener.Routes, &ir.HTTPRoute{
+			Name:     "mock-extension-injected-route",
+			Hostname: "*",
+			PathMatch: &ir.StringMatch{
+				Prefix: ptr.To("/extension"),
+			},
+			DirectResponse: &ir.CustomResponse{
+				Body:       ptr.To("injected by the extension"),
+				StatusCode: ptr.To(uint32(200)),
+			},
+		})
+		modified = true
+	}
+	if !modified {
+		return &pb.PreXDSTranslateResponse{}, nil
+	}
+
+	irBytes, err := json.Marshal(xdsIR)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PreXDSTranslateResponse{Ir: irBytes, IrVersion: req.IrVersion}, nil
+}
@@ -7,6 +7,7 @@ package translator
 
 import (
 	"bytes"
+	"cmp"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -643,14 +644,14 @@ func buildRateLimitServiceDescriptors(route *ir.HTTPRoute) []*rlsconfv3.RateLimi
 }
 
 // buildRateLimitTLSocket builds the TLS socket for the rate limit service.
-func buildRateLimitTLSocket() (*corev3.TransportSocket, error) {
+func buildRateLimitTLSocket(settings *GlobalRateLimitSettings) (*corev3.TransportSocket, error) {
 	tlsCtx := &tlsv3.UpstreamTlsContext{
 		CommonTlsContext: &tlsv3.CommonTlsContext{
 			TlsCertificates: []*tlsv3.TlsCertificate{},
 			ValidationContextType: &tlsv3.CommonTlsContext_ValidationContext{
 				ValidationContext: &tlsv3.CertificateValidationContext{
 					TrustedCa: &corev3.DataSource{
-						Specifier: &corev3.DataSource_Filename{Filename: rateLimitClientTLSCACertFilename},
+						Specifier: &corev3.DataSource_Filename{Filename: cmp.Or(settings.TLSCACertFilename, rateLimitClientTLSCACertFilename)},
 					},
 				},
 			},
@@ -659,10 +660,10 @@ func buildRateLimitTLSocket() (*corev3.TransportSocket, error) {
 
 	tlsCert := &tlsv3.TlsCertificate{
 		CertificateChain: &corev3.DataSource{
-			Specifier: &corev3.DataSource_Filename{Filename: rateLimitClientTLSCertFilename},
+			Specifier: &corev3.DataSource_Filename{Filename: cmp.Or(settings.TLSCertFilename, rateLimitClientTLSCertFilename)},
 		},
 		PrivateKey: &corev3.DataSource{
-			Specifier: &corev3.DataSource_Filename{Filename: rateLimitClientTLSKeyFilename},
+			Specifier: &corev3.DataSource_Filename{Filename: cmp.Or(settings.TLSKeyFilename, rateLimitClientTLSKeyFilename)},
 		},
 	}
 	tlsCtx.CommonTlsContext.TlsCertificates = append(tlsCtx.CommonTlsContext.TlsCertificates, tlsCert)
@@ -695,7 +696,7 @@ func (t *Translator) createRateLimitServiceCluster(tCtx *types.ResourceVersionTa
 		Name:      destinationSettingName(clusterName),
 	}
 
-	tSocket, err := buildRateLimitTLSocket()
+	tSocket, err := buildRateLimitTLSocket(t.GlobalRateLimit)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,57 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"testing"
+
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRateLimitTLSocket(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings *GlobalRateLimitSettings
+		wantCert string
+		wantKey  string
+		wantCA   string
+	}{
+		{
+			name:     "default certificates",
+			settings: &GlobalRateLimitSettings{},
+			wantCert: rateLimitClientTLSCertFilename,
+			wantKey:  rateLimitClientTLSKeyFilename,
+			wantCA:   rateLimitClientTLSCACertFilename,
+		},
+		{
+			name: "custom certificates",
+			settings: &GlobalRateLimitSettings{
+				TLSCertFilename:   "/tmp/envoy-gateway/certs/envoy/tls.crt",
+				TLSKeyFilename:    "/tmp/envoy-gateway/certs/envoy/tls.key",
+				TLSCACertFilename: "/tmp/envoy-gateway/certs/envoy/ca.crt",
+			},
+			wantCert: "/tmp/envoy-gateway/certs/envoy/tls.crt",
+			wantKey:  "/tmp/envoy-gateway/certs/envoy/tls.key",
+			wantCA:   "/tmp/envoy-gateway/certs/envoy/ca.crt",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			socket, err := buildRateLimitTLSocket(tc.settings)
+			require.NoError(t, err)
+
+			tlsCtx := &tlsv3.UpstreamTlsContext{}
+			require.NoError(t, socket.GetTypedConfig().UnmarshalTo(tlsCtx))
+			commonCtx := tlsCtx.CommonTlsContext
+			require.Len(t, commonCtx.TlsCertificates, 1)
+			require.Equal(t, tc.wantCert, commonCtx.TlsCertificates[0].CertificateChain.GetFilename())
+			require.Equal(t, tc.wantKey, commonCtx.TlsCertificates[0].PrivateKey.GetFilename())
+			require.Equal(t, tc.wantCA, commonCtx.GetValidationContext().TrustedCa.GetFilename())
+		})
+	}
+}
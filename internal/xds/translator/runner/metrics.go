@@ -0,0 +1,18 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import "github.com/envoyproxy/gateway/internal/metrics"
+
+var (
+	xdsTranslationDurationSeconds = metrics.NewHistogram(
+		"xds_translation_duration_seconds",
+		"How long in seconds the IR of a Gateway takes to be translated to xds resources, including the extension hooks.",
+		[]float64{0.001, 0.01, 0.1, 1, 5, 10, 30},
+	)
+
+	irKeyLabel = metrics.NewLabel("irKey")
+)
@@ -8,16 +8,19 @@ package runner
 import (
 	"context"
 	"reflect"
+	"time"
 
 	"github.com/telepresenceio/watchable"
 	ktypes "k8s.io/apimachinery/pkg/types"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/extension/registry"
 	extension "github.com/envoyproxy/gateway/internal/extension/types"
-	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/ratelimit"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/tracing"
 	"github.com/envoyproxy/gateway/internal/xds/translator"
 )
 
@@ -27,6 +30,9 @@ type Config struct {
 	Xds               *message.Xds
 	ExtensionManager  extension.Manager
 	ProviderResources *message.ProviderResources
+	// RateLimitServiceURL is the URL of the global rate limit service, which is
+	// deployed by the infrastructure provider.
+	RateLimitServiceURL string
 }
 
 type Runner struct {
@@ -63,32 +69,50 @@ func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *ir
 			val := update.Value
 
 			if update.Delete {
+				tracing.Forget(tracing.XdsMessage, key)
 				r.Xds.Delete(key)
 			} else {
+				ctx, span := tracing.Start(tracing.Subscribe(context.Background(), tracing.XdsIRMessage, key),
+					"xds-translate", tracing.IRKeyKey.String(key))
+				defer span.End()
+				start := time.Now()
+
 				// Translate to xds resources
 				t := &translator.Translator{
 					FilterOrder: val.FilterOrder,
+					IRKey:       key,
 				}
 
 				// Set the extension manager if an extension is loaded
 				if r.ExtensionManager != nil {
-					t.ExtensionManager = &r.ExtensionManager
+					extensionManager := registry.NewTracingManager(ctx, r.ExtensionManager)
+					t.ExtensionManager = &extensionManager
 				}
 
 				// Set the rate limit service URL if global rate limiting is enabled.
 				if r.EnvoyGateway.RateLimit != nil {
 					t.GlobalRateLimit = &translator.GlobalRateLimitSettings{
-						ServiceURL: ratelimit.GetServiceURL(r.Namespace, r.DNSDomain),
+						ServiceURL: r.RateLimitServiceURL,
 						FailClosed: r.EnvoyGateway.RateLimit.FailClosed,
 					}
 					if r.EnvoyGateway.RateLimit.Timeout != nil {
 						t.GlobalRateLimit.Timeout = r.EnvoyGateway.RateLimit.Timeout.Duration
 					}
+					// The rate limit service run on the host is connected to with the local certificates.
+					if r.EnvoyGateway.Provider != nil && r.EnvoyGateway.Provider.IsRunningOnHost() {
+						hostProvider := r.EnvoyGateway.Provider.GetHostInfrastructureProvider()
+						certPaths := crypto.GetLocalCertPaths(hostProvider.GetCertificatesDir(), crypto.LocalEnvoyDir)
+						t.GlobalRateLimit.TLSCertFilename = certPaths.TLSCert
+						t.GlobalRateLimit.TLSKeyFilename = certPaths.TLSKey
+						t.GlobalRateLimit.TLSCACertFilename = certPaths.CACert
+					}
 				}
 
 				result, err := t.Translate(val)
+				xdsTranslationDurationSeconds.With(irKeyLabel.Value(key)).Record(time.Since(start).Seconds())
 				if err != nil {
 					r.Logger.Error(err, "failed to translate xds ir")
+					tracing.RecordError(span, err)
 					errChan <- err
 				}
 
@@ -125,6 +149,7 @@ func (r *Runner) subscribeAndTranslate(sub <-chan watchable.Snapshot[string, *ir
 				result.EnvoyPatchPolicyStatuses = nil
 
 				// Publish
+				tracing.Publish(ctx, tracing.XdsMessage, key)
 				r.Xds.Store(key, result)
 
 				// Delete all the deletable status keys
@@ -175,6 +175,10 @@ type extManagerMock struct {
 	types.Manager
 }
 
+func (m *extManagerMock) GetPreXDSHookClient(egv1a1.XDSTranslatorHook) (types.XDSHookClient, error) {
+	return nil, nil
+}
+
 func (m *extManagerMock) GetPostXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) (types.XDSHookClient, error) {
 	if xdsHookType == egv1a1.XDSHTTPListener {
 		return &xdsHookClientMock{}, nil
@@ -55,6 +55,9 @@ type Translator struct {
 
 	// FilterOrder holds the custom order of the HTTP filters
 	FilterOrder []egv1a1.FilterPosition
+
+	// IRKey is the key of the IR being translated, which is passed to the extensions.
+	IRKey string
 }
 
 type GlobalRateLimitSettings struct {
@@ -69,6 +72,13 @@ type GlobalRateLimitSettings struct {
 	// FailClosed is a switch used to control the flow of traffic
 	// when the response from the ratelimit server cannot be obtained.
 	FailClosed bool
+
+	// TLSCertFilename, TLSKeyFilename and TLSCACertFilename are the paths of the
+	// certificate files used by the proxy to connect to the rate limit service.
+	// If unset, the paths of the certificates mounted into the proxy pods are used.
+	TLSCertFilename   string
+	TLSKeyFilename    string
+	TLSCACertFilename string
 }
 
 // Translate translates the XDS IR into xDS resources
@@ -90,6 +100,13 @@ func (t *Translator) Translate(xdsIR *ir.Xds) (*types.ResourceVersionTable, erro
 	// to collect all errors and reflect them in the status of the CRDs.
 	var errs error
 
+	// Let the extensions modify the IR before it is translated.
+	// If no extension exists (or it doesn't subscribe to this hook) then this is a quick no-op
+	xdsIR, preHookErr := processExtensionPreXDSTranslateHook(t.IRKey, xdsIR, t.ExtensionManager)
+	if preHookErr != nil {
+		errs = errors.Join(errs, preHookErr)
+	}
+
 	if err := t.processHTTPReadyListenerXdsTranslation(tCtx, xdsIR.ReadyListener); err != nil {
 		errs = errors.Join(errs, err)
 	}
@@ -111,28 +128,35 @@ func (t *Translator) Translate(xdsIR *ir.Xds) (*types.ResourceVersionTable, erro
 		errs = errors.Join(errs, err)
 	}
 
-	if err := processJSONPatches(tCtx, xdsIR.EnvoyPatchPolicies); err != nil {
+	if err := processClusterForAccessLog(tCtx, xdsIR.AccessLog, xdsIR.Metrics); err != nil {
 		errs = errors.Join(errs, err)
 	}
 
-	if err := processClusterForAccessLog(tCtx, xdsIR.AccessLog, xdsIR.Metrics); err != nil {
+	if err := processClusterForTracing(tCtx, xdsIR.Tracing, xdsIR.Metrics); err != nil {
 		errs = errors.Join(errs, err)
 	}
 
-	if err := processClusterForTracing(tCtx, xdsIR.Tracing, xdsIR.Metrics); err != nil {
+	// The clusters are complete once the access log and tracing clusters are added.
+	if err := t.notifyExtensionServerAboutClusters(tCtx, xdsIR); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if err := processJSONPatches(tCtx, xdsIR.EnvoyPatchPolicies); err != nil {
 		errs = errors.Join(errs, err)
 	}
 
 	// Check if an extension want to inject any clusters/secrets
 	// If no extension exists (or it doesn't subscribe to this hook) then this is a quick no-op
-	if err := processExtensionPostTranslationHook(tCtx, t.ExtensionManager); err != nil {
+	err := processExtensionPostTranslationHook(tCtx, t.ExtensionManager)
+	if err != nil {
 		errs = errors.Join(errs, err)
-		// Setting the configuration to fail open will mean that Envoy Gateway ignores the error and keeps the resources
-		// as they were before the extension server was called.
-		if t.ExtensionManager != nil && !(*t.ExtensionManager).FailOpen() {
-			for _, listener := range tCtx.XdsResources[resourcev3.ListenerType] {
-				errs = errors.Join(errs, clearListenerRoutes(listener.(*listenerv3.Listener)))
-			}
+	}
+	// Setting the configuration to fail open will mean that Envoy Gateway ignores the error and keeps the resources
+	// as they were before the extension server was called.
+	if (err != nil && !extensionFailOpen(t.ExtensionManager, err)) ||
+		(preHookErr != nil && !extensionFailOpen(t.ExtensionManager, preHookErr)) {
+		for _, listener := range tCtx.XdsResources[resourcev3.ListenerType] {
+			errs = errors.Join(errs, clearListenerRoutes(listener.(*listenerv3.Listener)))
 		}
 	}
 
@@ -203,7 +227,7 @@ func (t *Translator) notifyExtensionServerAboutListeners(
 			// then replace all of the routes in the virtual host with a single route that returns an InternalServerError result.
 			// Setting the configuration to fail open will mean that Envoy Gateway ignores the error and keeps the routes
 			// as they were before the extension server was called.
-			if !(*t.ExtensionManager).FailOpen() {
+			if !extensionFailOpen(t.ExtensionManager, err) {
 				errs = errors.Join(errs, clearListenerRoutes(listener))
 			}
 		}
@@ -211,6 +235,95 @@ func (t *Translator) notifyExtensionServerAboutListeners(
 	return errs
 }
 
+// notifyExtensionServerAboutClusters calls the extension server about all the clusters translated from
+// the IR. The clusters generated for the backends of a route are passed along with the metadata of the
+// route, its backends and BackendTrafficPolicy, the metadata of the backends of the TCP and UDP routes
+// is passed as well, whereas no metadata is passed for the other clusters, e.g. the clusters of the
+// external authorization, tracing and access log services.
+func (t *Translator) notifyExtensionServerAboutClusters(
+	tCtx *types.ResourceVersionTable,
+	xdsIR *ir.Xds,
+) error {
+	// Return quickly if there is no extension manager or the Cluster hook is not being used.
+	if t.ExtensionManager == nil {
+		return nil
+	}
+	if postHookClient, err := (*t.ExtensionManager).GetPostXDSHookClient(egv1a1.XDSCluster); postHookClient == nil && err == nil {
+		return nil
+	}
+
+	// The same cluster is shared by the routes generated for each hostname of a route rule,
+	// so the metadata of the first route of a cluster is passed.
+	type clusterSource struct {
+		route    *ir.HTTPRoute
+		settings []*ir.DestinationSetting
+	}
+	sources := make(map[string]clusterSource)
+	addSource := func(name string, irRoute *ir.HTTPRoute, settings []*ir.DestinationSetting) {
+		if _, ok := sources[name]; !ok {
+			sources[name] = clusterSource{route: irRoute, settings: settings}
+		}
+	}
+	for _, httpListener := range xdsIR.HTTP {
+		for _, httpRoute := range httpListener.Routes {
+			if httpRoute.Destination != nil {
+				if !needsClusterPerSetting(httpRoute.Destination.Settings) {
+					addSource(httpRoute.Destination.Name, httpRoute, httpRoute.Destination.Settings)
+				} else {
+					for _, setting := range httpRoute.Destination.Settings {
+						addSource(setting.Name, httpRoute, []*ir.DestinationSetting{setting})
+					}
+				}
+			}
+			for _, mrr := range httpRoute.Mirrors {
+				if mrr.Destination != nil {
+					addSource(mrr.Destination.Name, httpRoute, mrr.Destination.Settings)
+				}
+			}
+		}
+	}
+	for _, tcpListener := range xdsIR.TCP {
+		for _, tcpRoute := range tcpListener.Routes {
+			if tcpRoute.Destination != nil {
+				addSource(tcpRoute.Destination.Name, nil, tcpRoute.Destination.Settings)
+			}
+		}
+	}
+	for _, udpListener := range xdsIR.UDP {
+		if udpListener.Route != nil && udpListener.Route.Destination != nil {
+			addSource(udpListener.Route.Destination.Name, nil, udpListener.Route.Destination.Settings)
+		}
+	}
+
+	var errs error
+	for _, resource := range tCtx.XdsResources[resourcev3.ClusterType] {
+		cluster := resource.(*clusterv3.Cluster)
+		source := sources[cluster.Name]
+		if err := processExtensionPostClusterHook(cluster, source.route, source.settings, t.ExtensionManager); err != nil {
+			errs = errors.Join(errs, err)
+			// If the extension server returns an error, and the extension server is not configured to fail open,
+			// then remove all of the endpoints of the cluster such that accessing it returns an unavailable result.
+			// Setting the configuration to fail open will mean that Envoy Gateway ignores the error and keeps the cluster
+			// as it was before the extension server was called.
+			if !extensionFailOpen(t.ExtensionManager, err) {
+				clearClusterEndpoints(tCtx, cluster)
+			}
+		}
+	}
+	return errs
+}
+
+// clearClusterEndpoints removes all of the endpoints of the cluster, whether they are
+// configured inline or discovered through EDS.
+func clearClusterEndpoints(tCtx *types.ResourceVersionTable, cluster *clusterv3.Cluster) {
+	if cluster.LoadAssignment != nil {
+		cluster.LoadAssignment.Endpoints = nil
+	}
+	if endpoint := findXdsEndpoint(tCtx, cluster.Name); endpoint != nil {
+		endpoint.Endpoints = nil
+	}
+}
+
 func clearListenerRoutes(listener *listenerv3.Listener) error {
 	var errs error
 	if listener.DefaultFilterChain != nil {
@@ -556,7 +669,7 @@ func (t *Translator) addRouteToRouteConfig(
 			// then replace the route with one that returns an InternalServerError result.
 			// Setting the configuration to fail open will mean that Envoy Gateway ignores the error and keeps the route
 			// as it was before the extension server was called.
-			if t.ExtensionManager != nil && !(*t.ExtensionManager).FailOpen() {
+			if t.ExtensionManager != nil && !extensionFailOpen(t.ExtensionManager, err) {
 				xdsRoute.Action = &routev3.Route_DirectResponse{DirectResponse: buildXdsDirectResponseAction(&ir.CustomResponse{
 					StatusCode: ptr.To(uint32(http.StatusInternalServerError)),
 				})}
@@ -645,7 +758,7 @@ func (t *Translator) addRouteToRouteConfig(
 			// then replace all of the virtual hosts such that accessing them returns an InternalServerError result.
 			// Setting the configuration to fail open will mean that Envoy Gateway ignores the error and keeps the routes
 			// as they were before the extension server was called.
-			if t.ExtensionManager != nil && !(*t.ExtensionManager).FailOpen() {
+			if t.ExtensionManager != nil && !extensionFailOpen(t.ExtensionManager, err) {
 				vHost.Routes = []*routev3.Route{
 					{
 						Name: "error_route",
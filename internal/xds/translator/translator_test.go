@@ -253,6 +253,12 @@ func TestTranslateXdsWithExtension(t *testing.T) {
 		"http-route-extension-translate-error": {
 			errMsg: "rpc error: code = Unknown desc = cluster hook resource error: fail-close-error",
 		},
+		"http-route-extension-ir-error": {
+			errMsg: "rpc error: code = Unknown desc = extension pre xds translate hook error",
+		},
+		"http-route-extension-cluster-error": {
+			errMsg: "rpc error: code = Unknown desc = extension post xds cluster hook error",
+		},
 		"multiple-listeners-same-port-error": {
 			errMsg: "rpc error: code = Unknown desc = simulate error when there is no default filter chain in the original resources",
 		},
@@ -299,11 +305,15 @@ func TestTranslateXdsWithExtension(t *testing.T) {
 				},
 				Hooks: &egv1a1.ExtensionHooks{
 					XDSTranslator: &egv1a1.XDSTranslatorHooks{
+						Pre: []egv1a1.XDSTranslatorHook{
+							egv1a1.XDSIR,
+						},
 						Post: []egv1a1.XDSTranslatorHook{
 							egv1a1.XDSRoute,
 							egv1a1.XDSVirtualHost,
 							egv1a1.XDSHTTPListener,
 							egv1a1.XDSTranslation,
+							egv1a1.XDSCluster,
 						},
 					},
 				},
@@ -0,0 +1,41 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package types
+
+import "time"
+
+// ProxyStatus is the status of a proxy connected to the xDS server.
+type ProxyStatus struct {
+	// NodeID is the ID of the proxy.
+	NodeID string `json:"nodeID" yaml:"nodeID"`
+	// IRKey is the key of the IR whose snapshot is served to the proxy, which is the
+	// cluster of the node.
+	IRKey string `json:"irKey" yaml:"irKey"`
+	// BuildVersion is the Envoy version of the proxy, e.g. v1.31.0.
+	BuildVersion string `json:"buildVersion,omitempty" yaml:"buildVersion,omitempty"`
+	// Address is the IP address the proxy connected from.
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	// ConnectTime is when the oldest xDS stream of the proxy was opened.
+	ConnectTime time.Time `json:"connectTime" yaml:"connectTime"`
+	// Streams is the number of xDS streams opened by the proxy.
+	Streams int `json:"streams" yaml:"streams"`
+	// AckedVersions are the versions of the last updates acknowledged by the proxy, by type URL.
+	AckedVersions map[string]string `json:"ackedVersions,omitempty" yaml:"ackedVersions,omitempty"`
+	// ServedVersions are the versions of the snapshot the proxy is expected to run, by type URL.
+	ServedVersions map[string]string `json:"servedVersions,omitempty" yaml:"servedVersions,omitempty"`
+	// Synced is true if the proxy acknowledged the versions of the snapshot it is expected to run.
+	Synced bool `json:"synced" yaml:"synced"`
+	// LastNACK is the last update rejected by the proxy since it connected, even if a later
+	// update was acknowledged.
+	LastNACK *ProxyNACK `json:"lastNACK,omitempty" yaml:"lastNACK,omitempty"`
+}
+
+// ProxyNACK is an update rejected by a proxy, and when it was rejected.
+type ProxyNACK struct {
+	NACK `json:",inline" yaml:",inline"`
+	// Time is when the proxy rejected the update.
+	Time time.Time `json:"time" yaml:"time"`
+}
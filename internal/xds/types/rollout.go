@@ -0,0 +1,37 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package types
+
+import "time"
+
+// RolloutPhase is the phase of the staged rollout of an xDS snapshot.
+type RolloutPhase string
+
+const (
+	// RolloutPhaseBaking means the snapshot is served to the canary proxies only.
+	RolloutPhaseBaking RolloutPhase = "Baking"
+	// RolloutPhaseHeld means the canary proxies rejected the snapshot or exceeded the
+	// maximum error rate, the other proxies keep their snapshot.
+	RolloutPhaseHeld RolloutPhase = "Held"
+	// RolloutPhaseCompleted means the snapshot is served to all the proxies.
+	RolloutPhaseCompleted RolloutPhase = "Completed"
+)
+
+// RolloutStatus is the status of the staged rollout of the latest xDS snapshot of an IR key.
+type RolloutStatus struct {
+	// IRKey is the key of the IR the snapshot was translated from.
+	IRKey string `json:"irKey" yaml:"irKey"`
+	// Versions are the versions of the snapshot by type URL.
+	Versions map[string]string `json:"versions,omitempty" yaml:"versions,omitempty"`
+	// Phase is the phase of the rollout.
+	Phase RolloutPhase `json:"phase" yaml:"phase"`
+	// Canaries are the IDs of the proxies which received the snapshot first.
+	Canaries []string `json:"canaries,omitempty" yaml:"canaries,omitempty"`
+	// StartTime is when the snapshot was pushed to the canary proxies.
+	StartTime time.Time `json:"startTime" yaml:"startTime"`
+	// Reason explains why the rollout is held.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
@@ -0,0 +1,52 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package types
+
+import (
+	"fmt"
+	"slices"
+)
+
+// XdsStatus is the status of the xDS resources of an IR key, as reported by the proxies.
+type XdsStatus struct {
+	// NACKs are the xDS updates currently rejected by the proxies, sorted by node ID and type URL.
+	NACKs []NACK `json:"nacks,omitempty" yaml:"nacks,omitempty"`
+}
+
+// NACK is an xDS update of a resource type which was rejected by a proxy.
+type NACK struct {
+	// NodeID is the ID of the proxy which rejected the update.
+	NodeID string `json:"nodeID" yaml:"nodeID"`
+	// TypeURL is the type URL of the rejected resources.
+	TypeURL string `json:"typeURL" yaml:"typeURL"`
+	// Version is the version of the rejected update.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	// Message is the error message reported by the proxy.
+	Message string `json:"message" yaml:"message"`
+}
+
+// DeepCopy returns a deep copy of the status.
+func (s *XdsStatus) DeepCopy() *XdsStatus {
+	if s == nil {
+		return nil
+	}
+	return &XdsStatus{NACKs: slices.Clone(s.NACKs)}
+}
+
+// Message returns a human-readable summary of the rejected updates, or an empty string if
+// none of the updates is rejected.
+func (s *XdsStatus) Message() string {
+	if s == nil || len(s.NACKs) == 0 {
+		return ""
+	}
+
+	first := s.NACKs[0]
+	msg := fmt.Sprintf("Envoy proxy %s rejected the %s resources: %s", first.NodeID, first.TypeURL, first.Message)
+	if len(s.NACKs) > 1 {
+		msg += fmt.Sprintf(" (and %d more rejections)", len(s.NACKs)-1)
+	}
+	return msg
+}
@@ -28,6 +28,8 @@ const (
 	EnvoyGatewayExtension_PostVirtualHostModify_FullMethodName  = "/envoygateway.extension.EnvoyGatewayExtension/PostVirtualHostModify"
 	EnvoyGatewayExtension_PostHTTPListenerModify_FullMethodName = "/envoygateway.extension.EnvoyGatewayExtension/PostHTTPListenerModify"
 	EnvoyGatewayExtension_PostTranslateModify_FullMethodName    = "/envoygateway.extension.EnvoyGatewayExtension/PostTranslateModify"
+	EnvoyGatewayExtension_PostClusterModify_FullMethodName      = "/envoygateway.extension.EnvoyGatewayExtension/PostClusterModify"
+	EnvoyGatewayExtension_PreXDSTranslate_FullMethodName        = "/envoygateway.extension.EnvoyGatewayExtension/PreXDSTranslate"
 )
 
 // EnvoyGatewayExtensionClient is the client API for EnvoyGatewayExtension service.
@@ -59,6 +61,20 @@ type EnvoyGatewayExtensionClient interface {
 	// The list of clusters and secrets returned by the extension are used as the final list of all clusters and secrets
 	// PostTranslateModify is always executed when an extension is loaded
 	PostTranslateModify(ctx context.Context, in *PostTranslateModifyRequest, opts ...grpc.CallOption) (*PostTranslateModifyResponse, error)
+	// PostClusterModify provides a way for extensions to modify a cluster generated by Envoy Gateway before it is
+	// finalized. It passes the metadata of the route, backends and BackendTrafficPolicy the cluster was generated from,
+	// so extensions can customize the upstream behaviour per backend. The route and policy are empty for the clusters
+	// which are not generated for the backends of an HTTPRoute/GRPCRoute, and so are the backends for the other clusters,
+	// e.g. the clusters of the tracing and access log services.
+	// PostClusterModify is executed for each cluster generated by Envoy Gateway when an extension is loaded with the
+	// Cluster post hook. An extension may return nil to not make any changes to it.
+	PostClusterModify(ctx context.Context, in *PostClusterModifyRequest, opts ...grpc.CallOption) (*PostClusterModifyResponse, error)
+	// PreXDSTranslate allows an extension to modify the IR of a Gateway before it is translated into xDS resources.
+	// The IR is the intermediate representation used by Envoy Gateway, which allows extensions to inject routes or
+	// tweak traffic features without patching the generated Envoy protos. The IR is versioned by ir_version, see
+	// PreXDSTranslateRequest for its compatibility contract.
+	// PreXDSTranslate is always executed when an extension is loaded with the IR pre hook.
+	PreXDSTranslate(ctx context.Context, in *PreXDSTranslateRequest, opts ...grpc.CallOption) (*PreXDSTranslateResponse, error)
 }
 
 type envoyGatewayExtensionClient struct {
@@ -109,6 +125,26 @@ func (c *envoyGatewayExtensionClient) PostTranslateModify(ctx context.Context, i
 	return out, nil
 }
 
+func (c *envoyGatewayExtensionClient) PostClusterModify(ctx context.Context, in *PostClusterModifyRequest, opts ...grpc.CallOption) (*PostClusterModifyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PostClusterModifyResponse)
+	err := c.cc.Invoke(ctx, EnvoyGatewayExtension_PostClusterModify_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *envoyGatewayExtensionClient) PreXDSTranslate(ctx context.Context, in *PreXDSTranslateRequest, opts ...grpc.CallOption) (*PreXDSTranslateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreXDSTranslateResponse)
+	err := c.cc.Invoke(ctx, EnvoyGatewayExtension_PreXDSTranslate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EnvoyGatewayExtensionServer is the server API for EnvoyGatewayExtension service.
 // All implementations must embed UnimplementedEnvoyGatewayExtensionServer
 // for forward compatibility.
@@ -138,6 +174,20 @@ type EnvoyGatewayExtensionServer interface {
 	// The list of clusters and secrets returned by the extension are used as the final list of all clusters and secrets
 	// PostTranslateModify is always executed when an extension is loaded
 	PostTranslateModify(context.Context, *PostTranslateModifyRequest) (*PostTranslateModifyResponse, error)
+	// PostClusterModify provides a way for extensions to modify a cluster generated by Envoy Gateway before it is
+	// finalized. It passes the metadata of the route, backends and BackendTrafficPolicy the cluster was generated from,
+	// so extensions can customize the upstream behaviour per backend. The route and policy are empty for the clusters
+	// which are not generated for the backends of an HTTPRoute/GRPCRoute, and so are the backends for the other clusters,
+	// e.g. the clusters of the tracing and access log services.
+	// PostClusterModify is executed for each cluster generated by Envoy Gateway when an extension is loaded with the
+	// Cluster post hook. An extension may return nil to not make any changes to it.
+	PostClusterModify(context.Context, *PostClusterModifyRequest) (*PostClusterModifyResponse, error)
+	// PreXDSTranslate allows an extension to modify the IR of a Gateway before it is translated into xDS resources.
+	// The IR is the intermediate representation used by Envoy Gateway, which allows extensions to inject routes or
+	// tweak traffic features without patching the generated Envoy protos. The IR is versioned by ir_version, see
+	// PreXDSTranslateRequest for its compatibility contract.
+	// PreXDSTranslate is always executed when an extension is loaded with the IR pre hook.
+	PreXDSTranslate(context.Context, *PreXDSTranslateRequest) (*PreXDSTranslateResponse, error)
 	mustEmbedUnimplementedEnvoyGatewayExtensionServer()
 }
 
@@ -160,6 +210,12 @@ func (UnimplementedEnvoyGatewayExtensionServer) PostHTTPListenerModify(context.C
 func (UnimplementedEnvoyGatewayExtensionServer) PostTranslateModify(context.Context, *PostTranslateModifyRequest) (*PostTranslateModifyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PostTranslateModify not implemented")
 }
+func (UnimplementedEnvoyGatewayExtensionServer) PostClusterModify(context.Context, *PostClusterModifyRequest) (*PostClusterModifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostClusterModify not implemented")
+}
+func (UnimplementedEnvoyGatewayExtensionServer) PreXDSTranslate(context.Context, *PreXDSTranslateRequest) (*PreXDSTranslateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreXDSTranslate not implemented")
+}
 func (UnimplementedEnvoyGatewayExtensionServer) mustEmbedUnimplementedEnvoyGatewayExtensionServer() {}
 func (UnimplementedEnvoyGatewayExtensionServer) testEmbeddedByValue()                               {}
 
@@ -253,6 +309,42 @@ func _EnvoyGatewayExtension_PostTranslateModify_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EnvoyGatewayExtension_PostClusterModify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostClusterModifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvoyGatewayExtensionServer).PostClusterModify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnvoyGatewayExtension_PostClusterModify_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvoyGatewayExtensionServer).PostClusterModify(ctx, req.(*PostClusterModifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvoyGatewayExtension_PreXDSTranslate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreXDSTranslateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvoyGatewayExtensionServer).PreXDSTranslate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EnvoyGatewayExtension_PreXDSTranslate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvoyGatewayExtensionServer).PreXDSTranslate(ctx, req.(*PreXDSTranslateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EnvoyGatewayExtension_ServiceDesc is the grpc.ServiceDesc for EnvoyGatewayExtension service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -276,6 +368,14 @@ var EnvoyGatewayExtension_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PostTranslateModify",
 			Handler:    _EnvoyGatewayExtension_PostTranslateModify_Handler,
 		},
+		{
+			MethodName: "PostClusterModify",
+			Handler:    _EnvoyGatewayExtension_PostClusterModify_Handler,
+		},
+		{
+			MethodName: "PreXDSTranslate",
+			Handler:    _EnvoyGatewayExtension_PreXDSTranslate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/extension/service.proto",